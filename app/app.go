@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/SyntaxErrorLineNULL/common"
+	"github.com/SyntaxErrorLineNULL/common/buildinfo"
+	"github.com/SyntaxErrorLineNULL/common/cli"
+)
+
+// Exit codes returned by the process run with Run.
+const (
+	// ExitOK reports success.
+	ExitOK = 0
+	// ExitFailure reports an error returned by the application.
+	ExitFailure = 1
+	// ExitUsage reports an invalid command line or configuration.
+	ExitUsage = 2
+	// ExitInterrupted reports that the application was stopped by a signal, following the shell
+	// convention of 128 plus the number of SIGINT.
+	ExitInterrupted = 130
+)
+
+// Configurable is implemented by configurations that are filled from command-line flags.
+// The flags must bind to the fields of the configuration.
+type Configurable interface {
+	Flags() []cli.Flag
+}
+
+// ExitCoder is implemented by errors that choose the exit code of the process, such as *exec.ExitError.
+type ExitCoder interface {
+	ExitCode() int
+}
+
+// Options configures Run.
+type Options struct {
+	// Name is the program name shown in the help and the version. When empty, the base name of the executable is used.
+	Name string
+	// Summary is a one-line description shown in the help.
+	Summary string
+	// CrashDir enables crash reports written to this directory when the application panics.
+	CrashDir string
+}
+
+// Run is the entry point of a tool. It parses the configuration from the command line and the environment,
+// sets up the default slog logger, installs the crash handler and cancels the context on SIGINT and SIGTERM.
+// It then runs fn and exits the process with a code derived from its result:
+//
+//	func main() {
+//		app.Run(app.Options{Summary: "Sync the mirrors"}, func(ctx context.Context, cfg *Config) error {
+//			...
+//		})
+//	}
+//
+// Besides the flags of the configuration, every tool accepts --log-level, --log-format and --version.
+// Run does not return.
+func Run[C any](opts Options, fn func(ctx context.Context, cfg *C) error) {
+	os.Exit(execute(context.Background(), opts, os.Args[1:], os.Stdout, os.Stderr, fn))
+}
+
+// execute implements Run, returning the exit code instead of exiting.
+func execute[C any](ctx context.Context, opts Options, args []string, stdout, stderr io.Writer, fn func(context.Context, *C) error) (code int) {
+	name := opts.Name
+	if name == "" {
+		name = filepath.Base(os.Args[0])
+	}
+
+	if opts.CrashDir != "" {
+		common.InstallCrashHandler(opts.CrashDir)
+		defer common.HandleCrash()
+	}
+
+	var (
+		cfg       = new(C)
+		logLevel  string
+		logFormat string
+		version   bool
+	)
+
+	flags := []cli.Flag{
+		&cli.StringFlag{Name: "log-level", Usage: "minimum level of the log messages: debug, info, warn or error", Env: "LOG_LEVEL", Default: "info", Target: &logLevel},
+		&cli.StringFlag{Name: "log-format", Usage: "format of the log messages: text or json", Env: "LOG_FORMAT", Default: "text", Target: &logFormat},
+		&cli.BoolFlag{Name: "version", Usage: "print version information and exit", Target: &version},
+	}
+	if configurable, ok := any(cfg).(Configurable); ok {
+		flags = append(configurable.Flags(), flags...)
+	}
+
+	// Stop on the first signal. A second signal terminates the process as usual, as NotifyContext
+	// restores the default behavior once the context is done.
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	root := &cli.Command{
+		Name:    name,
+		Summary: opts.Summary,
+		Flags:   flags,
+		Run: func(ctx context.Context, args []string) error {
+			if len(args) > 0 {
+				return fmt.Errorf("%w: unexpected argument %q", cli.ErrUsage, args[0])
+			}
+
+			if version {
+				return buildinfo.Print(stdout, name)
+			}
+
+			logger, err := newLogger(stderr, logLevel, logFormat)
+			if err != nil {
+				return fmt.Errorf("%w: %w", cli.ErrUsage, err)
+			}
+			slog.SetDefault(logger)
+
+			return fn(ctx, cfg)
+		},
+	}
+
+	err := cli.Execute(ctx, root, args, stdout)
+
+	return exitCode(ctx, stderr, name, err)
+}
+
+// exitCode reports err on stderr and returns the matching exit code.
+func exitCode(ctx context.Context, stderr io.Writer, name string, err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	// An application stopped by a signal usually returns the context error, which is not worth reporting.
+	if ctx.Err() != nil && errors.Is(err, context.Canceled) {
+		return ExitInterrupted
+	}
+
+	fmt.Fprintf(stderr, "%s: %v\n", name, err)
+
+	var coder ExitCoder
+	switch {
+	case errors.Is(err, cli.ErrUsage):
+		return ExitUsage
+	case errors.As(err, &coder) && coder.ExitCode() > 0:
+		return coder.ExitCode()
+	case ctx.Err() != nil:
+		return ExitInterrupted
+	}
+
+	return ExitFailure
+}
+
+// newLogger creates the logger writing to w with the given level and format.
+func newLogger(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	}
+
+	return nil, fmt.Errorf("invalid log format %q", format)
+}
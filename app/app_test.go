@@ -0,0 +1,107 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/SyntaxErrorLineNULL/common/cli"
+	"github.com/stretchr/testify/assert"
+)
+
+// testConfig is a configuration filled from flags.
+type testConfig struct {
+	Target string
+	DryRun bool
+}
+
+// Flags implements Configurable.
+func (c *testConfig) Flags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{Name: "target", Usage: "target to sync", Env: "APPTEST_TARGET", Default: "all", Target: &c.Target},
+		&cli.BoolFlag{Name: "dry-run", Usage: "only print the changes", Target: &c.DryRun},
+	}
+}
+
+// exitError is an error choosing the exit code.
+type exitError struct{ code int }
+
+func (e exitError) Error() string { return "exit" }
+func (e exitError) ExitCode() int { return e.code }
+
+// TestExecute verifies configuration parsing and the exit codes derived from the result of the application.
+func TestExecute(t *testing.T) {
+	// Config ensures the configuration is parsed from flags and the environment.
+	t.Run("Config", func(t *testing.T) {
+		t.Setenv("APPTEST_TARGET", "mirrors")
+
+		var got testConfig
+		var stdout, stderr bytes.Buffer
+		code := execute(context.Background(), Options{Name: "tool"}, []string{"--dry-run", "--log-level", "debug"}, &stdout, &stderr,
+			func(_ context.Context, cfg *testConfig) error {
+				got = *cfg
+				slog.Debug("running")
+				return nil
+			})
+
+		assert.Equal(t, ExitOK, code, "Expected success")
+		assert.Equal(t, testConfig{Target: "mirrors", DryRun: true}, got, "Unexpected configuration")
+		assert.Contains(t, stderr.String(), "msg=running", "Expected debug messages to be logged")
+	})
+
+	// Version ensures --version prints the build information without running the application.
+	t.Run("Version", func(t *testing.T) {
+		var stdout bytes.Buffer
+		code := execute(context.Background(), Options{Name: "tool"}, []string{"--version"}, &stdout, &bytes.Buffer{},
+			func(context.Context, *struct{}) error {
+				t.Error("Expected the application not to run")
+				return nil
+			})
+
+		assert.Equal(t, ExitOK, code, "Expected success")
+		assert.Contains(t, stdout.String(), "tool ", "Expected the version to be printed")
+	})
+
+	// ExitCodes ensures errors are mapped to exit codes.
+	t.Run("ExitCodes", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			args     []string
+			err      error
+			expected int
+		}{
+			{name: "Failure", err: errors.New("broken"), expected: ExitFailure},
+			{name: "Exit coder", err: exitError{code: 3}, expected: 3},
+			{name: "Unknown flag", args: []string{"--unknown"}, expected: ExitUsage},
+			{name: "Unexpected argument", args: []string{"extra"}, expected: ExitUsage},
+			{name: "Invalid log level", args: []string{"--log-level", "loud"}, expected: ExitUsage},
+			{name: "Invalid log format", args: []string{"--log-format", "xml"}, expected: ExitUsage},
+		}
+
+		for _, tt := range cases {
+			var stderr bytes.Buffer
+			code := execute(context.Background(), Options{Name: "tool"}, tt.args, &bytes.Buffer{}, &stderr,
+				func(context.Context, *testConfig) error { return tt.err })
+
+			assert.Equal(t, tt.expected, code, "Test case %s failed", tt.name)
+			assert.Contains(t, stderr.String(), "tool: ", "Expected the error to be reported in test case %s", tt.name)
+		}
+	})
+
+	// Interrupted ensures an application stopped by cancellation exits quietly with the interrupt code.
+	t.Run("Interrupted", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var stderr bytes.Buffer
+		code := execute(ctx, Options{Name: "tool"}, nil, &bytes.Buffer{}, &stderr, func(ctx context.Context, _ *testConfig) error {
+			cancel()
+			<-ctx.Done()
+			return ctx.Err()
+		})
+
+		assert.Equal(t, ExitInterrupted, code, "Expected the interrupt exit code")
+		assert.Empty(t, stderr.String(), "Expected no error to be reported")
+	})
+}
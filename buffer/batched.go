@@ -0,0 +1,159 @@
+package buffer
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+)
+
+// DefaultBatchSize is the batch size of a BatchedWriter created with a non-positive maxBytes.
+const DefaultBatchSize = 64 * 1024
+
+// ErrClosed is returned when writing to a closed writer.
+var ErrClosed = errors.New("buffer: writer closed")
+
+// BatchedWriter coalesces small writes into larger ones, which reduces the number of system calls
+// and packets when many short records, such as NDJSON lines or command output, go to a file or socket.
+// Buffered data is written once maxBytes are collected, once maxDelay has passed since the first
+// buffered byte, on Flush and on Close, so data is never delayed for longer than maxDelay.
+// BatchedWriter is safe for concurrent use.
+type BatchedWriter struct {
+	// w receives the batches.
+	w io.Writer
+	// maxBytes is the size at which the batch is written.
+	maxBytes int
+	// maxDelay is the longest time data stays buffered, or zero or less for no limit.
+	maxDelay time.Duration
+	// mu guards the fields below and serializes writes to w.
+	mu sync.Mutex
+	// buf holds the current batch.
+	buf []byte
+	// timer flushes the batch once maxDelay has passed, or is nil while no timer is pending.
+	timer *time.Timer
+	// err is the first error writing to w. It is returned by all later calls.
+	err error
+	// closed reports whether Close has been called.
+	closed bool
+}
+
+// NewBatchedWriter creates a BatchedWriter writing batches of up to maxBytes to w, flushing buffered data
+// after at most maxDelay. A non-positive maxBytes uses DefaultBatchSize; a non-positive maxDelay disables
+// the time-based flush, so data is only written when the batch is full, on Flush and on Close.
+func NewBatchedWriter(w io.Writer, maxBytes int, maxDelay time.Duration) *BatchedWriter {
+	if maxBytes <= 0 {
+		maxBytes = DefaultBatchSize
+	}
+
+	return &BatchedWriter{w: w, maxBytes: maxBytes, maxDelay: maxDelay, buf: make([]byte, 0, maxBytes)}
+}
+
+// Write adds p to the batch. Writes of at least maxBytes bypass the batch after flushing it, so they are
+// not copied needlessly. An error of an earlier background flush is returned by the next call.
+func (b *BatchedWriter) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return 0, ErrClosed
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+
+	// Make room for p, keeping the order of the data.
+	if len(b.buf)+len(p) > b.maxBytes {
+		if err := b.flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(p) >= b.maxBytes {
+		n, err := b.w.Write(p)
+		if err != nil {
+			b.err = err
+		}
+		return n, err
+	}
+
+	b.buf = append(b.buf, p...)
+
+	if len(b.buf) >= b.maxBytes {
+		return len(p), b.flush()
+	}
+
+	// Start the clock when the first byte of a batch arrives.
+	if b.timer == nil && b.maxDelay > 0 {
+		b.timer = time.AfterFunc(b.maxDelay, b.flushLater)
+	}
+
+	return len(p), nil
+}
+
+// Flush writes the buffered data to the underlying writer.
+func (b *BatchedWriter) Flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.flush()
+}
+
+// Buffered returns the number of bytes waiting to be written.
+func (b *BatchedWriter) Buffered() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.buf)
+}
+
+// Close flushes the buffered data and stops the writer. Later writes fail with ErrClosed.
+// The underlying writer is not closed, as it is owned by the caller.
+func (b *BatchedWriter) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.flush()
+}
+
+// flushLater is called by the timer once the batch has waited for maxDelay. Errors are kept for the next call.
+func (b *BatchedWriter) flushLater() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.err == nil {
+		_ = b.flush()
+	}
+}
+
+// flush writes the batch and stops the pending timer. The caller must hold the mutex.
+func (b *BatchedWriter) flush() error {
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	if len(b.buf) == 0 {
+		return nil
+	}
+
+	_, err := b.w.Write(b.buf)
+	if err != nil {
+		b.err = err
+		return err
+	}
+	b.buf = b.buf[:0]
+
+	return nil
+}
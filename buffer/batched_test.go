@@ -0,0 +1,104 @@
+package buffer
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingWriter records every write it receives.
+type recordingWriter struct {
+	mu     sync.Mutex
+	writes []string
+	err    error
+}
+
+// Write implements io.Writer.
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return 0, w.err
+	}
+	w.writes = append(w.writes, string(p))
+
+	return len(p), nil
+}
+
+// Writes returns a copy of the recorded writes.
+func (w *recordingWriter) Writes() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]string(nil), w.writes...)
+}
+
+// TestBatchedWriter verifies flushing on size, on time and on Close.
+func TestBatchedWriter(t *testing.T) {
+	t.Parallel()
+
+	// FlushOnSize ensures a batch is written once it is full and large writes bypass the batch.
+	t.Run("FlushOnSize", func(t *testing.T) {
+		out := &recordingWriter{}
+		w := NewBatchedWriter(out, 8, 0)
+
+		for _, s := range []string{"ab", "cd", "ef"} {
+			_, err := w.Write([]byte(s))
+			assert.NoError(t, err, "Expected no error")
+		}
+		assert.Empty(t, out.Writes(), "Expected the data to be buffered")
+		assert.Equal(t, 6, w.Buffered(), "Unexpected buffered size")
+
+		_, _ = w.Write([]byte("gh"))
+		assert.Equal(t, []string{"abcdefgh"}, out.Writes(), "Expected the full batch to be written")
+
+		_, _ = w.Write([]byte("i"))
+		_, _ = w.Write([]byte("0123456789"))
+		assert.Equal(t, []string{"abcdefgh", "i", "0123456789"}, out.Writes(), "Expected the large write to bypass the batch in order")
+	})
+
+	// FlushOnTime ensures buffered data is written after the delay.
+	t.Run("FlushOnTime", func(t *testing.T) {
+		out := &recordingWriter{}
+		w := NewBatchedWriter(out, 1024, 10*time.Millisecond)
+
+		_, _ = w.Write([]byte("a"))
+		_, _ = w.Write([]byte("b"))
+
+		assert.Eventually(t, func() bool { return len(out.Writes()) == 1 }, time.Second, time.Millisecond, "Expected a timed flush")
+		assert.Equal(t, []string{"ab"}, out.Writes(), "Expected both writes in one batch")
+	})
+
+	// Close ensures Close flushes the remaining data and rejects later writes.
+	t.Run("Close", func(t *testing.T) {
+		var out bytes.Buffer
+		w := NewBatchedWriter(&out, 0, time.Hour)
+
+		_, _ = w.Write([]byte("pending"))
+		assert.NoError(t, w.Close(), "Expected no error on close")
+		assert.Equal(t, "pending", out.String(), "Expected the data to be flushed")
+
+		_, err := w.Write([]byte("late"))
+		assert.ErrorIs(t, err, ErrClosed, "Expected writes after close to fail")
+		assert.NoError(t, w.Close(), "Expected a second close to succeed")
+	})
+
+	// StickyError ensures an error of the underlying writer is returned by later calls.
+	t.Run("StickyError", func(t *testing.T) {
+		failure := errors.New("disk full")
+		out := &recordingWriter{err: failure}
+		w := NewBatchedWriter(out, 4, 0)
+
+		_, _ = w.Write([]byte("ab"))
+		assert.ErrorIs(t, w.Flush(), failure, "Expected the write error")
+
+		_, err := w.Write([]byte("c"))
+		assert.ErrorIs(t, err, failure, "Expected the error to be kept")
+		assert.ErrorIs(t, w.Close(), failure, "Expected close to report the error")
+	})
+}
@@ -0,0 +1,161 @@
+package buffer
+
+import (
+	"errors"
+	"io"
+	"os"
+)
+
+// errNegativePosition is returned when seeking before the start of a SpillBuffer.
+var errNegativePosition = errors.New("buffer: negative position")
+
+// SpillBuffer is an io.ReadWriteSeeker holding its data in memory up to a limit and in a temporary file
+// beyond it, for data of unpredictable size such as response bodies or command output. Like a file, it has
+// a single position shared by reads and writes: write the data, seek back to the start and read it.
+// Close removes the temporary file. SpillBuffer is not safe for concurrent use.
+type SpillBuffer struct {
+	// memLimit is the largest size kept in memory.
+	memLimit int
+	// data holds the content while it is in memory.
+	data []byte
+	// pos is the position of the next read or write while the content is in memory.
+	pos int64
+	// file holds the content once it has spilled, or is nil before.
+	file *os.File
+	// closed reports whether Close has been called.
+	closed bool
+}
+
+// NewSpillBuffer creates a SpillBuffer keeping up to memLimit bytes in memory.
+// A non-positive memLimit writes all data to a temporary file.
+func NewSpillBuffer(memLimit int) *SpillBuffer {
+	return &SpillBuffer{memLimit: max(memLimit, 0)}
+}
+
+// Write writes p at the current position, moving the content to a temporary file when it outgrows the limit.
+func (b *SpillBuffer) Write(p []byte) (int, error) {
+	if b.closed {
+		return 0, ErrClosed
+	}
+
+	if b.file == nil && b.pos+int64(len(p)) > int64(b.memLimit) {
+		if err := b.spill(); err != nil {
+			return 0, err
+		}
+	}
+	if b.file != nil {
+		return b.file.Write(p)
+	}
+
+	// Grow the content when writing beyond its end, filling a gap left by seeking with zeros.
+	if end := int(b.pos) + len(p); end > len(b.data) {
+		b.data = append(b.data, make([]byte, end-len(b.data))...)
+	}
+	n := copy(b.data[b.pos:], p)
+	b.pos += int64(n)
+
+	return n, nil
+}
+
+// Read reads from the current position, returning io.EOF at the end of the content.
+func (b *SpillBuffer) Read(p []byte) (int, error) {
+	if b.closed {
+		return 0, ErrClosed
+	}
+	if b.file != nil {
+		return b.file.Read(p)
+	}
+
+	if b.pos >= int64(len(b.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, b.data[b.pos:])
+	b.pos += int64(n)
+
+	return n, nil
+}
+
+// Seek sets the position for the next read or write, as described by io.Seeker.
+func (b *SpillBuffer) Seek(offset int64, whence int) (int64, error) {
+	if b.closed {
+		return 0, ErrClosed
+	}
+	if b.file != nil {
+		return b.file.Seek(offset, whence)
+	}
+
+	var pos int64
+	switch whence {
+	case io.SeekStart:
+		pos = offset
+	case io.SeekCurrent:
+		pos = b.pos + offset
+	case io.SeekEnd:
+		pos = int64(len(b.data)) + offset
+	default:
+		return 0, errors.New("buffer: invalid whence")
+	}
+	if pos < 0 {
+		return 0, errNegativePosition
+	}
+	b.pos = pos
+
+	return pos, nil
+}
+
+// Size returns the size of the content.
+func (b *SpillBuffer) Size() (int64, error) {
+	if b.closed {
+		return 0, ErrClosed
+	}
+	if b.file == nil {
+		return int64(len(b.data)), nil
+	}
+
+	info, err := b.file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// Spilled reports whether the content has been moved to a temporary file.
+func (b *SpillBuffer) Spilled() bool {
+	return b.file != nil
+}
+
+// Close releases the memory and removes the temporary file. Later calls fail with ErrClosed.
+func (b *SpillBuffer) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	b.data = nil
+
+	if b.file == nil {
+		return nil
+	}
+
+	return errors.Join(b.file.Close(), os.Remove(b.file.Name()))
+}
+
+// spill moves the content from memory to a temporary file, keeping the position.
+func (b *SpillBuffer) spill() error {
+	file, err := os.CreateTemp("", "spill-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = file.Write(b.data); err == nil {
+		_, err = file.Seek(b.pos, io.SeekStart)
+	}
+	if err != nil {
+		return errors.Join(err, file.Close(), os.Remove(file.Name()))
+	}
+
+	b.file = file
+	b.data = nil
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package buffer
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSpillBuffer verifies reading and writing in memory and after spilling to a file.
+func TestSpillBuffer(t *testing.T) {
+	t.Parallel()
+
+	// InMemory ensures small content stays in memory and behaves like a file.
+	t.Run("InMemory", func(t *testing.T) {
+		b := NewSpillBuffer(16)
+		defer b.Close()
+
+		_, err := io.WriteString(b, "hello world")
+		assert.NoError(t, err, "Expected no error")
+		assert.False(t, b.Spilled(), "Expected the content to stay in memory")
+
+		_, err = b.Seek(6, io.SeekStart)
+		assert.NoError(t, err, "Expected no error")
+		_, _ = io.WriteString(b, "there")
+
+		_, _ = b.Seek(0, io.SeekStart)
+		data, err := io.ReadAll(b)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "hello there", string(data), "Unexpected content")
+
+		_, err = b.Seek(-1, io.SeekStart)
+		assert.Error(t, err, "Expected an error for a negative position")
+	})
+
+	// Spill ensures content beyond the limit moves to a temporary file that Close removes.
+	t.Run("Spill", func(t *testing.T) {
+		b := NewSpillBuffer(8)
+
+		_, _ = io.WriteString(b, "12345")
+		_, err := io.WriteString(b, strings.Repeat("x", 10))
+		assert.NoError(t, err, "Expected no error")
+		assert.True(t, b.Spilled(), "Expected the content to spill")
+
+		size, err := b.Size()
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, int64(15), size, "Unexpected size")
+
+		_, _ = b.Seek(0, io.SeekStart)
+		data, err := io.ReadAll(b)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "12345"+strings.Repeat("x", 10), string(data), "Expected the content to survive the spill")
+
+		name := b.file.Name()
+		assert.NoError(t, b.Close(), "Expected no error on close")
+		_, err = os.Stat(name)
+		assert.True(t, os.IsNotExist(err), "Expected the temporary file to be removed")
+
+		_, err = b.Write([]byte("late"))
+		assert.ErrorIs(t, err, ErrClosed, "Expected writes after close to fail")
+	})
+}
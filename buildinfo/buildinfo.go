@@ -0,0 +1,147 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// These variables can be set at link time, which takes precedence over the information embedded
+// by the Go toolchain:
+//
+//	go build -ldflags "-X github.com/SyntaxErrorLineNULL/common/buildinfo.version=v1.2.3 \
+//		-X github.com/SyntaxErrorLineNULL/common/buildinfo.commit=$(git rev-parse HEAD)"
+var (
+	// version is the version of the binary.
+	version string
+	// commit is the VCS revision the binary was built from.
+	commit string
+	// date is the build or commit time.
+	date string
+	// dirty is "true" if the working tree had uncommitted changes.
+	dirty string
+)
+
+// Info describes the build of the running binary.
+type Info struct {
+	// Version is the release version, or "(devel)" for builds outside of a tagged module.
+	Version string `json:"version"`
+	// Commit is the VCS revision, empty when unknown.
+	Commit string `json:"commit,omitempty"`
+	// Date is the time of the commit or build, empty when unknown.
+	Date string `json:"date,omitempty"`
+	// Dirty reports whether the binary was built from a working tree with uncommitted changes.
+	Dirty bool `json:"dirty"`
+	// GoVersion is the version of the Go toolchain that built the binary.
+	GoVersion string `json:"go_version"`
+	// Platform is the target operating system and architecture, for example "linux/amd64".
+	Platform string `json:"platform"`
+}
+
+// Get returns the build information of the running binary. Values injected with ldflags take precedence
+// over the module version and VCS settings recorded by the Go toolchain. The result is computed once.
+var Get = sync.OnceValue(func() Info {
+	info := Info{
+		Version:   "(devel)",
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	// Start with what the toolchain recorded in the binary.
+	if build, ok := debug.ReadBuildInfo(); ok {
+		if build.Main.Version != "" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Commit = setting.Value
+			case "vcs.time":
+				info.Date = setting.Value
+			case "vcs.modified":
+				info.Dirty = setting.Value == "true"
+			}
+		}
+	}
+
+	// Override with the values injected at link time.
+	if version != "" {
+		info.Version = version
+	}
+	if commit != "" {
+		info.Commit = commit
+	}
+	if date != "" {
+		info.Date = date
+	}
+	if dirty != "" {
+		info.Dirty = dirty == "true"
+	}
+
+	return info
+})
+
+// Version returns the version of the running binary.
+func Version() string {
+	return Get().Version
+}
+
+// Commit returns the VCS revision of the running binary, or an empty string when unknown.
+func Commit() string {
+	return Get().Commit
+}
+
+// Dirty reports whether the running binary was built from a working tree with uncommitted changes.
+func Dirty() bool {
+	return Get().Dirty
+}
+
+// String formats the build information as a single line, as printed by --version.
+func (i Info) String() string {
+	s := i.Version
+
+	if i.Commit != "" {
+		// A short revision is enough to identify the commit.
+		shortCommit := i.Commit
+		if len(shortCommit) > 12 {
+			shortCommit = shortCommit[:12]
+		}
+		s += " (" + shortCommit
+		if i.Dirty {
+			s += ", dirty"
+		}
+		s += ")"
+	}
+
+	if i.Date != "" {
+		s += " built " + i.Date
+	}
+
+	return s + " " + i.GoVersion + " " + i.Platform
+}
+
+// Print writes the build information of the running binary, prefixed with the program name, to w.
+func Print(w io.Writer, program string) error {
+	_, err := fmt.Fprintf(w, "%s %s\n", program, Get())
+	return err
+}
+
+// VersionFlag registers a boolean "version" flag on fs and returns its value.
+// After parsing, the tool checks the flag and prints the build information with Print.
+func VersionFlag(fs *flag.FlagSet) *bool {
+	return fs.Bool("version", false, "print version information and exit")
+}
+
+// Handler returns an http.Handler responding with the build information as JSON,
+// intended for a /version endpoint.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Get())
+	})
+}
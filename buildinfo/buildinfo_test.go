@@ -0,0 +1,92 @@
+package buildinfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGet verifies that the build information always includes the toolchain details.
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	info := Get()
+
+	assert.NotEmpty(t, info.Version, "Expected a version")
+	assert.Equal(t, runtime.Version(), info.GoVersion, "Unexpected Go version")
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform, "Unexpected platform")
+	assert.Equal(t, info.Version, Version(), "Expected Version to match Get")
+	assert.Equal(t, info.Commit, Commit(), "Expected Commit to match Get")
+	assert.Equal(t, info.Dirty, Dirty(), "Expected Dirty to match Get")
+}
+
+// TestInfoString verifies the single-line format of the build information.
+func TestInfoString(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases with and without VCS information.
+	cases := []struct {
+		name     string
+		info     Info
+		expected string
+	}{
+		{
+			name:     "Full",
+			info:     Info{Version: "v1.2.3", Commit: "0123456789abcdef", Date: "2024-05-01T12:00:00Z", Dirty: true, GoVersion: "go1.22.0", Platform: "linux/amd64"},
+			expected: "v1.2.3 (0123456789ab, dirty) built 2024-05-01T12:00:00Z go1.22.0 linux/amd64",
+		},
+		{
+			name:     "VersionOnly",
+			info:     Info{Version: "(devel)", GoVersion: "go1.22.0", Platform: "darwin/arm64"},
+			expected: "(devel) go1.22.0 darwin/arm64",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.info.String(), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestPrint verifies that Print prefixes the build information with the program name.
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	assert.NoError(t, Print(&out, "mytool"), "Expected no error")
+	assert.Equal(t, "mytool "+Get().String()+"\n", out.String(), "Unexpected output")
+}
+
+// TestVersionFlag verifies that the version flag is registered and parsed.
+func TestVersionFlag(t *testing.T) {
+	t.Parallel()
+
+	fs := flag.NewFlagSet("mytool", flag.ContinueOnError)
+	printVersion := VersionFlag(fs)
+
+	assert.NoError(t, fs.Parse([]string{"--version"}), "Expected the flag to be parsed")
+	assert.True(t, *printVersion, "Expected the flag to be set")
+}
+
+// TestHandler verifies that the handler serves the build information as JSON.
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/version", http.NoBody))
+
+	assert.Equal(t, http.StatusOK, rec.Code, "Unexpected status")
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json"), "Expected a JSON response")
+
+	var info Info
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &info), "Expected valid JSON")
+	assert.Equal(t, Get(), info, "Expected the build information")
+}
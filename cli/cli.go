@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	commonstrings "github.com/SyntaxErrorLineNULL/common/strings"
+)
+
+// ErrUsage is returned by Execute when the command line is invalid, for example because of an unknown flag
+// or subcommand. The help of the affected command has been written to the output already.
+var ErrUsage = errors.New("cli: invalid usage")
+
+// helpWidth is the width the descriptions in the help are wrapped to. SplitStringWithWidthConstraints
+// counts only the characters of the words, so with the spaces and the overflow the lines stay
+// within about 80 columns.
+const helpWidth = 60
+
+// helpOverflow is how far a word may exceed helpWidth before it is moved to the next line.
+const helpOverflow = 8
+
+// Command is a declarative definition of a command and its subcommands.
+type Command struct {
+	// Name is the name of the command, as typed on the command line.
+	Name string
+	// Summary is a one-line description shown in the command list of the parent.
+	Summary string
+	// Description is a longer explanation shown in the help of the command. It is wrapped automatically.
+	Description string
+	// Flags are the flags accepted by the command. They must precede the arguments and the subcommand name.
+	Flags []Flag
+	// Commands are the subcommands, selected by the first argument after the flags.
+	Commands []*Command
+	// Run executes the command with the remaining arguments. A command without Run requires a subcommand.
+	Run func(ctx context.Context, args []string) error
+}
+
+// Execute parses args, which do not include the program name, for the root command and dispatches to the
+// selected subcommand. The help is written to out when requested with -h or --help, in which case Execute
+// returns nil, and when the command line is invalid, in which case the returned error wraps ErrUsage.
+// Otherwise Execute returns the error of the Run function of the selected command.
+func Execute(ctx context.Context, root *Command, args []string, out io.Writer) error {
+	return root.execute(ctx, []string{root.Name}, args, out)
+}
+
+// execute parses the flags of c and runs it or the selected subcommand. The path holds the names of
+// the commands leading to c, which the help shows in the usage line.
+func (c *Command) execute(ctx context.Context, path, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	// Errors are reported by Execute and the help is generated by this package.
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+
+	for _, f := range c.Flags {
+		if err := f.define(fs); err != nil {
+			return err
+		}
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return c.writeHelp(out, path)
+		}
+		return c.usageError(out, path, err.Error())
+	}
+
+	rest := fs.Args()
+
+	// Dispatch to the subcommand named by the first remaining argument.
+	if len(rest) > 0 {
+		for _, sub := range c.Commands {
+			if sub.Name == rest[0] {
+				return sub.execute(ctx, append(path, sub.Name), rest[1:], out)
+			}
+		}
+	}
+
+	if c.Run == nil {
+		if len(rest) > 0 {
+			return c.usageError(out, path, fmt.Sprintf("unknown command %q", rest[0]))
+		}
+		return c.usageError(out, path, "missing command")
+	}
+
+	return c.Run(ctx, rest)
+}
+
+// usageError writes the problem and the help of c to out and returns an error wrapping ErrUsage.
+func (c *Command) usageError(out io.Writer, path []string, problem string) error {
+	if _, err := fmt.Fprintf(out, "Error: %s\n\n", problem); err != nil {
+		return err
+	}
+	if err := c.writeHelp(out, path); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", ErrUsage, problem)
+}
+
+// writeHelp writes the generated help of c to out.
+func (c *Command) writeHelp(out io.Writer, path []string) error {
+	var help strings.Builder
+
+	// The usage line lists what the command accepts.
+	help.WriteString("Usage: " + strings.Join(path, " "))
+	if len(c.Flags) > 0 {
+		help.WriteString(" [flags]")
+	}
+	if len(c.Commands) > 0 {
+		help.WriteString(" <command>")
+	} else if c.Run != nil {
+		help.WriteString(" [args...]")
+	}
+	help.WriteString("\n")
+
+	if description := c.Description; description != "" || c.Summary != "" {
+		if description == "" {
+			description = c.Summary
+		}
+		help.WriteString("\n")
+		writeWrapped(&help, description, "")
+	}
+
+	if len(c.Commands) > 0 {
+		help.WriteString("\nCommands:\n")
+		width := 0
+		for _, sub := range c.Commands {
+			width = max(width, len(sub.Name))
+		}
+		for _, sub := range c.Commands {
+			fmt.Fprintf(&help, "  %-*s  %s\n", width, sub.Name, sub.Summary)
+		}
+	}
+
+	if len(c.Flags) > 0 {
+		help.WriteString("\nFlags:\n")
+		for _, f := range c.Flags {
+			writeFlagHelp(&help, f.info())
+		}
+	}
+
+	_, err := io.WriteString(out, help.String())
+
+	return err
+}
+
+// writeFlagHelp writes the help entry of a single flag.
+func writeFlagHelp(help *strings.Builder, info flagInfo) {
+	help.WriteString("  --" + info.name)
+	if !info.boolean {
+		help.WriteString(" value")
+	}
+	help.WriteString("\n")
+
+	// Describe the default and the environment variable after the usage text.
+	details := info.usage
+	details += " (default " + info.def
+	if info.env != "" {
+		details += ", env " + info.env
+	}
+	details += ")"
+
+	writeWrapped(help, strings.TrimSpace(details), "      ")
+}
+
+// writeWrapped writes text wrapped to helpWidth, with every line prefixed by indent.
+func writeWrapped(help *strings.Builder, text, indent string) {
+	for _, line := range commonstrings.SplitStringWithWidthConstraints(text, helpWidth-len(indent), helpOverflow) {
+		help.WriteString(indent + line + "\n")
+	}
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTool builds a small command tree recording what was run into the returned log.
+func newTool(log *[]string) (*Command, *string, *int, *bool, *time.Duration) {
+	var (
+		host    string
+		retries int
+		verbose bool
+		timeout time.Duration
+	)
+
+	deploy := &Command{
+		Name:        "deploy",
+		Summary:     "Deploy the application",
+		Description: "Deploy builds the application and rolls it out to every host of the selected environment, one host at a time. Hosts that fail the health check are rolled back before the next host is updated.",
+		Flags: []Flag{
+			&IntFlag{Name: "retries", Usage: "number of attempts per host", Env: "CLITEST_RETRIES", Default: 3, Target: &retries},
+			&DurationFlag{Name: "timeout", Usage: "timeout per host", Default: time.Minute, Target: &timeout},
+		},
+		Run: func(_ context.Context, args []string) error {
+			*log = append(*log, "deploy "+strings.Join(args, " "))
+			return nil
+		},
+	}
+
+	root := &Command{
+		Name:    "tool",
+		Summary: "Operate the application",
+		Flags: []Flag{
+			&StringFlag{Name: "host", Usage: "API host", Env: "CLITEST_HOST", Default: "localhost", Target: &host},
+			&BoolFlag{Name: "verbose", Usage: "print more details", Target: &verbose},
+		},
+		Commands: []*Command{deploy, {Name: "status", Summary: "Show the status", Run: func(context.Context, []string) error {
+			return errors.New("status unavailable")
+		}}},
+	}
+
+	return root, &host, &retries, &verbose, &timeout
+}
+
+// TestExecute verifies flag parsing, environment fallback and subcommand dispatch.
+func TestExecute(t *testing.T) {
+	// Dispatch ensures flags of every level are parsed and the subcommand receives the remaining arguments.
+	t.Run("Dispatch", func(t *testing.T) {
+		var log []string
+		root, host, retries, verbose, timeout := newTool(&log)
+
+		err := Execute(context.Background(), root, []string{"--host", "api", "-verbose", "deploy", "--retries=5", "v1.2", "v1.3"}, &bytes.Buffer{})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, []string{"deploy v1.2 v1.3"}, log, "Expected the subcommand to run with its arguments")
+		assert.Equal(t, "api", *host, "Unexpected host")
+		assert.Equal(t, 5, *retries, "Unexpected retries")
+		assert.True(t, *verbose, "Expected verbose to be set")
+		assert.Equal(t, time.Minute, *timeout, "Expected the default timeout")
+	})
+
+	// EnvFallback ensures environment variables are used for flags not given on the command line.
+	t.Run("EnvFallback", func(t *testing.T) {
+		t.Setenv("CLITEST_HOST", "env-host")
+		t.Setenv("CLITEST_RETRIES", "7")
+
+		var log []string
+		root, host, retries, _, _ := newTool(&log)
+
+		err := Execute(context.Background(), root, []string{"deploy", "--retries", "1"}, &bytes.Buffer{})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "env-host", *host, "Expected the environment value")
+		assert.Equal(t, 1, *retries, "Expected the command line to override the environment")
+	})
+
+	// InvalidEnv ensures an unparsable environment value is reported.
+	t.Run("InvalidEnv", func(t *testing.T) {
+		t.Setenv("CLITEST_RETRIES", "many")
+
+		var log []string
+		root, _, _, _, _ := newTool(&log)
+
+		err := Execute(context.Background(), root, []string{"deploy"}, &bytes.Buffer{})
+		assert.ErrorContains(t, err, "CLITEST_RETRIES", "Expected the environment variable in the error")
+	})
+
+	// RunError ensures the error of the command is returned as is.
+	t.Run("RunError", func(t *testing.T) {
+		var log []string
+		root, _, _, _, _ := newTool(&log)
+
+		err := Execute(context.Background(), root, []string{"status"}, &bytes.Buffer{})
+		assert.EqualError(t, err, "status unavailable", "Expected the command error")
+	})
+
+	// Usage ensures unknown commands, unknown flags and missing commands print the help.
+	t.Run("Usage", func(t *testing.T) {
+		for _, args := range [][]string{{"unknown"}, {"--bogus"}, {}} {
+			var log []string
+			root, _, _, _, _ := newTool(&log)
+			var out bytes.Buffer
+
+			err := Execute(context.Background(), root, args, &out)
+			assert.ErrorIs(t, err, ErrUsage, "Expected a usage error for %v", args)
+			assert.Contains(t, out.String(), "Usage: tool [flags] <command>", "Expected the help for %v", args)
+		}
+	})
+}
+
+// TestHelp verifies the generated help of a command.
+func TestHelp(t *testing.T) {
+	var log []string
+	root, _, _, _, _ := newTool(&log)
+	var out bytes.Buffer
+
+	err := Execute(context.Background(), root, []string{"deploy", "--help"}, &out)
+	assert.NoError(t, err, "Expected the help not to be an error")
+	assert.Empty(t, log, "Expected the command not to run")
+
+	expected := `Usage: tool deploy [flags] [args...]
+
+Deploy builds the application and rolls it out to every host of the selected
+environment, one host at a time. Hosts that fail the health check are rolled back
+before the next host is updated.
+
+Flags:
+  --retries value
+      number of attempts per host (default 3, env CLITEST_RETRIES)
+  --timeout value
+      timeout per host (default 1m0s)
+`
+	assert.Equal(t, expected, out.String(), "Unexpected help")
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Flag is a typed command-line flag of a Command. The implementations in this package bind the flag
+// to a target variable and can fall back to an environment variable when the flag is not given.
+type Flag interface {
+	// define registers the flag on fs, using the environment variable as default when it is set.
+	define(fs *flag.FlagSet) error
+	// info describes the flag for the generated help.
+	info() flagInfo
+}
+
+// flagInfo is the description of a flag shown in the help.
+type flagInfo struct {
+	name, usage, env, def string
+	// boolean flags take no argument, which the help reflects.
+	boolean bool
+}
+
+// StringFlag is a flag holding a string.
+type StringFlag struct {
+	// Name is the flag name without dashes.
+	Name string
+	// Usage describes the flag in the help.
+	Usage string
+	// Env is the environment variable used when the flag is not given on the command line.
+	Env string
+	// Default is used when neither the flag nor the environment variable is set.
+	Default string
+	// Target receives the value. It must not be nil.
+	Target *string
+}
+
+// define implements Flag.
+func (f *StringFlag) define(fs *flag.FlagSet) error {
+	value, err := envDefault(f.Env, f.Default, func(s string) (string, error) { return s, nil })
+	if err != nil {
+		return err
+	}
+	fs.StringVar(f.Target, f.Name, value, f.Usage)
+
+	return nil
+}
+
+// info implements Flag.
+func (f *StringFlag) info() flagInfo {
+	return flagInfo{name: f.Name, usage: f.Usage, env: f.Env, def: strconv.Quote(f.Default)}
+}
+
+// IntFlag is a flag holding an integer.
+type IntFlag struct {
+	// Name is the flag name without dashes.
+	Name string
+	// Usage describes the flag in the help.
+	Usage string
+	// Env is the environment variable used when the flag is not given on the command line.
+	Env string
+	// Default is used when neither the flag nor the environment variable is set.
+	Default int
+	// Target receives the value. It must not be nil.
+	Target *int
+}
+
+// define implements Flag.
+func (f *IntFlag) define(fs *flag.FlagSet) error {
+	value, err := envDefault(f.Env, f.Default, strconv.Atoi)
+	if err != nil {
+		return err
+	}
+	fs.IntVar(f.Target, f.Name, value, f.Usage)
+
+	return nil
+}
+
+// info implements Flag.
+func (f *IntFlag) info() flagInfo {
+	return flagInfo{name: f.Name, usage: f.Usage, env: f.Env, def: strconv.Itoa(f.Default)}
+}
+
+// BoolFlag is a flag holding a boolean. It is set by giving the flag without an argument.
+type BoolFlag struct {
+	// Name is the flag name without dashes.
+	Name string
+	// Usage describes the flag in the help.
+	Usage string
+	// Env is the environment variable used when the flag is not given on the command line.
+	Env string
+	// Default is used when neither the flag nor the environment variable is set.
+	Default bool
+	// Target receives the value. It must not be nil.
+	Target *bool
+}
+
+// define implements Flag.
+func (f *BoolFlag) define(fs *flag.FlagSet) error {
+	value, err := envDefault(f.Env, f.Default, strconv.ParseBool)
+	if err != nil {
+		return err
+	}
+	fs.BoolVar(f.Target, f.Name, value, f.Usage)
+
+	return nil
+}
+
+// info implements Flag.
+func (f *BoolFlag) info() flagInfo {
+	return flagInfo{name: f.Name, usage: f.Usage, env: f.Env, def: strconv.FormatBool(f.Default), boolean: true}
+}
+
+// DurationFlag is a flag holding a duration such as "1m30s".
+type DurationFlag struct {
+	// Name is the flag name without dashes.
+	Name string
+	// Usage describes the flag in the help.
+	Usage string
+	// Env is the environment variable used when the flag is not given on the command line.
+	Env string
+	// Default is used when neither the flag nor the environment variable is set.
+	Default time.Duration
+	// Target receives the value. It must not be nil.
+	Target *time.Duration
+}
+
+// define implements Flag.
+func (f *DurationFlag) define(fs *flag.FlagSet) error {
+	value, err := envDefault(f.Env, f.Default, time.ParseDuration)
+	if err != nil {
+		return err
+	}
+	fs.DurationVar(f.Target, f.Name, value, f.Usage)
+
+	return nil
+}
+
+// info implements Flag.
+func (f *DurationFlag) info() flagInfo {
+	return flagInfo{name: f.Name, usage: f.Usage, env: f.Env, def: f.Default.String()}
+}
+
+// envDefault returns the value of the environment variable env parsed by parse, or def when it is not set.
+func envDefault[T any](env string, def T, parse func(string) (T, error)) (T, error) {
+	if env == "" {
+		return def, nil
+	}
+
+	raw, ok := os.LookupEnv(env)
+	if !ok {
+		return def, nil
+	}
+
+	value, err := parse(raw)
+	if err != nil {
+		return def, fmt.Errorf("cli: invalid value %q of %s: %w", raw, env, err)
+	}
+
+	return value, nil
+}
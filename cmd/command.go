@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Customizer adjusts a command after it has been built and before it is started, for settings this package
+// does not model itself, such as SysProcAttr, credentials, extra files or a working directory.
+type Customizer func(*exec.Cmd)
+
+// CommandFromTemplate builds the command line with BuildFromTemplate and returns it as an exec.Cmd bound to ctx,
+// after applying the customizers in order. The command is not started.
+func CommandFromTemplate(ctx context.Context, text string, data any, customizers ...Customizer) (*exec.Cmd, error) {
+	argv, err := BuildFromTemplate(text, data)
+	if err != nil {
+		return nil, err
+	}
+
+	command := exec.CommandContext(ctx, argv[0], argv[1:]...)
+
+	// Let the caller adjust what the builder cannot express.
+	for _, customize := range customizers {
+		customize(command)
+	}
+
+	return command, nil
+}
+
+// StdinString returns a Customizer feeding s to the standard input of the command, for short here-docs.
+func StdinString(s string) Customizer {
+	return func(c *exec.Cmd) {
+		c.Stdin = strings.NewReader(s)
+	}
+}
+
+// StdinBytes returns a Customizer feeding b to the standard input of the command.
+// The slice must not be modified until the command has finished.
+func StdinBytes(b []byte) Customizer {
+	return func(c *exec.Cmd) {
+		c.Stdin = bytes.NewReader(b)
+	}
+}
+
+// StdinFile returns a Customizer feeding the file at path to the standard input of the command.
+// The file is opened when the command starts, so building the command does not fail; an error opening
+// or reading it is reported by Wait. The file is closed once the command has consumed it or exited,
+// at the latest when Wait returns, even if the command does not read all of it.
+func StdinFile(path string) Customizer {
+	return func(c *exec.Cmd) {
+		c.Stdin = &fileReader{path: path, size: -1}
+	}
+}
+
+// inputSize returns the number of bytes of the standard input r, as reported by the readers of StdinString,
+// StdinBytes and StdinFile, zero without input, or -1 if unknown.
+func inputSize(r io.Reader) int64 {
+	if r == nil {
+		return 0
+	}
+	if sized, ok := r.(interface{ Size() int64 }); ok {
+		return sized.Size()
+	}
+
+	return -1
+}
+
+// fileReader reads a file opened on the first use and closed at its end.
+type fileReader struct {
+	// path is the name of the file.
+	path string
+	// size is the size of the file when it was opened, or -1 before.
+	size int64
+	// file is the open file, nil before the first use and after closing.
+	file *os.File
+	// err is the sticky error returned once the file is done.
+	err error
+}
+
+// Size returns the size of the file when it was opened, or -1 if it was not opened.
+func (r *fileReader) Size() int64 {
+	return r.size
+}
+
+// WriteTo implements io.WriterTo, which exec uses to copy the standard input to the command. The file is
+// closed when the copy ends, including when it fails because the command exited without reading all of it.
+func (r *fileReader) WriteTo(w io.Writer) (int64, error) {
+	if err := r.open(); err != nil {
+		return 0, err
+	}
+	defer r.close(io.EOF)
+
+	return io.Copy(w, r.file)
+}
+
+// Read implements io.Reader.
+func (r *fileReader) Read(p []byte) (int, error) {
+	if err := r.open(); err != nil {
+		return 0, err
+	}
+
+	n, err := r.file.Read(p)
+	if err != nil {
+		r.close(err)
+	}
+
+	return n, err
+}
+
+// open opens the file unless it is already open, returning the sticky error once the file is done.
+func (r *fileReader) open() error {
+	if r.err != nil || r.file != nil {
+		return r.err
+	}
+
+	if r.file, r.err = os.Open(r.path); r.err != nil {
+		return r.err
+	}
+	if info, err := r.file.Stat(); err == nil {
+		r.size = info.Size()
+	}
+
+	return nil
+}
+
+// close closes the file, making err the result of further reads.
+func (r *fileReader) close(err error) {
+	_ = r.file.Close()
+	r.file, r.err = nil, err
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCommandFromTemplate verifies that the built command is passed through the customizers in order.
+func TestCommandFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	// Customizers ensures the customizers see the built command and run in order.
+	t.Run("Customizers", func(t *testing.T) {
+		var calls []string
+		command, err := CommandFromTemplate(context.Background(), "git log {{.Ref}}", map[string]string{"Ref": "main"},
+			func(c *exec.Cmd) {
+				calls = append(calls, "dir")
+				c.Dir = "/tmp"
+			},
+			func(c *exec.Cmd) {
+				calls = append(calls, "env")
+				c.Env = append(c.Env, "GIT_PAGER=cat")
+			},
+		)
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, []string{"git", "log", "main"}, command.Args, "Unexpected arguments")
+		assert.Equal(t, "/tmp", command.Dir, "Expected the working directory to be set")
+		assert.Equal(t, []string{"GIT_PAGER=cat"}, command.Env, "Expected the environment to be set")
+		assert.Equal(t, []string{"dir", "env"}, calls, "Expected the customizers to run in order")
+	})
+
+	// InvalidTemplate ensures template errors are returned before any customizer runs.
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		called := false
+		_, err := CommandFromTemplate(context.Background(), "", nil, func(*exec.Cmd) { called = true })
+
+		assert.Error(t, err, "Expected an error")
+		assert.False(t, called, "Expected no customizer to run")
+	})
+}
+
+// TestStdin verifies that the stdin customizers feed their input to the command.
+func TestStdin(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("cat"); err != nil {
+		t.Skip("cat is not available")
+	}
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("from file"), 0o600), "Expected the input file to be written")
+
+	cases := []struct {
+		name       string
+		customizer Customizer
+		expected   string
+	}{
+		{name: "String", customizer: StdinString("from string"), expected: "from string"},
+		{name: "Bytes", customizer: StdinBytes([]byte("from bytes")), expected: "from bytes"},
+		{name: "File", customizer: StdinFile(path), expected: "from file"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := CommandFromTemplate(context.Background(), "cat", nil, tt.customizer)
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+
+			output, err := command.Output()
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, string(output), "Test case %s failed", tt.name)
+		})
+	}
+
+	// MissingFile ensures a missing file is reported when the command runs.
+	t.Run("MissingFile", func(t *testing.T) {
+		command, err := CommandFromTemplate(context.Background(), "cat", nil, StdinFile(filepath.Join(t.TempDir(), "missing")))
+		assert.NoError(t, err, "Expected building the command to succeed")
+
+		_, err = command.Output()
+		assert.ErrorIs(t, err, os.ErrNotExist, "Expected the missing file to be reported")
+	})
+
+	// UnreadFile ensures the file is closed when the command exits without reading it.
+	t.Run("UnreadFile", func(t *testing.T) {
+		if _, err := exec.LookPath("true"); err != nil {
+			t.Skip("true is not available")
+		}
+
+		// Write more than a pipe buffer holds, so the copy to the command cannot finish.
+		large := filepath.Join(t.TempDir(), "large.bin")
+		assert.NoError(t, os.WriteFile(large, make([]byte, 1<<20), 0o600), "Expected the input file to be written")
+
+		command, err := CommandFromTemplate(context.Background(), "true", nil, StdinFile(large))
+		assert.NoError(t, err, "Expected building the command to succeed")
+
+		stats, _ := Run(command, RunOptions{})
+
+		reader := command.Stdin.(*fileReader)
+		assert.Nil(t, reader.file, "Expected the file to be closed")
+		assert.Equal(t, int64(1<<20), stats.InputBytes, "Expected the size of the input")
+	})
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/iox"
+	"github.com/SyntaxErrorLineNULL/common/metrics"
+)
+
+// Stats describes a finished command run by Run.
+type Stats struct {
+	// Name is the base name of the program, such as "git", used to group the metrics of a command.
+	Name string
+	// Duration is the time from starting the command until it finished.
+	Duration time.Duration
+	// ExitCode is the exit code of the command, or -1 if it could not be started or was killed by a signal.
+	ExitCode int
+	// OutputBytes is the number of bytes the command wrote to its standard output and error, or -1 if unknown,
+	// because a stream is connected to a file or discarded and therefore not copied by the program.
+	OutputBytes int64
+	// InputBytes is the size of the standard input fed by StdinString, StdinBytes or StdinFile, zero without
+	// input, or -1 if unknown, for other readers and for a file that could not be opened.
+	InputBytes int64
+	// Slow reports whether the command took longer than the SlowCommandThreshold.
+	Slow bool
+}
+
+// RunOptions configures Run.
+type RunOptions struct {
+	// SlowCommandThreshold marks commands running longer than this as slow and logs them.
+	// Zero disables slow-command detection.
+	SlowCommandThreshold time.Duration
+	// Logger receives a warning for every slow command. When nil, slog.Default() is used.
+	Logger *slog.Logger
+	// Observe, if set, receives the stats of the command, for example Metrics.Observe.
+	Observe func(Stats)
+}
+
+// Run starts the command, waits for it to finish and measures it. The standard output and error are counted
+// on their way to the writers configured on the command. To do so, Run wraps these writers while the command
+// runs and restores the Stdout and Stderr fields afterwards. Streams connected to an *os.File, such as the
+// terminal, or left nil are passed to the command unchanged, so it keeps writing to the file directly;
+// their output is not counted and OutputBytes is -1.
+// The stats are returned even when the command fails, together with the error of exec.Cmd.Run.
+func Run(command *exec.Cmd, opts RunOptions) (Stats, error) {
+	stats := Stats{Name: filepath.Base(command.Path), ExitCode: -1}
+
+	// Count the output. A writer shared by both streams must stay shared, since exec only then serializes the writes.
+	originalStdout, originalStderr := command.Stdout, command.Stderr
+	defer func() { command.Stdout, command.Stderr = originalStdout, originalStderr }()
+
+	stdout := countable(command.Stdout)
+	stderr := stdout
+	if command.Stderr != command.Stdout {
+		stderr = countable(command.Stderr)
+	}
+	if stdout != nil {
+		command.Stdout = stdout
+	}
+	if stderr != nil {
+		command.Stderr = stderr
+	}
+
+	start := time.Now()
+	err := command.Run()
+	stats.Duration = time.Since(start)
+
+	if command.ProcessState != nil {
+		stats.ExitCode = command.ProcessState.ExitCode()
+	}
+	stats.InputBytes = inputSize(command.Stdin)
+	switch {
+	case stdout == nil || stderr == nil:
+		stats.OutputBytes = -1
+	case stderr == stdout:
+		stats.OutputBytes = stdout.Count()
+	default:
+		stats.OutputBytes = stdout.Count() + stderr.Count()
+	}
+
+	if opts.SlowCommandThreshold > 0 && stats.Duration > opts.SlowCommandThreshold {
+		stats.Slow = true
+
+		logger := opts.Logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.Warn("slow command", "command", stats.Name, "duration", stats.Duration,
+			"threshold", opts.SlowCommandThreshold, "exit_code", stats.ExitCode)
+	}
+
+	if opts.Observe != nil {
+		opts.Observe(stats)
+	}
+
+	return stats, err
+}
+
+// countable wraps w to count the bytes written to it, or returns nil for a nil writer or an *os.File.
+// Exec connects those to the command directly, and wrapping them would put a pipe in between, which
+// changes how the command sees the stream, for example whether it is a terminal.
+func countable(w io.Writer) *iox.CountingWriter {
+	if w == nil {
+		return nil
+	}
+	if _, ok := w.(*os.File); ok {
+		return nil
+	}
+
+	return iox.NewCountingWriter(w)
+}
+
+// durationAlpha is the smoothing factor of the average duration kept by Metrics.
+const durationAlpha = 0.2
+
+// Summary aggregates the runs of a command.
+type Summary struct {
+	// Runs is the number of times the command ran.
+	Runs int64
+	// Failures is the number of runs that did not exit with code zero.
+	Failures int64
+	// Slow is the number of runs exceeding the slow-command threshold.
+	Slow int64
+	// AvgDuration is the moving average of the durations, weighted towards recent runs.
+	AvgDuration time.Duration
+	// MaxDuration is the longest run.
+	MaxDuration time.Duration
+	// OutputBytes is the total output of the runs whose output was counted.
+	OutputBytes int64
+}
+
+// commandMetrics holds the running aggregates of a command.
+type commandMetrics struct {
+	summary  Summary
+	duration *metrics.EWMA
+}
+
+// Metrics aggregates the stats of commands by name, to find the external tools slowing a program down.
+// Pass its Observe method as RunOptions.Observe. Metrics is safe for concurrent use.
+type Metrics struct {
+	// mu guards commands.
+	mu sync.Mutex
+	// commands holds the aggregates by command name.
+	commands map[string]*commandMetrics
+}
+
+// NewMetrics creates an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{commands: make(map[string]*commandMetrics)}
+}
+
+// Observe records the stats of a command run.
+func (m *Metrics) Observe(stats Stats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	command, ok := m.commands[stats.Name]
+	if !ok {
+		command = &commandMetrics{duration: metrics.NewEWMA(durationAlpha)}
+		m.commands[stats.Name] = command
+	}
+
+	command.summary.Runs++
+	if stats.ExitCode != 0 {
+		command.summary.Failures++
+	}
+	if stats.Slow {
+		command.summary.Slow++
+	}
+	command.duration.Add(float64(stats.Duration))
+	command.summary.MaxDuration = max(command.summary.MaxDuration, stats.Duration)
+	if stats.OutputBytes > 0 {
+		command.summary.OutputBytes += stats.OutputBytes
+	}
+}
+
+// Snapshot returns the summaries of all observed commands by name.
+func (m *Metrics) Snapshot() map[string]Summary {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]Summary, len(m.commands))
+	for name, command := range m.commands {
+		summary := command.summary
+		summary.AvgDuration = time.Duration(command.duration.Value())
+		snapshot[name] = summary
+	}
+
+	return snapshot
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRun verifies that Run measures commands and reports slow ones.
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+
+	// Stats ensures the exit code and the output of both streams are measured.
+	t.Run("Stats", func(t *testing.T) {
+		var output bytes.Buffer
+		command := exec.Command("sh", "-c", "printf abc; printf de >&2; exit 3")
+		command.Stdout = &output
+		command.Stderr = &output
+
+		stats, err := Run(command, RunOptions{})
+
+		var exitErr *exec.ExitError
+		assert.ErrorAs(t, err, &exitErr, "Expected the exit error")
+		assert.Equal(t, "sh", stats.Name, "Unexpected name")
+		assert.Equal(t, 3, stats.ExitCode, "Unexpected exit code")
+		assert.Equal(t, int64(5), stats.OutputBytes, "Expected both streams to be counted")
+		assert.Zero(t, stats.InputBytes, "Expected no input")
+		assert.Equal(t, 5, output.Len(), "Expected the output to reach the writer")
+		assert.False(t, stats.Slow, "Expected no slow-command detection without a threshold")
+	})
+
+	// Files ensures streams connected to files are passed to the command directly and not counted.
+	t.Run("Files", func(t *testing.T) {
+		file, err := os.Create(filepath.Join(t.TempDir(), "output"))
+		assert.NoError(t, err, "Expected no error")
+		defer file.Close()
+
+		var stderr bytes.Buffer
+		command := exec.Command("sh", "-c", "printf abc; printf de >&2")
+		command.Stdout = file
+		command.Stderr = &stderr
+
+		stats, err := Run(command, RunOptions{})
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, int64(-1), stats.OutputBytes, "Expected the output to be unknown")
+		assert.Same(t, file, command.Stdout, "Expected the file to stay the standard output")
+		assert.Same(t, &stderr, command.Stderr, "Expected the standard error to be restored")
+		assert.Equal(t, "de", stderr.String(), "Expected the output to reach the writer")
+
+		data, err := os.ReadFile(file.Name())
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "abc", string(data), "Expected the output to reach the file")
+	})
+
+	// NotStarted ensures a missing program is reported with exit code -1.
+	t.Run("NotStarted", func(t *testing.T) {
+		stats, err := Run(exec.Command("/nonexistent/program"), RunOptions{})
+
+		assert.Error(t, err, "Expected an error")
+		assert.Equal(t, -1, stats.ExitCode, "Unexpected exit code")
+	})
+
+	// Slow ensures commands exceeding the threshold are flagged, logged and observed.
+	t.Run("Slow", func(t *testing.T) {
+		var logs bytes.Buffer
+		metrics := NewMetrics()
+
+		for _, script := range []string{"sleep 0.2", "true", "exit 1"} {
+			command, err := CommandFromTemplate(context.Background(), "sh -c {{.}}", script)
+			assert.NoError(t, err, "Expected no error")
+
+			_, _ = Run(command, RunOptions{
+				SlowCommandThreshold: 100 * time.Millisecond,
+				Logger:               slog.New(slog.NewTextHandler(&logs, nil)),
+				Observe:              metrics.Observe,
+			})
+		}
+
+		summary := metrics.Snapshot()["sh"]
+		assert.Equal(t, int64(3), summary.Runs, "Unexpected runs")
+		assert.Equal(t, int64(1), summary.Failures, "Unexpected failures")
+		assert.Equal(t, int64(1), summary.Slow, "Unexpected slow runs")
+		assert.GreaterOrEqual(t, summary.MaxDuration, 200*time.Millisecond, "Unexpected longest run")
+		assert.Positive(t, summary.AvgDuration, "Expected an average duration")
+		assert.Contains(t, logs.String(), "slow command", "Expected the slow command to be logged")
+	})
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BuildFromTemplate builds a command line from a template such as "kubectl logs {{.Pod}} -n {{.Namespace}}"
+// and returns it as an argv slice, where the first element is the program and the rest are its arguments.
+// The template is split into words before any substitution happens, and every word is rendered on its own,
+// so a substituted value always stays inside a single argument no matter which spaces, quotes or shell
+// metacharacters it contains. The result is meant to be passed to exec.Command directly and never to a shell.
+//
+// Words are separated by unquoted whitespace outside of template actions. Single or double quotes can be
+// used to keep literal whitespace inside a word; the quotes themselves are removed. Referencing a missing
+// key in a map is reported as an error instead of producing "<no value>".
+func BuildFromTemplate(text string, data any) ([]string, error) {
+	// Split the template into words while it still only contains trusted text.
+	words, err := splitTemplateWords(text)
+	if err != nil {
+		return nil, err
+	}
+
+	// There must be at least a program to run.
+	if len(words) == 0 {
+		return nil, errors.New("command template is empty")
+	}
+
+	argv := make([]string, 0, len(words))
+	for _, word := range words {
+		// Parse and execute every word separately, so each one maps to exactly one argument.
+		tmpl, err := template.New("cmd").Option("missingkey=error").Parse(word)
+		if err != nil {
+			return nil, err
+		}
+
+		var arg strings.Builder
+		if err = tmpl.Execute(&arg, data); err != nil {
+			return nil, err
+		}
+
+		argv = append(argv, arg.String())
+	}
+
+	// The program name must not be empty after substitution.
+	if argv[0] == "" {
+		return nil, errors.New("command template produced an empty program name")
+	}
+
+	return argv, nil
+}
+
+// splitTemplateWords splits a command template into words. Whitespace inside template actions
+// ("{{" ... "}}") and inside single or double quotes does not split words, and quotes outside of
+// actions are removed. An unterminated action or quote is reported as an error.
+func splitTemplateWords(text string) ([]string, error) {
+	var (
+		words []string
+		word  strings.Builder
+		// inWord reports whether a word has been started, which keeps empty quoted words like "".
+		inWord bool
+		// quote holds the active quote character, or zero when outside quotes.
+		quote rune
+	)
+
+	for i := 0; i < len(text); {
+		// Copy template actions verbatim, including any whitespace or quotes inside them.
+		if strings.HasPrefix(text[i:], "{{") {
+			end := strings.Index(text[i:], "}}")
+			if end < 0 {
+				return nil, errors.New("command template has an unterminated action")
+			}
+			word.WriteString(text[i : i+end+2])
+			inWord = true
+			i += end + 2
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+
+		switch {
+		case quote != 0 && r == quote:
+			// Closing quote: keep the word open, drop the quote character.
+			quote = 0
+		case quote != 0:
+			word.WriteRune(r)
+		case r == '"' || r == '\'':
+			// Opening quote: start a word even if it ends up empty.
+			quote = r
+			inWord = true
+		case unicode.IsSpace(r):
+			// Unquoted whitespace ends the current word.
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, errors.New("command template has an unterminated quote")
+	}
+
+	// Flush the last word.
+	if inWord {
+		words = append(words, word.String())
+	}
+
+	return words, nil
+}
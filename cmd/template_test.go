@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBuildFromTemplate verifies that substituted values always end up in a single argument.
+func TestBuildFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	// Substitution checks the produced argv for a range of templates.
+	t.Run("Substitution", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			template string
+			data     any
+			expected []string
+		}{
+			{
+				name:     "Simple substitution",
+				template: "kubectl logs {{.Pod}} -n {{.Namespace}}",
+				data:     map[string]string{"Pod": "api-0", "Namespace": "prod"},
+				expected: []string{"kubectl", "logs", "api-0", "-n", "prod"},
+			},
+			{
+				name:     "Value with spaces stays one argument",
+				template: "grep {{.Pattern}} file.txt",
+				data:     map[string]string{"Pattern": "a b c"},
+				expected: []string{"grep", "a b c", "file.txt"},
+			},
+			{
+				name:     "Shell metacharacters are not interpreted",
+				template: "echo {{.Text}}",
+				data:     map[string]string{"Text": "x; rm -rf / && $(id)"},
+				expected: []string{"echo", "x; rm -rf / && $(id)"},
+			},
+			{
+				name:     "Substitution inside a word",
+				template: "app --name={{.Name}}",
+				data:     struct{ Name string }{Name: "my app"},
+				expected: []string{"app", "--name=my app"},
+			},
+			{
+				name:     "Action containing spaces",
+				template: `app {{ index .Args "first" }}`,
+				data:     map[string]map[string]string{"Args": {"first": "one"}},
+				expected: []string{"app", "one"},
+			},
+			{
+				name:     "Quoted literal with spaces",
+				template: `git commit -m "initial commit" ''`,
+				data:     nil,
+				expected: []string{"git", "commit", "-m", "initial commit", ""},
+			},
+			{
+				name:     "Empty value becomes an empty argument",
+				template: "app {{.Empty}} end",
+				data:     map[string]string{"Empty": ""},
+				expected: []string{"app", "", "end"},
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				argv, err := BuildFromTemplate(tt.template, tt.data)
+				assert.NoError(t, err, "Expected no error for case: %s", tt.name)
+				assert.Equal(t, tt.expected, argv, "Unexpected argv for case: %s", tt.name)
+			})
+		}
+	})
+
+	// Errors checks that invalid templates and data are rejected.
+	t.Run("Errors", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			template string
+			data     any
+		}{
+			{name: "Empty template", template: "   ", data: nil},
+			{name: "Unterminated action", template: "app {{.Pod", data: nil},
+			{name: "Unterminated quote", template: `app "arg`, data: nil},
+			{name: "Missing key", template: "app {{.Missing}}", data: map[string]string{}},
+			{name: "Invalid action", template: "app {{.Pod | nosuchfunc}}", data: nil},
+			{name: "Empty program", template: "{{.Program}} arg", data: map[string]string{"Program": ""}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := BuildFromTemplate(tt.template, tt.data)
+				assert.Error(t, err, "Expected an error for case: %s", tt.name)
+			})
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package container
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// delayedItem is an item waiting in a DelayQueue together with the moment it becomes due.
+type delayedItem[T any] struct {
+	// item is the queued value.
+	item T
+	// due is the moment the item may be popped.
+	due time.Time
+	// sequence breaks ties between items due at the same moment, keeping them in insertion order.
+	sequence uint64
+}
+
+// DelayQueue is an in-memory queue whose items can only be popped once they are due.
+// Items are ordered by their due time, and items due at the same moment are popped in insertion order.
+// Pop blocks until the earliest item is due, waking up early when an earlier item is pushed.
+// DelayQueue is safe for concurrent use by multiple goroutines.
+type DelayQueue[T any] struct {
+	// mu guards the queue and the sequence counter.
+	mu sync.Mutex
+	// queue holds the pending items ordered by due time.
+	queue *PriorityQueue[delayedItem[T]]
+	// sequence is incremented for every pushed item.
+	sequence uint64
+	// changed is closed and replaced whenever an item is pushed, waking up all waiting consumers.
+	changed chan struct{}
+	// now returns the current time and can be replaced in tests.
+	now func() time.Time
+}
+
+// NewDelayQueue creates an empty DelayQueue.
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{
+		queue: NewPriorityQueue(func(a, b delayedItem[T]) bool {
+			if a.due.Equal(b.due) {
+				return a.sequence < b.sequence
+			}
+			return a.due.Before(b.due)
+		}),
+		changed: make(chan struct{}),
+		now:     time.Now,
+	}
+}
+
+// Push adds the item to the queue, making it available once the due time has passed.
+func (q *DelayQueue[T]) Push(item T, due time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sequence++
+	q.queue.Push(delayedItem[T]{item: item, due: due, sequence: q.sequence})
+
+	// Wake up all waiting consumers, since the new item may be due earlier than the ones they wait for.
+	// Waking only one would leave the others asleep when several items are pushed back to back.
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// PushAfter adds the item to the queue, making it available after the given delay.
+func (q *DelayQueue[T]) PushAfter(item T, delay time.Duration) {
+	q.Push(item, q.now().Add(delay))
+}
+
+// TryPop removes and returns the earliest item if it is already due, without blocking.
+func (q *DelayQueue[T]) TryPop() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, _, ok := q.popDue()
+
+	return item, ok
+}
+
+// Pop blocks until the earliest item is due, then removes and returns it.
+// It returns the context error if the context is done before an item becomes due.
+func (q *DelayQueue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.mu.Lock()
+		item, wait, ok := q.popDue()
+		changed := q.changed
+		q.mu.Unlock()
+
+		if ok {
+			return item, nil
+		}
+
+		// Wait until the head is due, a new item is pushed, or the context is done.
+		// A negative wait means the queue is empty, so only a push or cancellation can wake us.
+		var (
+			timer   *time.Timer
+			timeout <-chan time.Time
+		)
+		if wait >= 0 {
+			timer = time.NewTimer(wait)
+			timeout = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			stopTimer(timer)
+			var zero T
+			return zero, ctx.Err()
+		case <-changed:
+			stopTimer(timer)
+		case <-timeout:
+		}
+	}
+}
+
+// Chan starts a goroutine that pops due items and sends them to the returned channel.
+// The channel is closed once the context is done.
+func (q *DelayQueue[T]) Chan(ctx context.Context) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for {
+			item, err := q.Pop(ctx)
+			if err != nil {
+				return
+			}
+
+			select {
+			case out <- item:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// Len returns the number of items in the queue, including those that are not due yet.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.queue.Len()
+}
+
+// popDue pops the head of the queue if it is due. Otherwise it returns the time left until the head
+// is due, or a negative duration if the queue is empty. The caller must hold the mutex.
+func (q *DelayQueue[T]) popDue() (item T, wait time.Duration, ok bool) {
+	head, ok := q.queue.Peek()
+	if !ok {
+		return item, -1, false
+	}
+
+	if wait = head.due.Sub(q.now()); wait > 0 {
+		return item, wait, false
+	}
+
+	q.queue.Pop()
+
+	return head.item, 0, true
+}
+
+// stopTimer stops the timer if it was started.
+func stopTimer(timer *time.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
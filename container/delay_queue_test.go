@@ -0,0 +1,161 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDelayQueue verifies that DelayQueue releases items only once they are due.
+func TestDelayQueue(t *testing.T) {
+	t.Parallel()
+
+	// TryPopNotDue ensures items that are not due cannot be popped.
+	t.Run("TryPopNotDue", func(t *testing.T) {
+		queue := NewDelayQueue[string]()
+		queue.PushAfter("later", time.Hour)
+
+		_, ok := queue.TryPop()
+		assert.False(t, ok, "Expected the item not to be due yet")
+		assert.Equal(t, 1, queue.Len(), "Expected the item to stay in the queue")
+	})
+
+	// OrderByDueTime ensures due items are popped by due time and then by insertion order.
+	t.Run("OrderByDueTime", func(t *testing.T) {
+		queue := NewDelayQueue[string]()
+		now := time.Now()
+		queue.Push("second", now.Add(-time.Second))
+		queue.Push("first", now.Add(-time.Minute))
+		queue.Push("third", now.Add(-time.Second))
+
+		var popped []string
+		for {
+			item, ok := queue.TryPop()
+			if !ok {
+				break
+			}
+			popped = append(popped, item)
+		}
+
+		assert.Equal(t, []string{"first", "second", "third"}, popped, "Expected items by due time and insertion order")
+	})
+
+	// PopWaitsUntilDue ensures Pop blocks until the item is due.
+	t.Run("PopWaitsUntilDue", func(t *testing.T) {
+		queue := NewDelayQueue[int]()
+		start := time.Now()
+		queue.PushAfter(1, 50*time.Millisecond)
+
+		item, err := queue.Pop(context.Background())
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, 1, item, "Unexpected item")
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "Expected Pop to wait for the due time")
+	})
+
+	// PopWakesOnEarlierPush ensures an earlier item pushed while waiting is popped first.
+	t.Run("PopWakesOnEarlierPush", func(t *testing.T) {
+		queue := NewDelayQueue[string]()
+		queue.PushAfter("late", time.Hour)
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			queue.PushAfter("soon", 10*time.Millisecond)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		item, err := queue.Pop(ctx)
+		assert.NoError(t, err, "Expected the earlier item to be popped")
+		assert.Equal(t, "soon", item, "Unexpected item")
+	})
+
+	// ConcurrentPop ensures every waiting consumer is woken when several items are pushed back to back.
+	t.Run("ConcurrentPop", func(t *testing.T) {
+		queue := NewDelayQueue[int]()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		const consumers = 32
+		results := make(chan int, consumers)
+		for range consumers {
+			go func() {
+				item, err := queue.Pop(ctx)
+				assert.NoError(t, err, "Expected every consumer to receive an item")
+				results <- item
+			}()
+		}
+
+		// Push while the consumers are still starting, so that some of them are not waiting yet.
+		want := make([]int, consumers)
+		for i := range consumers {
+			want[i] = i
+			queue.PushAfter(i, 0)
+		}
+
+		popped := make([]int, 0, consumers)
+		for range consumers {
+			popped = append(popped, <-results)
+		}
+		assert.ElementsMatch(t, want, popped, "Expected every item to be popped")
+	})
+
+	// PopCancelled ensures Pop returns the context error when cancelled.
+	t.Run("PopCancelled", func(t *testing.T) {
+		queue := NewDelayQueue[int]()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := queue.Pop(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the context error")
+	})
+
+	// Chan ensures due items are delivered through the channel, which is closed on cancellation.
+	t.Run("Chan", func(t *testing.T) {
+		queue := NewDelayQueue[int]()
+		queue.PushAfter(2, 20*time.Millisecond)
+		queue.PushAfter(1, 10*time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		items := queue.Chan(ctx)
+
+		assert.Equal(t, 1, <-items, "Expected the earliest item first")
+		assert.Equal(t, 2, <-items, "Expected the second item next")
+
+		cancel()
+		_, open := <-items
+		assert.False(t, open, "Expected the channel to be closed after cancellation")
+	})
+}
+
+// TestDelayQueueChanNoLeak verifies that the goroutine started by Chan exits once the context is done.
+// The test does not run in parallel, so the leak checker only sees goroutines started by this test.
+func TestDelayQueueChanNoLeak(t *testing.T) {
+	test.VerifyNoLeaks(t)
+
+	queue := NewDelayQueue[int]()
+	queue.PushAfter(1, time.Hour)
+
+	ctx, cancel := context.WithCancel(test.Context(t))
+	items := queue.Chan(ctx)
+
+	// Push an item that becomes due shortly and wait for it to be delivered.
+	queue.PushAfter(2, 10*time.Millisecond)
+	var received []int
+	test.Eventually(t, func() bool {
+		select {
+		case item := <-items:
+			received = append(received, item)
+		default:
+		}
+		return len(received) == 1
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []int{2}, received, "Expected the due item to be delivered")
+
+	cancel()
+}
@@ -0,0 +1,235 @@
+package container
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lfuEntry is the payload stored in every element of the LFU frequency lists.
+type lfuEntry[K comparable, V any] struct {
+	// key is the key of the entry, needed to remove it from the index on eviction.
+	key K
+	// value is the cached value.
+	value V
+	// frequency is the number of times the entry has been accessed, including its insertion.
+	frequency int
+}
+
+// LFU is a fixed-capacity cache that evicts the least frequently used entry when it is full.
+// Entries with the same frequency are evicted in least recently used order. All operations except Remove
+// run in O(1) by keeping one list per access frequency; Remove may scan the frequencies present to find
+// the new lowest one. An optional callback is invoked for every evicted entry.
+// LFU is safe for concurrent use by multiple goroutines.
+type LFU[K comparable, V any] struct {
+	// mu guards the fields below.
+	mu sync.Mutex
+	// capacity is the maximum number of entries kept in the cache.
+	capacity int
+	// items indexes the list elements by key.
+	items map[K]*list.Element
+	// frequencies holds one list per access frequency, ordered from the least to the most recently used.
+	frequencies map[int]*list.List
+	// minFrequency is the lowest frequency currently present in the cache.
+	minFrequency int
+	// onEvict is called with the key and value of every evicted entry, if set.
+	onEvict func(key K, value V)
+}
+
+// NewLFU creates a new LFU cache holding at most capacity entries.
+// A capacity lower than one is raised to one. The onEvict callback may be nil; when set,
+// it is called while the cache lock is held, so it must not call back into the cache.
+func NewLFU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LFU[K, V] {
+	// A cache must be able to hold at least one entry.
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &LFU[K, V]{
+		capacity:    capacity,
+		items:       make(map[K]*list.Element, capacity),
+		frequencies: make(map[int]*list.List),
+		onEvict:     onEvict,
+	}
+}
+
+// Get returns the value stored under the key and increments its access frequency.
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return c.touch(element).value, true
+}
+
+// Peek returns the value stored under the key without changing its access frequency.
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return element.Value.(*lfuEntry[K, V]).value, true
+}
+
+// Set stores the value under the key. Updating an existing entry counts as an access.
+// If the cache is full, the least frequently used entry is evicted. It reports whether an eviction happened.
+func (c *LFU[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Update an existing entry and count the update as an access.
+	if element, ok := c.items[key]; ok {
+		c.touch(element).value = value
+		return false
+	}
+
+	// Make room for the new entry if the cache is full.
+	evicted := false
+	if len(c.items) >= c.capacity {
+		c.evict()
+		evicted = true
+	}
+
+	// New entries always start with a frequency of one, which becomes the minimum.
+	entry := &lfuEntry[K, V]{key: key, value: value, frequency: 1}
+	c.items[key] = c.frequencyList(1).PushBack(entry)
+	c.minFrequency = 1
+
+	return evicted
+}
+
+// Remove deletes the entry stored under the key and reports whether it was present.
+// The eviction callback is not called for explicitly removed entries.
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	entry := element.Value.(*lfuEntry[K, V])
+	c.unlink(element, entry.frequency)
+	delete(c.items, key)
+
+	// The minimum frequency may have disappeared together with the entry, so recompute it.
+	if _, ok = c.frequencies[c.minFrequency]; !ok {
+		c.minFrequency = c.lowestFrequency()
+	}
+
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *LFU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.items)
+}
+
+// Frequency returns the access frequency of the entry stored under the key, or zero if it is absent.
+func (c *LFU[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.items[key]; ok {
+		return element.Value.(*lfuEntry[K, V]).frequency
+	}
+
+	return 0
+}
+
+// Purge removes all entries from the cache without calling the eviction callback.
+func (c *LFU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element, c.capacity)
+	c.frequencies = make(map[int]*list.List)
+	c.minFrequency = 0
+}
+
+// touch increments the access frequency of the element, moving it to the next frequency list,
+// and returns its entry. The caller must hold the mutex.
+func (c *LFU[K, V]) touch(element *list.Element) *lfuEntry[K, V] {
+	entry := element.Value.(*lfuEntry[K, V])
+	c.unlink(element, entry.frequency)
+
+	// If the entry was the last one with the minimum frequency, the minimum moves up with it.
+	if c.minFrequency == entry.frequency {
+		if _, ok := c.frequencies[entry.frequency]; !ok {
+			c.minFrequency++
+		}
+	}
+
+	entry.frequency++
+	c.items[entry.key] = c.frequencyList(entry.frequency).PushBack(entry)
+
+	return entry
+}
+
+// evict removes the least recently used entry among those with the lowest frequency.
+// The caller must hold the mutex.
+func (c *LFU[K, V]) evict() {
+	bucket, ok := c.frequencies[c.minFrequency]
+	if !ok {
+		return
+	}
+
+	element := bucket.Front()
+	entry := element.Value.(*lfuEntry[K, V])
+	c.unlink(element, entry.frequency)
+	delete(c.items, entry.key)
+
+	if c.onEvict != nil {
+		c.onEvict(entry.key, entry.value)
+	}
+}
+
+// unlink removes the element from the list of the given frequency, dropping the list once it is empty.
+// The caller must hold the mutex.
+func (c *LFU[K, V]) unlink(element *list.Element, frequency int) {
+	bucket := c.frequencies[frequency]
+	bucket.Remove(element)
+
+	if bucket.Len() == 0 {
+		delete(c.frequencies, frequency)
+	}
+}
+
+// frequencyList returns the list for the given frequency, creating it if needed.
+// The caller must hold the mutex.
+func (c *LFU[K, V]) frequencyList(frequency int) *list.List {
+	bucket, ok := c.frequencies[frequency]
+	if !ok {
+		bucket = list.New()
+		c.frequencies[frequency] = bucket
+	}
+
+	return bucket
+}
+
+// lowestFrequency scans the frequency lists for the lowest frequency present, or zero if the cache is empty.
+// It is only needed after an explicit removal, so the scan does not affect the O(1) cost of the other operations.
+// The caller must hold the mutex.
+func (c *LFU[K, V]) lowestFrequency() int {
+	lowest := 0
+	for frequency := range c.frequencies {
+		if lowest == 0 || frequency < lowest {
+			lowest = frequency
+		}
+	}
+
+	return lowest
+}
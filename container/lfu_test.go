@@ -0,0 +1,133 @@
+package container
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLFU verifies that the LFU cache evicts the least frequently used entries.
+func TestLFU(t *testing.T) {
+	t.Parallel()
+
+	// SetAndGet ensures stored values can be retrieved and accesses are counted.
+	t.Run("SetAndGet", func(t *testing.T) {
+		cache := NewLFU[string, int](2, nil)
+		cache.Set("a", 1)
+		cache.Get("a")
+
+		value, ok := cache.Get("a")
+		assert.True(t, ok, "Expected the key to be present")
+		assert.Equal(t, 1, value, "Unexpected value")
+		assert.Equal(t, 3, cache.Frequency("a"), "Expected the insertion and two reads to be counted")
+		assert.Zero(t, cache.Frequency("missing"), "Expected zero frequency for a missing key")
+	})
+
+	// EvictsLeastFrequentlyUsed ensures the entry with the lowest frequency is evicted.
+	t.Run("EvictsLeastFrequentlyUsed", func(t *testing.T) {
+		var evicted []string
+		cache := NewLFU(2, func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Get("a")
+		cache.Get("a")
+		cache.Get("b")
+
+		assert.True(t, cache.Set("c", 3), "Expected an eviction")
+		assert.Equal(t, []string{"b"}, evicted, "Expected b to be evicted")
+
+		_, ok := cache.Peek("a")
+		assert.True(t, ok, "Expected the most frequently used entry to stay")
+	})
+
+	// TieBreaksByRecency ensures entries with equal frequency are evicted in least recently used order.
+	t.Run("TieBreaksByRecency", func(t *testing.T) {
+		var evicted []string
+		cache := NewLFU(3, func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Set("c", 3)
+		cache.Set("d", 4)
+		cache.Set("e", 5)
+
+		assert.Equal(t, []string{"a", "b"}, evicted, "Expected the oldest entries to be evicted first")
+	})
+
+	// NewEntryResetsMinimum ensures a new entry becomes the next eviction candidate.
+	t.Run("NewEntryResetsMinimum", func(t *testing.T) {
+		cache := NewLFU[string, int](2, nil)
+		cache.Set("a", 1)
+		cache.Get("a")
+		cache.Set("b", 2)
+		cache.Get("b")
+		cache.Get("b")
+
+		// Inserting "c" evicts "a" (frequency 2), then "c" with frequency 1 is the next candidate.
+		cache.Set("c", 3)
+		cache.Set("d", 4)
+
+		_, okB := cache.Peek("b")
+		_, okC := cache.Peek("c")
+		assert.True(t, okB, "Expected b to survive")
+		assert.False(t, okC, "Expected c to be evicted")
+	})
+
+	// RemoveRecomputesMinimum ensures removing the only entry with the minimum frequency keeps eviction correct.
+	t.Run("RemoveRecomputesMinimum", func(t *testing.T) {
+		var evicted []string
+		cache := NewLFU(2, func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Get("b")
+		cache.Get("b")
+
+		assert.True(t, cache.Remove("a"), "Expected a to be removed")
+		assert.False(t, cache.Remove("a"), "Expected a second removal to report absence")
+
+		cache.Set("c", 3)
+		cache.Set("d", 4)
+		assert.Equal(t, []string{"c"}, evicted, "Expected the least frequently used entry to be evicted")
+	})
+
+	// UpdateExisting ensures updating a key counts as an access and does not evict.
+	t.Run("UpdateExisting", func(t *testing.T) {
+		cache := NewLFU[string, int](1, nil)
+		cache.Set("a", 1)
+
+		assert.False(t, cache.Set("a", 2), "Expected no eviction on update")
+		assert.Equal(t, 2, cache.Frequency("a"), "Expected the update to count as an access")
+	})
+
+	// Purge ensures all entries are dropped.
+	t.Run("Purge", func(t *testing.T) {
+		cache := NewLFU[int, int](0, nil)
+		cache.Set(1, 1)
+		cache.Purge()
+
+		assert.Zero(t, cache.Len(), "Expected an empty cache after purge")
+		cache.Set(2, 2)
+		assert.Equal(t, 1, cache.Len(), "Expected the cache to be usable after purge")
+	})
+
+	// Concurrent ensures the cache can be used from several goroutines at once.
+	t.Run("Concurrent", func(t *testing.T) {
+		cache := NewLFU[int, int](10, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				cache.Set(v%20, v)
+				cache.Get(v % 20)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, cache.Len(), 10, "Expected the cache to stay within its capacity")
+	})
+}
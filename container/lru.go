@@ -0,0 +1,160 @@
+package container
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lruEntry is the payload stored in every element of the LRU recency list.
+type lruEntry[K comparable, V any] struct {
+	// key is the key of the entry, needed to remove it from the index on eviction.
+	key K
+	// value is the cached value.
+	value V
+}
+
+// LRU is a fixed-capacity cache that evicts the least recently used entry when it is full.
+// All operations run in O(1). An optional callback is invoked for every evicted entry.
+// LRU is safe for concurrent use by multiple goroutines.
+type LRU[K comparable, V any] struct {
+	// mu guards the fields below.
+	mu sync.Mutex
+	// capacity is the maximum number of entries kept in the cache.
+	capacity int
+	// items indexes the recency list elements by key.
+	items map[K]*list.Element
+	// order keeps the entries from the most recently used (front) to the least recently used (back).
+	order *list.List
+	// onEvict is called with the key and value of every evicted entry, if set.
+	onEvict func(key K, value V)
+}
+
+// NewLRU creates a new LRU cache holding at most capacity entries.
+// A capacity lower than one is raised to one. The onEvict callback may be nil; when set,
+// it is called while the cache lock is held, so it must not call back into the cache.
+func NewLRU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LRU[K, V] {
+	// A cache must be able to hold at least one entry.
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &LRU[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+		onEvict:  onEvict,
+	}
+}
+
+// Get returns the value stored under the key and marks the entry as most recently used.
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	// Move the entry to the front, as it has just been used.
+	c.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// Peek returns the value stored under the key without updating its recency.
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+// Set stores the value under the key and marks the entry as most recently used.
+// If the cache is full, the least recently used entry is evicted. It reports whether an eviction happened.
+func (c *LRU[K, V]) Set(key K, value V) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Update an existing entry in place.
+	if element, ok := c.items[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(element)
+		return false
+	}
+
+	// Make room for the new entry if the cache is full.
+	evicted := false
+	if c.order.Len() >= c.capacity {
+		c.removeElement(c.order.Back(), true)
+		evicted = true
+	}
+
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+
+	return evicted
+}
+
+// Remove deletes the entry stored under the key and reports whether it was present.
+// The eviction callback is not called for explicitly removed entries.
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	c.removeElement(element, false)
+
+	return true
+}
+
+// Len returns the number of entries in the cache.
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Keys returns the keys of the cache from the most recently used to the least recently used.
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	keys := make([]K, 0, c.order.Len())
+	for element := c.order.Front(); element != nil; element = element.Next() {
+		keys = append(keys, element.Value.(*lruEntry[K, V]).key)
+	}
+
+	return keys
+}
+
+// Purge removes all entries from the cache without calling the eviction callback.
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element, c.capacity)
+	c.order.Init()
+}
+
+// removeElement unlinks the element from the cache and, if evict is set, reports it to the eviction callback.
+// The caller must hold the mutex.
+func (c *LRU[K, V]) removeElement(element *list.Element, evict bool) {
+	entry := c.order.Remove(element).(*lruEntry[K, V])
+	delete(c.items, entry.key)
+
+	if evict && c.onEvict != nil {
+		c.onEvict(entry.key, entry.value)
+	}
+}
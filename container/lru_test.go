@@ -0,0 +1,105 @@
+package container
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLRU verifies that the LRU cache evicts the least recently used entries.
+func TestLRU(t *testing.T) {
+	t.Parallel()
+
+	// SetAndGet ensures stored values can be retrieved.
+	t.Run("SetAndGet", func(t *testing.T) {
+		cache := NewLRU[string, int](2, nil)
+		cache.Set("a", 1)
+
+		value, ok := cache.Get("a")
+		assert.True(t, ok, "Expected the key to be present")
+		assert.Equal(t, 1, value, "Unexpected value")
+
+		_, ok = cache.Get("missing")
+		assert.False(t, ok, "Expected the key to be absent")
+	})
+
+	// EvictsLeastRecentlyUsed ensures the entry that was not used for the longest time is evicted.
+	t.Run("EvictsLeastRecentlyUsed", func(t *testing.T) {
+		var evicted []string
+		cache := NewLRU(2, func(key string, _ int) { evicted = append(evicted, key) })
+
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		// Using "a" makes "b" the least recently used entry.
+		cache.Get("a")
+
+		assert.True(t, cache.Set("c", 3), "Expected an eviction")
+		assert.Equal(t, []string{"b"}, evicted, "Expected b to be evicted")
+		assert.Equal(t, []string{"c", "a"}, cache.Keys(), "Unexpected recency order")
+	})
+
+	// PeekDoesNotTouch ensures Peek does not change the recency order.
+	t.Run("PeekDoesNotTouch", func(t *testing.T) {
+		cache := NewLRU[string, int](2, nil)
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+		cache.Peek("a")
+		cache.Set("c", 3)
+
+		_, ok := cache.Peek("a")
+		assert.False(t, ok, "Expected a to be evicted despite the Peek")
+	})
+
+	// UpdateExisting ensures updating a key replaces its value without evicting anything.
+	t.Run("UpdateExisting", func(t *testing.T) {
+		cache := NewLRU[string, int](1, nil)
+		cache.Set("a", 1)
+
+		assert.False(t, cache.Set("a", 2), "Expected no eviction on update")
+		value, _ := cache.Get("a")
+		assert.Equal(t, 2, value, "Expected the updated value")
+	})
+
+	// RemoveAndPurge ensures explicit removal does not trigger the eviction callback.
+	t.Run("RemoveAndPurge", func(t *testing.T) {
+		calls := 0
+		cache := NewLRU(3, func(string, int) { calls++ })
+		cache.Set("a", 1)
+		cache.Set("b", 2)
+
+		assert.True(t, cache.Remove("a"), "Expected a to be removed")
+		assert.False(t, cache.Remove("a"), "Expected a second removal to report absence")
+
+		cache.Purge()
+		assert.Zero(t, cache.Len(), "Expected an empty cache after purge")
+		assert.Zero(t, calls, "Expected no eviction callbacks")
+	})
+
+	// InvalidCapacity ensures a non-positive capacity is raised to one.
+	t.Run("InvalidCapacity", func(t *testing.T) {
+		cache := NewLRU[int, int](0, nil)
+		cache.Set(1, 1)
+		cache.Set(2, 2)
+
+		assert.Equal(t, 1, cache.Len(), "Expected a capacity of one")
+	})
+
+	// Concurrent ensures the cache can be used from several goroutines at once.
+	t.Run("Concurrent", func(t *testing.T) {
+		cache := NewLRU[int, int](10, nil)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(v int) {
+				defer wg.Done()
+				cache.Set(v, v)
+				cache.Get(v)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 10, cache.Len(), "Expected the cache to stay within its capacity")
+	})
+}
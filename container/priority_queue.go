@@ -0,0 +1,89 @@
+package container
+
+// PriorityQueue is a generic binary heap ordered by a comparator. The element for which less reports
+// true against all others is popped first, so a less of a < b yields a min-queue and a > b a max-queue.
+// Push and Pop run in O(log n), Peek and Len in O(1). PriorityQueue is not safe for concurrent use.
+type PriorityQueue[T any] struct {
+	// items holds the heap in array form: the children of index i are at 2i+1 and 2i+2.
+	items []T
+	// less orders the elements; the smallest element is at the root.
+	less func(a, b T) bool
+}
+
+// NewPriorityQueue creates an empty PriorityQueue ordered by less.
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// Push adds the item to the queue.
+func (q *PriorityQueue[T]) Push(item T) {
+	q.items = append(q.items, item)
+	q.up(len(q.items) - 1)
+}
+
+// Pop removes and returns the item with the highest priority, or false if the queue is empty.
+func (q *PriorityQueue[T]) Pop() (T, bool) {
+	var zero T
+	if len(q.items) == 0 {
+		return zero, false
+	}
+
+	// Move the last item to the root and restore the heap property.
+	last := len(q.items) - 1
+	item := q.items[0]
+	q.items[0] = q.items[last]
+	// Clear the vacated slot so the popped value can be garbage collected.
+	q.items[last] = zero
+	q.items = q.items[:last]
+	q.down(0)
+
+	return item, true
+}
+
+// Peek returns the item with the highest priority without removing it, or false if the queue is empty.
+func (q *PriorityQueue[T]) Peek() (T, bool) {
+	if len(q.items) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.items[0], true
+}
+
+// Len returns the number of items in the queue.
+func (q *PriorityQueue[T]) Len() int {
+	return len(q.items)
+}
+
+// up moves the item at index i towards the root until its parent is not greater than it.
+func (q *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !q.less(q.items[i], q.items[parent]) {
+			return
+		}
+		q.items[i], q.items[parent] = q.items[parent], q.items[i]
+		i = parent
+	}
+}
+
+// down moves the item at index i towards the leaves until none of its children is less than it.
+func (q *PriorityQueue[T]) down(i int) {
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < len(q.items) && q.less(q.items[left], q.items[smallest]) {
+			smallest = left
+		}
+		if right < len(q.items) && q.less(q.items[right], q.items[smallest]) {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+		q.items[i], q.items[smallest] = q.items[smallest], q.items[i]
+		i = smallest
+	}
+}
@@ -0,0 +1,64 @@
+package container
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPriorityQueue verifies that PriorityQueue pops items in comparator order.
+func TestPriorityQueue(t *testing.T) {
+	t.Parallel()
+
+	// Empty ensures Pop and Peek report an empty queue.
+	t.Run("Empty", func(t *testing.T) {
+		queue := NewPriorityQueue(func(a, b int) bool { return a < b })
+
+		_, ok := queue.Pop()
+		assert.False(t, ok, "Expected Pop to report an empty queue")
+		_, ok = queue.Peek()
+		assert.False(t, ok, "Expected Peek to report an empty queue")
+	})
+
+	// MinQueue ensures items are popped in ascending order.
+	t.Run("MinQueue", func(t *testing.T) {
+		queue := NewPriorityQueue(func(a, b int) bool { return a < b })
+		input := []int{5, 3, 9, 1, 7, 3, 8, 2}
+		for _, v := range input {
+			queue.Push(v)
+		}
+
+		head, _ := queue.Peek()
+		assert.Equal(t, 1, head, "Expected the smallest item at the head")
+		assert.Equal(t, len(input), queue.Len(), "Expected Peek not to remove the item")
+
+		var popped []int
+		for queue.Len() > 0 {
+			v, _ := queue.Pop()
+			popped = append(popped, v)
+		}
+
+		sort.Ints(input)
+		assert.Equal(t, input, popped, "Expected items in ascending order")
+	})
+
+	// MaxQueueWithStructs ensures the comparator decides the priority of arbitrary types.
+	t.Run("MaxQueueWithStructs", func(t *testing.T) {
+		type task struct {
+			name     string
+			priority int
+		}
+
+		queue := NewPriorityQueue(func(a, b task) bool { return a.priority > b.priority })
+		queue.Push(task{name: "low", priority: 1})
+		queue.Push(task{name: "high", priority: 10})
+		queue.Push(task{name: "mid", priority: 5})
+
+		first, _ := queue.Pop()
+		second, _ := queue.Pop()
+		third, _ := queue.Pop()
+
+		assert.Equal(t, []string{"high", "mid", "low"}, []string{first.name, second.name, third.name}, "Expected items by descending priority")
+	})
+}
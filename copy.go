@@ -0,0 +1,254 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// ErrInvalidCopyTarget is returned by CopyFields when dst is not a non-nil pointer to a struct
+// or src is not a struct or a pointer to a struct.
+var ErrInvalidCopyTarget = errors.New("common: CopyFields expects a pointer to a struct as dst and a struct as src")
+
+// ErrLossyConversion is returned by CopyFields when a number does not fit the numeric type of the destination
+// field, such as 300 copied to an int8, -1 to a uint or 3.7 to an int.
+var ErrLossyConversion = errors.New("common: number cannot be represented in the destination type")
+
+// CopyOptions configures CopyFields.
+type CopyOptions struct {
+	// Tag is the struct tag used to match fields, for example "json". Fields without the tag,
+	// or all fields when Tag is empty, are matched by their Go name. A tag name of "-" skips the field.
+	Tag string
+	// Converters convert between field types that cannot be assigned or converted directly.
+	Converters []Converter
+	// SkipZero leaves destination fields untouched when the source field holds the zero value,
+	// which allows applying partial updates.
+	SkipZero bool
+}
+
+// Converter converts a value of one type into another for CopyFields. Create it with NewConverter.
+type Converter struct {
+	from, to reflect.Type
+	convert  func(src reflect.Value) (reflect.Value, error)
+}
+
+// NewConverter creates a Converter from a function converting values of type S into values of type D.
+func NewConverter[S, D any](fn func(S) (D, error)) Converter {
+	return Converter{
+		from: reflect.TypeFor[S](),
+		to:   reflect.TypeFor[D](),
+		convert: func(src reflect.Value) (reflect.Value, error) {
+			dst, err := fn(src.Interface().(S))
+			if err != nil {
+				return reflect.Value{}, err
+			}
+
+			return reflect.ValueOf(&dst).Elem(), nil
+		},
+	}
+}
+
+// CopyFields copies the exported fields of src into the fields of dst with the same name, as defined by opts.Tag.
+// Values are assigned directly when possible; numeric values are converted between numeric types, pointers are
+// dereferenced or allocated as needed, and nested structs of different types are copied field by field.
+// Other type combinations require a matching converter in opts.Converters, otherwise an error is returned.
+// A number that the destination type cannot represent exactly is reported with ErrLossyConversion.
+// Fields without a counterpart in the other struct are skipped. A nil pointer as src copies nothing.
+func CopyFields(dst, src any, opts CopyOptions) error {
+	dstValue := reflect.ValueOf(dst)
+	if dstValue.Kind() != reflect.Pointer || dstValue.IsNil() || dstValue.Elem().Kind() != reflect.Struct {
+		return ErrInvalidCopyTarget
+	}
+
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() == reflect.Pointer {
+		// There is nothing to copy from a nil struct pointer.
+		if srcValue.IsNil() {
+			return nil
+		}
+		srcValue = srcValue.Elem()
+	}
+	if srcValue.Kind() != reflect.Struct {
+		return ErrInvalidCopyTarget
+	}
+
+	return copyStruct(dstValue.Elem(), srcValue, &opts)
+}
+
+// copyStruct copies the matching fields of the struct src into the settable struct dst.
+func copyStruct(dst, src reflect.Value, opts *CopyOptions) error {
+	dstFields := fieldIndexes(dst.Type(), opts.Tag)
+
+	// Walk the source fields in declaration order, so errors are reported deterministically.
+	for i := 0; i < src.NumField(); i++ {
+		name, ok := fieldName(src.Type().Field(i), opts.Tag)
+		if !ok {
+			continue
+		}
+
+		dstIndex, ok := dstFields[name]
+		if !ok {
+			continue
+		}
+
+		srcField := src.Field(i)
+		if opts.SkipZero && srcField.IsZero() {
+			continue
+		}
+
+		if err := copyValue(dst.Field(dstIndex), srcField, opts); err != nil {
+			return fmt.Errorf("common: copy field %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// copyValue assigns src to the settable value dst, converting it if the types differ.
+func copyValue(dst, src reflect.Value, opts *CopyOptions) error {
+	// Registered converters take precedence, so they can override the built-in rules.
+	for _, converter := range opts.Converters {
+		if converter.from == src.Type() && converter.to == dst.Type() {
+			converted, err := converter.convert(src)
+			if err != nil {
+				return err
+			}
+			dst.Set(converted)
+			return nil
+		}
+	}
+
+	switch {
+	case src.Type().AssignableTo(dst.Type()):
+		dst.Set(src)
+		return nil
+
+	case isNumber(src.Kind()) && isNumber(dst.Kind()):
+		// Numbers are converted only if the destination type can represent them.
+		if !fitsNumber(dst, src) {
+			return fmt.Errorf("%w: %v to %s", ErrLossyConversion, src, dst.Type())
+		}
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+
+	case src.Kind() == reflect.String && dst.Kind() == reflect.String:
+		// Conversions between string types keep the meaning of the value. Other conversions,
+		// such as from an integer to a string, are not what a field copy is expected to do.
+		dst.Set(src.Convert(dst.Type()))
+		return nil
+
+	case src.Kind() == reflect.Pointer:
+		// Copy the pointed-to value, a nil pointer leaves the destination untouched.
+		if src.IsNil() {
+			return nil
+		}
+		return copyValue(dst, src.Elem(), opts)
+
+	case dst.Kind() == reflect.Pointer:
+		// Allocate the destination value and copy into it.
+		value := reflect.New(dst.Type().Elem())
+		if err := copyValue(value.Elem(), src, opts); err != nil {
+			return err
+		}
+		dst.Set(value)
+		return nil
+
+	case src.Kind() == reflect.Struct && dst.Kind() == reflect.Struct:
+		return copyStruct(dst, src, opts)
+	}
+
+	return fmt.Errorf("cannot copy %s to %s", src.Type(), dst.Type())
+}
+
+// fieldIndexes maps the names used for matching to the indexes of the exported fields of t.
+func fieldIndexes(t reflect.Type, tag string) map[string]int {
+	fields := make(map[string]int, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		if name, ok := fieldName(t.Field(i), tag); ok {
+			fields[name] = i
+		}
+	}
+
+	return fields
+}
+
+// fieldName returns the name used to match the field, and false if the field does not take part in copying.
+func fieldName(field reflect.StructField, tag string) (string, bool) {
+	// Unexported fields cannot be read or set through reflection.
+	if !field.IsExported() {
+		return "", false
+	}
+
+	if tag == "" {
+		return field.Name, true
+	}
+
+	// Only the part before options such as ",omitempty" names the field.
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	default:
+		return name, true
+	}
+}
+
+// isNumber reports whether kind is an integer or floating-point kind.
+func isNumber(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+// isSigned reports whether kind is a signed integer kind.
+func isSigned(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Int64
+}
+
+// isUnsigned reports whether kind is an unsigned integer kind.
+func isUnsigned(kind reflect.Kind) bool {
+	return kind >= reflect.Uint && kind <= reflect.Uintptr
+}
+
+// fitsNumber reports whether the number src can be converted to the numeric type of dst without wrapping
+// around or being truncated. Floats must be integral to be copied to integers, while integers may lose
+// precision when copied to floats, as in any Go conversion.
+func fitsNumber(dst, src reflect.Value) bool {
+	switch {
+	case isSigned(src.Kind()):
+		v := src.Int()
+		switch {
+		case isSigned(dst.Kind()):
+			return !dst.OverflowInt(v)
+		case isUnsigned(dst.Kind()):
+			return v >= 0 && !dst.OverflowUint(uint64(v))
+		}
+
+	case isUnsigned(src.Kind()):
+		v := src.Uint()
+		switch {
+		case isSigned(dst.Kind()):
+			return v <= math.MaxInt64 && !dst.OverflowInt(int64(v))
+		case isUnsigned(dst.Kind()):
+			return !dst.OverflowUint(v)
+		}
+
+	default:
+		v := src.Float()
+		switch {
+		case isSigned(dst.Kind()):
+			// The bounds are exact powers of two, so the comparisons are exact as well.
+			return v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 && !dst.OverflowInt(int64(v))
+		case isUnsigned(dst.Kind()):
+			return v == math.Trunc(v) && v >= 0 && v < math.MaxUint64 && !dst.OverflowUint(uint64(v))
+		default:
+			return !dst.OverflowFloat(v)
+		}
+	}
+
+	// Integers always fit a float type.
+	return true
+}
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCopyFields verifies that CopyFields maps fields between structs of different types.
+func TestCopyFields(t *testing.T) {
+	t.Parallel()
+
+	type address struct {
+		City string
+	}
+
+	type task struct {
+		ID       int64  `json:"id"`
+		Title    string `json:"title"`
+		Priority int32  `json:"priority"`
+		Owner    *string
+		Address  address
+		Due      time.Time
+		Secret   string `json:"-"`
+	}
+
+	type taskModel struct {
+		Identifier int     `json:"id"`
+		Title      string  `json:"title"`
+		Priority   float64 `json:"priority"`
+		Owner      string
+		Address    *struct{ City string }
+		Due        time.Time
+		Secret     string
+	}
+
+	// ByTag ensures fields are matched by tag name and converted between compatible types.
+	t.Run("ByTag", func(t *testing.T) {
+		src := task{ID: 7, Title: "write tests", Priority: 2, Owner: Ptr("alice"), Address: address{City: "Riga"}, Secret: "hidden"}
+
+		var dst taskModel
+		err := CopyFields(&dst, &src, CopyOptions{Tag: "json"})
+		assert.NoError(t, err, "Expected no error")
+
+		assert.Equal(t, 7, dst.Identifier, "Expected the field to be matched by tag")
+		assert.Equal(t, "write tests", dst.Title, "Expected the title to be copied")
+		assert.Equal(t, 2.0, dst.Priority, "Expected the number to be converted")
+		assert.Equal(t, "alice", dst.Owner, "Expected the pointer to be dereferenced")
+		assert.Equal(t, "Riga", dst.Address.City, "Expected the nested struct to be copied")
+		assert.Empty(t, dst.Secret, "Expected the field tagged with - to be skipped")
+	})
+
+	// Converter ensures registered converters handle types without a built-in conversion.
+	t.Run("Converter", func(t *testing.T) {
+		due := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		type event struct{ Due time.Time }
+		type eventModel struct{ Due string }
+
+		var dst eventModel
+		err := CopyFields(&dst, event{Due: due}, CopyOptions{Converters: []Converter{
+			NewConverter(func(v time.Time) (string, error) { return v.Format(time.DateOnly), nil }),
+		}})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "2024-05-01", dst.Due, "Expected the converter to be used")
+	})
+
+	// ConverterError ensures an error of a converter is returned with the field name.
+	t.Run("ConverterError", func(t *testing.T) {
+		type source struct{ Count string }
+		type target struct{ Count int }
+
+		var dst target
+		err := CopyFields(&dst, source{Count: "many"}, CopyOptions{Converters: []Converter{
+			NewConverter(strconv.Atoi),
+		}})
+		assert.ErrorIs(t, err, strconv.ErrSyntax, "Expected the converter error")
+		assert.ErrorContains(t, err, "Count", "Expected the field name in the error")
+	})
+
+	// IncompatibleTypes ensures types without a conversion are reported.
+	t.Run("IncompatibleTypes", func(t *testing.T) {
+		type source struct{ Tags []string }
+		type target struct{ Tags string }
+
+		var dst target
+		err := CopyFields(&dst, source{Tags: []string{"a"}}, CopyOptions{})
+		assert.ErrorContains(t, err, "cannot copy []string to string", "Expected an error for incompatible types")
+	})
+
+	// NumericConversion ensures numbers are converted only when the destination type can represent them.
+	t.Run("NumericConversion", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			src      any
+			dst      any
+			expected any
+			lossy    bool
+		}{
+			{name: "Widening", src: &struct{ N int8 }{N: -5}, dst: &struct{ N int64 }{}, expected: int64(-5)},
+			{name: "Narrowing in range", src: &struct{ N int64 }{N: 100}, dst: &struct{ N int8 }{}, expected: int8(100)},
+			{name: "Integral float", src: &struct{ N float64 }{N: 3}, dst: &struct{ N int }{}, expected: 3},
+			{name: "Integer to float", src: &struct{ N int }{N: 3}, dst: &struct{ N float32 }{}, expected: float32(3)},
+			{name: "Signed overflow", src: &struct{ N int64 }{N: 300}, dst: &struct{ N int8 }{}, lossy: true},
+			{name: "Negative to unsigned", src: &struct{ N int }{N: -1}, dst: &struct{ N uint64 }{}, lossy: true},
+			{name: "Unsigned overflow", src: &struct{ N uint64 }{N: math.MaxUint64}, dst: &struct{ N int64 }{}, lossy: true},
+			{name: "Unsigned narrowing", src: &struct{ N uint16 }{N: 256}, dst: &struct{ N uint8 }{}, lossy: true},
+			{name: "Fractional float", src: &struct{ N float64 }{N: 3.7}, dst: &struct{ N int }{}, lossy: true},
+			{name: "Float out of range", src: &struct{ N float64 }{N: 1e20}, dst: &struct{ N int64 }{}, lossy: true},
+			{name: "Negative float to unsigned", src: &struct{ N float64 }{N: -2}, dst: &struct{ N uint }{}, lossy: true},
+			{name: "NaN to integer", src: &struct{ N float64 }{N: math.NaN()}, dst: &struct{ N int }{}, lossy: true},
+			{name: "Float32 overflow", src: &struct{ N float64 }{N: 1e300}, dst: &struct{ N float32 }{}, lossy: true},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				err := CopyFields(tt.dst, tt.src, CopyOptions{})
+				if tt.lossy {
+					assert.ErrorIs(t, err, ErrLossyConversion, "Test case %s failed", tt.name)
+					return
+				}
+
+				assert.NoError(t, err, "Test case %s failed", tt.name)
+				assert.Equal(t, tt.expected, reflect.ValueOf(tt.dst).Elem().Field(0).Interface(), "Test case %s failed", tt.name)
+			})
+		}
+	})
+
+	// SkipZero ensures zero source fields do not overwrite destination fields.
+	t.Run("SkipZero", func(t *testing.T) {
+		type patch struct {
+			Title string
+			Owner *string
+		}
+
+		dst := taskModel{Title: "old", Owner: "bob"}
+		err := CopyFields(&dst, patch{Title: "new"}, CopyOptions{SkipZero: true})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, taskModel{Title: "new", Owner: "bob"}, dst, "Expected only the set field to be copied")
+	})
+
+	// InvalidArguments ensures non-struct arguments are rejected.
+	t.Run("InvalidArguments", func(t *testing.T) {
+		var dst taskModel
+		assert.True(t, errors.Is(CopyFields(dst, task{}, CopyOptions{}), ErrInvalidCopyTarget), "Expected dst to be rejected")
+		assert.True(t, errors.Is(CopyFields(&dst, 1, CopyOptions{}), ErrInvalidCopyTarget), "Expected src to be rejected")
+		assert.NoError(t, CopyFields(&dst, (*task)(nil), CopyOptions{}), "Expected a nil source to copy nothing")
+	})
+}
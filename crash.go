@@ -0,0 +1,105 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/filesystem"
+)
+
+// crashDir holds the directory crash reports are written to, or an empty string when reporting is disabled.
+var crashDir atomic.Value
+
+// InstallCrashHandler enables crash reports written to dir. Once installed, HandleCrash and panics recovered
+// by SafeGo write a report with the panic value, the stack trace and the build information of the binary,
+// which helps investigating crashes of tools whose output is gone by the time somebody looks at them.
+// Passing an empty dir disables crash reports again.
+func InstallCrashHandler(dir string) {
+	crashDir.Store(dir)
+}
+
+// HandleCrash writes a crash report for a panic in progress and then continues panicking, so the process
+// still terminates with the usual panic output. Defer it first thing in main and in long-running goroutines:
+//
+//	func main() {
+//		common.InstallCrashHandler("/var/log/mytool/crash")
+//		defer common.HandleCrash()
+//		...
+//	}
+//
+// Without an installed crash handler it only re-panics.
+func HandleCrash() {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	// Report a failure to write the crash report, but never hide the original panic.
+	if _, err := WriteCrashReport(rec, debug.Stack()); err != nil {
+		fmt.Fprintf(os.Stderr, "common: write crash report: %v\n", err)
+	}
+
+	panic(rec)
+}
+
+// WriteCrashReport writes a crash report for the recovered value rec and its stack trace to the directory
+// set by InstallCrashHandler and returns the path of the report. If no crash handler is installed,
+// it does nothing and returns an empty path.
+func WriteCrashReport(rec any, stack []byte) (string, error) {
+	dir, _ := crashDir.Load().(string)
+	if dir == "" {
+		return "", nil
+	}
+
+	// Include the process ID, so concurrent crashes of several processes do not overwrite each other.
+	now := time.Now()
+	path := filepath.Join(dir, fmt.Sprintf("crash-%s-%d.txt", now.UTC().Format("20060102T150405.000000000"), os.Getpid()))
+
+	// Write atomically, so a crash while writing the report never leaves a truncated file behind.
+	file, err := filesystem.CreateAtomic(path, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	if _, err = file.WriteString(crashReport(rec, stack, now)); err != nil {
+		return "", err
+	}
+
+	if err = file.Commit(); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// crashReport formats the contents of a crash report.
+func crashReport(rec any, stack []byte, now time.Time) string {
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "time: %s\n", now.Format(time.RFC3339Nano))
+	fmt.Fprintf(&report, "panic: %v\n", rec)
+	fmt.Fprintf(&report, "command: %s\n", strings.Join(os.Args, " "))
+	fmt.Fprintf(&report, "pid: %d\n", os.Getpid())
+	fmt.Fprintf(&report, "go: %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+
+	// Describe the binary, which tells which version of the code crashed.
+	if info, ok := debug.ReadBuildInfo(); ok {
+		fmt.Fprintf(&report, "module: %s %s\n", info.Main.Path, info.Main.Version)
+		for _, setting := range info.Settings {
+			if strings.HasPrefix(setting.Key, "vcs.") {
+				fmt.Fprintf(&report, "%s: %s\n", setting.Key, setting.Value)
+			}
+		}
+	}
+
+	fmt.Fprintf(&report, "\n%s", stack)
+
+	return report.String()
+}
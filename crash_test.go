@@ -0,0 +1,66 @@
+package common
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCrashHandler verifies that crash reports are written by HandleCrash and SafeGo once installed.
+// The subtests share the global crash handler, so neither this test nor its subtests run in parallel.
+func TestCrashHandler(t *testing.T) {
+	t.Cleanup(func() { InstallCrashHandler("") })
+
+	// NotInstalled ensures nothing is written without an installed crash handler.
+	t.Run("NotInstalled", func(t *testing.T) {
+		InstallCrashHandler("")
+
+		path, err := WriteCrashReport("boom", []byte("stack"))
+		assert.NoError(t, err, "Expected no error")
+		assert.Empty(t, path, "Expected no report")
+	})
+
+	// HandleCrash ensures the report is written and the panic continues.
+	t.Run("HandleCrash", func(t *testing.T) {
+		dir := t.TempDir()
+		InstallCrashHandler(filepath.Join(dir, "crash"))
+
+		sample := errors.New("sample error")
+		assert.PanicsWithError(t, sample.Error(), func() {
+			defer HandleCrash()
+			panic(sample)
+		}, "Expected the panic to continue")
+
+		reports, err := filepath.Glob(filepath.Join(dir, "crash", "crash-*.txt"))
+		assert.NoError(t, err, "Expected no error")
+		assert.Len(t, reports, 1, "Expected a single crash report")
+
+		content, err := os.ReadFile(reports[0])
+		assert.NoError(t, err, "Expected the report to be readable")
+		assert.Contains(t, string(content), "panic: sample error", "Expected the panic value")
+		assert.Contains(t, string(content), "TestCrashHandler", "Expected the stack trace")
+	})
+
+	// SafeGo ensures panics recovered by SafeGo are reported as well.
+	t.Run("SafeGo", func(t *testing.T) {
+		dir := t.TempDir()
+		InstallCrashHandler(dir)
+
+		recovered := make(chan error, 1)
+		SafeGo(func() { panic("boom") }, func(err error) { recovered <- err })
+
+		select {
+		case <-recovered:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the panic to be reported")
+		}
+
+		reports, err := filepath.Glob(filepath.Join(dir, "crash-*.txt"))
+		assert.NoError(t, err, "Expected no error")
+		assert.Len(t, reports, 1, "Expected a crash report")
+	})
+}
@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/hkdf"
+)
+
+// X25519KeySize is the size in bytes of X25519 private and public keys.
+const X25519KeySize = 32
+
+// sealedBoxInfo is the HKDF context string binding derived keys to the sealed box construction.
+var sealedBoxInfo = []byte("common/crypto sealed box v1")
+
+// GenerateX25519KeyPair generates a new random X25519 key pair.
+// Both keys are returned as raw 32-byte slices, suitable for storage or transmission;
+// the public key can be shared freely, while the private key must be kept secret.
+func (srv *Crypto) GenerateX25519KeyPair() (privateKey, publicKey []byte, err error) {
+	// Generate the private key using the operating system's secure random source.
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Return the raw encodings of both keys.
+	return key.Bytes(), key.PublicKey().Bytes(), nil
+}
+
+// SharedSecret performs an X25519 Diffie-Hellman exchange between the local private key and the peer's public key.
+// Both sides of the exchange compute the same 32-byte secret. The raw secret is not uniformly random and
+// should be passed through a key derivation function before being used as an encryption key, as Seal does.
+func (srv *Crypto) SharedSecret(privateKey, peerPublicKey []byte) ([]byte, error) {
+	// Parse the local private key; this fails if the key does not have the expected size.
+	private, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the peer's public key.
+	public, err := ecdh.X25519().NewPublicKey(peerPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute the shared secret. An error is returned for low-order public keys, which would yield an all-zero secret.
+	return private.ECDH(public)
+}
+
+// Seal encrypts the plaintext for the owner of the recipient's public key, in the style of a NaCl sealed box.
+// A fresh ephemeral key pair is generated for every message, so the sender does not need a long-term key
+// and no key or IV has to be managed by the caller. The encryption key is derived with HKDF-SHA256 from the
+// X25519 shared secret and both public keys, and the payload is encrypted with XChaCha20-Poly1305.
+// The result is laid out as: ephemeral public key (32 bytes) || nonce (24 bytes) || ciphertext with tag.
+func (srv *Crypto) Seal(recipientPublicKey, plainText []byte) ([]byte, error) {
+	// Parse the recipient's public key before doing any work.
+	recipient, err := ecdh.X25519().NewPublicKey(recipientPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate the ephemeral key pair used only for this message.
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	// Agree on a shared secret with the recipient.
+	secret, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the AEAD key from the shared secret, bound to both public keys.
+	ephemeralPublicKey := ephemeral.PublicKey().Bytes()
+	aead, err := sealedBoxAEAD(secret, ephemeralPublicKey, recipient.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Allocate the output once: ephemeral key, nonce, ciphertext and authentication tag.
+	out := make([]byte, 0, X25519KeySize+aead.NonceSize()+len(plainText)+aead.Overhead())
+	out = append(out, ephemeralPublicKey...)
+
+	// Generate a random nonce; with 24 bytes random nonces are safe to use.
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out = append(out, nonce...)
+
+	// Encrypt and authenticate the plaintext, appending the ciphertext to the output.
+	return aead.Seal(out, nonce, plainText, nil), nil
+}
+
+// Open decrypts a message produced by Seal using the recipient's private key.
+// It returns an error if the message is malformed, was not sealed for this key, or has been tampered with.
+func (srv *Crypto) Open(privateKey, sealed []byte) ([]byte, error) {
+	// Parse the recipient's private key.
+	private, err := ecdh.X25519().NewPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The message must at least contain the ephemeral key, the nonce and the authentication tag.
+	if len(sealed) < X25519KeySize+chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead {
+		return nil, errors.New("sealed message is too short")
+	}
+
+	// Parse the sender's ephemeral public key from the message header.
+	ephemeral, err := ecdh.X25519().NewPublicKey(sealed[:X25519KeySize])
+	if err != nil {
+		return nil, err
+	}
+
+	// Recompute the shared secret on the recipient side.
+	secret, err := private.ECDH(ephemeral)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive the same AEAD key as the sender.
+	aead, err := sealedBoxAEAD(secret, ephemeral.Bytes(), private.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Split the remaining data into the nonce and the ciphertext, then decrypt and verify it.
+	nonce := sealed[X25519KeySize : X25519KeySize+aead.NonceSize()]
+	cipherText := sealed[X25519KeySize+aead.NonceSize():]
+
+	return aead.Open(nil, nonce, cipherText, nil)
+}
+
+// sealedBoxAEAD derives an XChaCha20-Poly1305 cipher from the shared secret using HKDF-SHA256.
+// Both public keys are used as the salt, so the key is bound to this particular pair of parties.
+func sealedBoxAEAD(secret, ephemeralPublicKey, recipientPublicKey []byte) (cipher.AEAD, error) {
+	salt := make([]byte, 0, len(ephemeralPublicKey)+len(recipientPublicKey))
+	salt = append(salt, ephemeralPublicKey...)
+	salt = append(salt, recipientPublicKey...)
+
+	// Expand the shared secret into a uniformly random key of the required size.
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, sealedBoxInfo), key); err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.NewX(key)
+}
@@ -0,0 +1,112 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSealedBox verifies the X25519 key agreement and the sealed box encryption built on top of it.
+func TestSealedBox(t *testing.T) {
+	t.Parallel()
+
+	// Initialize a Crypto instance shared by all subtests.
+	crypto := &Crypto{}
+
+	// GenerateKeyPair ensures generated keys have the expected size and differ between calls.
+	t.Run("GenerateKeyPair", func(t *testing.T) {
+		privateKey, publicKey, err := crypto.GenerateX25519KeyPair()
+		assert.NoError(t, err, "Expected no error while generating a key pair")
+		assert.Len(t, privateKey, X25519KeySize, "Unexpected private key size")
+		assert.Len(t, publicKey, X25519KeySize, "Unexpected public key size")
+
+		otherPrivateKey, _, err := crypto.GenerateX25519KeyPair()
+		assert.NoError(t, err, "Expected no error while generating a second key pair")
+		assert.NotEqual(t, privateKey, otherPrivateKey, "Expected distinct private keys")
+	})
+
+	// SharedSecret ensures both parties compute the same secret.
+	t.Run("SharedSecret", func(t *testing.T) {
+		alicePrivate, alicePublic, _ := crypto.GenerateX25519KeyPair()
+		bobPrivate, bobPublic, _ := crypto.GenerateX25519KeyPair()
+
+		aliceSecret, err := crypto.SharedSecret(alicePrivate, bobPublic)
+		assert.NoError(t, err, "Expected no error on Alice's side")
+		bobSecret, err := crypto.SharedSecret(bobPrivate, alicePublic)
+		assert.NoError(t, err, "Expected no error on Bob's side")
+
+		assert.Equal(t, aliceSecret, bobSecret, "Expected both parties to compute the same secret")
+	})
+
+	// SharedSecretInvalidKeys ensures malformed and low-order keys are rejected.
+	t.Run("SharedSecretInvalidKeys", func(t *testing.T) {
+		privateKey, publicKey, _ := crypto.GenerateX25519KeyPair()
+
+		_, err := crypto.SharedSecret([]byte("short"), publicKey)
+		assert.Error(t, err, "Expected an error for a short private key")
+
+		_, err = crypto.SharedSecret(privateKey, []byte("short"))
+		assert.Error(t, err, "Expected an error for a short public key")
+
+		_, err = crypto.SharedSecret(privateKey, make([]byte, X25519KeySize))
+		assert.Error(t, err, "Expected an error for a low-order public key")
+	})
+
+	// SealAndOpen ensures a sealed message can be opened by the recipient.
+	t.Run("SealAndOpen", func(t *testing.T) {
+		privateKey, publicKey, _ := crypto.GenerateX25519KeyPair()
+		plainText := []byte("Hello, Gophers!")
+
+		sealed, err := crypto.Seal(publicKey, plainText)
+		assert.NoError(t, err, "Expected no error while sealing")
+		assert.Len(t, sealed, X25519KeySize+24+len(plainText)+16, "Unexpected sealed message size")
+
+		opened, err := crypto.Open(privateKey, sealed)
+		assert.NoError(t, err, "Expected no error while opening")
+		assert.Equal(t, plainText, opened, "Expected the original plaintext")
+	})
+
+	// SealIsRandomized ensures sealing the same message twice produces different outputs.
+	t.Run("SealIsRandomized", func(t *testing.T) {
+		_, publicKey, _ := crypto.GenerateX25519KeyPair()
+
+		first, _ := crypto.Seal(publicKey, []byte("same"))
+		second, _ := crypto.Seal(publicKey, []byte("same"))
+
+		assert.NotEqual(t, first, second, "Expected distinct sealed messages")
+	})
+
+	// OpenWithWrongKey ensures a message cannot be opened with another private key.
+	t.Run("OpenWithWrongKey", func(t *testing.T) {
+		_, publicKey, _ := crypto.GenerateX25519KeyPair()
+		otherPrivateKey, _, _ := crypto.GenerateX25519KeyPair()
+
+		sealed, _ := crypto.Seal(publicKey, []byte("secret"))
+		_, err := crypto.Open(otherPrivateKey, sealed)
+
+		assert.Error(t, err, "Expected an error when opening with the wrong key")
+	})
+
+	// OpenTampered ensures any modification of the sealed message is detected.
+	t.Run("OpenTampered", func(t *testing.T) {
+		privateKey, publicKey, _ := crypto.GenerateX25519KeyPair()
+		sealed, _ := crypto.Seal(publicKey, []byte("secret"))
+
+		for _, index := range []int{0, X25519KeySize, len(sealed) - 1} {
+			tampered := append([]byte(nil), sealed...)
+			tampered[index] ^= 0x01
+
+			_, err := crypto.Open(privateKey, tampered)
+			assert.Error(t, err, "Expected an error for a modification at index %d", index)
+		}
+
+		_, err := crypto.Open(privateKey, sealed[:10])
+		assert.Error(t, err, "Expected an error for a truncated message")
+	})
+
+	// SealInvalidRecipient ensures a malformed recipient key is rejected.
+	t.Run("SealInvalidRecipient", func(t *testing.T) {
+		_, err := crypto.Seal([]byte("short"), []byte("secret"))
+		assert.Error(t, err, "Expected an error for a short recipient key")
+	})
+}
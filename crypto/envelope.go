@@ -0,0 +1,173 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+const (
+	// DataKeySize is the size in bytes of the AES-256 data keys generated for envelope encryption.
+	DataKeySize = 32
+	// envelopeVersion identifies the layout of the serialized envelope.
+	envelopeVersion byte = 1
+	// envelopeHeaderSize is the size of the fixed envelope header: version byte and wrapped key length.
+	envelopeHeaderSize = 3
+)
+
+// KeyWrapper protects data keys used by envelope encryption. Implementations may wrap keys locally
+// with a master key, as MasterKeyWrapper does, or delegate to an external key management service,
+// so that the master key never leaves it.
+type KeyWrapper interface {
+	// WrapKey encrypts the data key and returns the wrapped form to be stored alongside the payload.
+	WrapKey(dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a key previously returned by WrapKey.
+	UnwrapKey(wrappedKey []byte) ([]byte, error)
+}
+
+// MasterKeyWrapper is a KeyWrapper that wraps data keys locally with AES-GCM under a master key.
+type MasterKeyWrapper struct {
+	// aead is the AES-GCM cipher keyed with the master key.
+	aead cipher.AEAD
+}
+
+// NewMasterKeyWrapper creates a MasterKeyWrapper using the given master key, which must be
+// 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+func NewMasterKeyWrapper(masterKey []byte) (*MasterKeyWrapper, error) {
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MasterKeyWrapper{aead: aead}, nil
+}
+
+// WrapKey encrypts the data key with the master key. The result is the random nonce followed by the sealed key.
+func (w *MasterKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	return sealGCM(w.aead, dataKey, nil)
+}
+
+// UnwrapKey decrypts a data key wrapped by WrapKey, failing if it was wrapped under another master key or modified.
+func (w *MasterKeyWrapper) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	return openGCM(w.aead, wrappedKey, nil)
+}
+
+// EnvelopeEncrypt encrypts the plaintext using envelope encryption. A fresh random AES-256 data key is generated
+// for every call and used to encrypt the payload with AES-GCM; the data key itself is then wrapped by the
+// given KeyWrapper. Everything needed for decryption, except the master key, is serialized into one blob:
+// version (1 byte) || wrapped key length (2 bytes, big-endian) || wrapped key || nonce || ciphertext with tag.
+// The header and wrapped key are authenticated as additional data, so they cannot be swapped or altered.
+func (srv *Crypto) EnvelopeEncrypt(wrapper KeyWrapper, plainText []byte) ([]byte, error) {
+	// A wrapper is required to protect the data key.
+	if wrapper == nil {
+		return nil, errors.New("key wrapper is nil")
+	}
+
+	// Generate a random data key for this payload only.
+	dataKey := make([]byte, DataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+
+	// Wrap the data key so it can be stored next to the payload.
+	wrappedKey, err := wrapper.WrapKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// The wrapped key length is stored in two bytes.
+	if len(wrappedKey) > math.MaxUint16 {
+		return nil, errors.New("wrapped key is too large")
+	}
+
+	// Build the header: version, wrapped key length and the wrapped key itself.
+	header := make([]byte, envelopeHeaderSize, envelopeHeaderSize+len(wrappedKey))
+	header[0] = envelopeVersion
+	binary.BigEndian.PutUint16(header[1:], uint16(len(wrappedKey)))
+	header = append(header, wrappedKey...)
+
+	// Encrypt the payload with the data key, authenticating the header as additional data.
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := sealGCM(aead, plainText, header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Concatenate the header and the encrypted payload into a single blob.
+	return append(header, sealed...), nil
+}
+
+// EnvelopeDecrypt decrypts a blob produced by EnvelopeEncrypt. The data key is unwrapped with the given KeyWrapper
+// and then used to decrypt and verify the payload. An error is returned if the blob is malformed, uses an unknown
+// version, or fails authentication.
+func (srv *Crypto) EnvelopeDecrypt(wrapper KeyWrapper, blob []byte) ([]byte, error) {
+	// A wrapper is required to recover the data key.
+	if wrapper == nil {
+		return nil, errors.New("key wrapper is nil")
+	}
+
+	// Validate the fixed part of the header.
+	if len(blob) < envelopeHeaderSize {
+		return nil, errors.New("envelope is too short")
+	}
+	if blob[0] != envelopeVersion {
+		return nil, errors.New("unsupported envelope version")
+	}
+
+	// Read the wrapped key using the length stored in the header.
+	wrappedKeyEnd := envelopeHeaderSize + int(binary.BigEndian.Uint16(blob[1:envelopeHeaderSize]))
+	if len(blob) < wrappedKeyEnd {
+		return nil, errors.New("envelope is too short")
+	}
+	header := blob[:wrappedKeyEnd]
+
+	// Recover the data key.
+	dataKey, err := wrapper.UnwrapKey(header[envelopeHeaderSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt the payload, verifying that the header was not altered.
+	aead, err := newGCM(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return openGCM(aead, blob[wrappedKeyEnd:], header)
+}
+
+// newGCM creates an AES-GCM cipher from the raw key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealGCM encrypts the plaintext with a random nonce and returns the nonce followed by the ciphertext.
+func sealGCM(aead cipher.AEAD, plainText, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plainText)+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plainText, additionalData), nil
+}
+
+// openGCM splits the nonce from the ciphertext produced by sealGCM and decrypts it.
+func openGCM(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize()+aead.Overhead() {
+		return nil, errors.New("ciphertext is too short")
+	}
+
+	return aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+}
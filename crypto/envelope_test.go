@@ -0,0 +1,120 @@
+package crypto
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stubKeyWrapper is a KeyWrapper standing in for an external key management service.
+type stubKeyWrapper struct {
+	// keys maps the handles returned by WrapKey to the data keys.
+	keys map[string][]byte
+}
+
+// WrapKey stores the data key and returns an opaque handle to it.
+func (w *stubKeyWrapper) WrapKey(dataKey []byte) ([]byte, error) {
+	handle := []byte{byte('a' + len(w.keys))}
+	w.keys[string(handle)] = append([]byte(nil), dataKey...)
+	return handle, nil
+}
+
+// UnwrapKey looks up the data key by its handle.
+func (w *stubKeyWrapper) UnwrapKey(wrappedKey []byte) ([]byte, error) {
+	key, ok := w.keys[string(wrappedKey)]
+	if !ok {
+		return nil, errors.New("unknown key")
+	}
+	return key, nil
+}
+
+// TestEnvelope verifies envelope encryption with local and pluggable key wrappers.
+func TestEnvelope(t *testing.T) {
+	t.Parallel()
+
+	// Initialize a Crypto instance shared by all subtests.
+	crypto := &Crypto{}
+	// masterKey is an AES-256 master key used by the local wrapper.
+	masterKey := []byte("0123456789abcdef0123456789abcdef")
+
+	// InvalidMasterKey ensures master keys of an invalid size are rejected.
+	t.Run("InvalidMasterKey", func(t *testing.T) {
+		_, err := NewMasterKeyWrapper([]byte("short"))
+		assert.Error(t, err, "Expected an error for an invalid master key")
+	})
+
+	// RoundTrip ensures a payload encrypted under the master key can be decrypted.
+	t.Run("RoundTrip", func(t *testing.T) {
+		wrapper, err := NewMasterKeyWrapper(masterKey)
+		assert.NoError(t, err, "Expected no error for a valid master key")
+
+		for _, plainText := range [][]byte{[]byte("Hello, Gophers!"), {}} {
+			blob, err := crypto.EnvelopeEncrypt(wrapper, plainText)
+			assert.NoError(t, err, "Expected no error while encrypting")
+
+			decrypted, err := crypto.EnvelopeDecrypt(wrapper, blob)
+			assert.NoError(t, err, "Expected no error while decrypting")
+			assert.Equal(t, string(plainText), string(decrypted), "Expected the original plaintext")
+		}
+	})
+
+	// FreshDataKeys ensures every call produces a different blob.
+	t.Run("FreshDataKeys", func(t *testing.T) {
+		wrapper, _ := NewMasterKeyWrapper(masterKey)
+
+		first, _ := crypto.EnvelopeEncrypt(wrapper, []byte("same"))
+		second, _ := crypto.EnvelopeEncrypt(wrapper, []byte("same"))
+
+		assert.NotEqual(t, first, second, "Expected distinct blobs")
+	})
+
+	// WrongMasterKey ensures a blob cannot be decrypted under another master key.
+	t.Run("WrongMasterKey", func(t *testing.T) {
+		wrapper, _ := NewMasterKeyWrapper(masterKey)
+		other, _ := NewMasterKeyWrapper([]byte("fedcba9876543210fedcba9876543210"))
+
+		blob, _ := crypto.EnvelopeEncrypt(wrapper, []byte("secret"))
+		_, err := crypto.EnvelopeDecrypt(other, blob)
+
+		assert.Error(t, err, "Expected an error for the wrong master key")
+	})
+
+	// Tampered ensures modifications of any part of the blob are detected.
+	t.Run("Tampered", func(t *testing.T) {
+		wrapper, _ := NewMasterKeyWrapper(masterKey)
+		blob, _ := crypto.EnvelopeEncrypt(wrapper, []byte("secret"))
+
+		for _, index := range []int{0, 1, envelopeHeaderSize, len(blob) - 1} {
+			tampered := append([]byte(nil), blob...)
+			tampered[index] ^= 0x01
+
+			_, err := crypto.EnvelopeDecrypt(wrapper, tampered)
+			assert.Error(t, err, "Expected an error for a modification at index %d", index)
+		}
+
+		_, err := crypto.EnvelopeDecrypt(wrapper, blob[:2])
+		assert.Error(t, err, "Expected an error for a truncated blob")
+	})
+
+	// PluggableWrapper ensures a custom KeyWrapper can replace the local master key.
+	t.Run("PluggableWrapper", func(t *testing.T) {
+		wrapper := &stubKeyWrapper{keys: make(map[string][]byte)}
+
+		blob, err := crypto.EnvelopeEncrypt(wrapper, []byte("kms protected"))
+		assert.NoError(t, err, "Expected no error while encrypting")
+
+		decrypted, err := crypto.EnvelopeDecrypt(wrapper, blob)
+		assert.NoError(t, err, "Expected no error while decrypting")
+		assert.Equal(t, []byte("kms protected"), decrypted, "Expected the original plaintext")
+	})
+
+	// NilWrapper ensures a missing wrapper is rejected.
+	t.Run("NilWrapper", func(t *testing.T) {
+		_, err := crypto.EnvelopeEncrypt(nil, []byte("secret"))
+		assert.Error(t, err, "Expected an error while encrypting without a wrapper")
+
+		_, err = crypto.EnvelopeDecrypt(nil, []byte("blob"))
+		assert.Error(t, err, "Expected an error while decrypting without a wrapper")
+	})
+}
@@ -0,0 +1,255 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/SyntaxErrorLineNULL/common/filesystem"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// FileChunkSize is the amount of plaintext encrypted in every chunk of an encrypted file.
+	FileChunkSize = 64 * 1024
+	// fileMagic identifies files produced by EncryptFile. Version 2 derives a key per file from a salt.
+	fileMagic = "SCF2"
+	// fileSaltSize is the size of the random salt stored in the file header.
+	fileSaltSize = 32
+	// fileHeaderSize is the size of the file header: magic, chunk size and salt.
+	fileHeaderSize = len(fileMagic) + 4 + fileSaltSize
+	// fileTagSize is the size of the authentication tag GCM appends to every chunk.
+	fileTagSize = 16
+)
+
+// fileKeyInfo is the HKDF context string binding derived keys to the file encryption format.
+var fileKeyInfo = []byte("common/crypto file v2")
+
+// ProgressFunc is called after every processed chunk with the number of source bytes processed so far
+// and the total size of the source file.
+type ProgressFunc func(processed, total int64)
+
+// EncryptFile encrypts the file at src into dst using AES-GCM with the given raw key (16, 24 or 32 bytes).
+// The file is streamed in chunks of FileChunkSize, so memory usage does not depend on the file size.
+// Every file is encrypted with its own key, derived from the given key and a random salt with HKDF-SHA256,
+// so nonces never repeat across files encrypted with the same key. Every chunk is sealed separately with
+// a nonce made of the chunk counter and a flag marking the final chunk, which prevents chunks from being
+// reordered, dropped or the file from being truncated.
+// The output is written atomically: dst only appears once the whole file has been encrypted.
+// The optional progress callback is invoked after every chunk.
+func (srv *Crypto) EncryptFile(src, dst string, key []byte, progress ProgressFunc) error {
+	if err := checkFileKey(key); err != nil {
+		return err
+	}
+
+	// Open the source file and determine its size for progress reporting.
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Build the header: magic, chunk size and random salt.
+	header := make([]byte, fileHeaderSize)
+	copy(header, fileMagic)
+	binary.BigEndian.PutUint32(header[len(fileMagic):], FileChunkSize)
+	if _, err = io.ReadFull(rand.Reader, header[len(fileMagic)+4:]); err != nil {
+		return err
+	}
+
+	aead, err := fileAEAD(key, header)
+	if err != nil {
+		return err
+	}
+
+	// Create the output atomically; it is discarded unless committed below.
+	out, err := filesystem.CreateAtomic(dst, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := bufio.NewWriter(out)
+	if _, err = writer.Write(header); err != nil {
+		return err
+	}
+
+	// Read one chunk ahead, so the final chunk can be flagged when it is sealed.
+	reader := bufio.NewReaderSize(in, FileChunkSize)
+	plainText := make([]byte, FileChunkSize)
+	cipherText := make([]byte, 0, FileChunkSize+aead.Overhead())
+	processed := int64(0)
+
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(reader, plainText)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		// The chunk is the last one if the source has no more data after it.
+		_, peekErr := reader.Peek(1)
+		last := errors.Is(peekErr, io.EOF)
+
+		// Seal the chunk, authenticating the header as additional data.
+		cipherText = aead.Seal(cipherText[:0], fileChunkNonce(counter, last), plainText[:n], header)
+		if _, err = writer.Write(cipherText); err != nil {
+			return err
+		}
+
+		processed += int64(n)
+		if progress != nil {
+			progress(processed, info.Size())
+		}
+
+		if last {
+			break
+		}
+
+		// Guard against counter overflow, which would reuse nonces.
+		if counter == ^uint32(0) {
+			return errors.New("file is too large")
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	// Publish the encrypted file.
+	return out.Commit()
+}
+
+// DecryptFile decrypts a file produced by EncryptFile from src into dst using the same key.
+// Every chunk is verified before being written, and the output is written atomically, so dst only appears
+// if the whole file decrypted successfully. The optional progress callback is invoked after every chunk
+// with the number of encrypted bytes processed so far and the size of the encrypted file.
+func (srv *Crypto) DecryptFile(src, dst string, key []byte, progress ProgressFunc) error {
+	if err := checkFileKey(key); err != nil {
+		return err
+	}
+
+	// Open the encrypted file and determine its size for progress reporting.
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	reader := bufio.NewReaderSize(in, FileChunkSize+fileTagSize)
+
+	// Read and validate the header.
+	header := make([]byte, fileHeaderSize)
+	if _, err = io.ReadFull(reader, header); err != nil {
+		return errors.New("encrypted file header is truncated")
+	}
+	if !bytes.Equal(header[:len(fileMagic)], []byte(fileMagic)) {
+		return errors.New("not an encrypted file")
+	}
+	chunkSize := int(binary.BigEndian.Uint32(header[len(fileMagic):]))
+	if chunkSize <= 0 || chunkSize > 16*FileChunkSize {
+		return errors.New("invalid chunk size")
+	}
+
+	aead, err := fileAEAD(key, header)
+	if err != nil {
+		return err
+	}
+
+	// Create the output atomically; it is discarded unless committed below.
+	out, err := filesystem.CreateAtomic(dst, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	writer := bufio.NewWriter(out)
+	cipherText := make([]byte, chunkSize+aead.Overhead())
+	plainText := make([]byte, 0, chunkSize)
+	processed := int64(fileHeaderSize)
+
+	for counter := uint32(0); ; counter++ {
+		n, err := io.ReadFull(reader, cipherText)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+
+		// The chunk is the last one if the file has no more data after it.
+		_, peekErr := reader.Peek(1)
+		last := errors.Is(peekErr, io.EOF)
+
+		// Open the chunk; this fails for modified, reordered, or truncated data.
+		plainText, err = aead.Open(plainText[:0], fileChunkNonce(counter, last), cipherText[:n], header)
+		if err != nil {
+			return errors.New("encrypted file is corrupted or the key is wrong")
+		}
+		if _, err = writer.Write(plainText); err != nil {
+			return err
+		}
+
+		processed += int64(n)
+		if progress != nil {
+			progress(processed, info.Size())
+		}
+
+		if last {
+			break
+		}
+	}
+
+	if err = writer.Flush(); err != nil {
+		return err
+	}
+
+	// Publish the decrypted file.
+	return out.Commit()
+}
+
+// checkFileKey rejects keys that are not valid AES keys before any file is touched.
+func checkFileKey(key []byte) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return aes.KeySizeError(len(key))
+	}
+}
+
+// fileAEAD derives the key of a single file from the given key and the salt in the header using HKDF-SHA256,
+// and returns an AES-GCM cipher using it. The derived key has the size of the given key.
+func fileAEAD(key, header []byte) (cipher.AEAD, error) {
+	fileKey := make([]byte, len(key))
+	if _, err := io.ReadFull(hkdf.New(sha256.New, key, header[len(fileMagic)+4:], fileKeyInfo), fileKey); err != nil {
+		return nil, err
+	}
+
+	return newGCM(fileKey)
+}
+
+// fileChunkNonce builds the 12-byte GCM nonce for a chunk: seven zero bytes, the big-endian chunk counter,
+// and a final byte set to 1 for the last chunk. The nonce only needs to be unique under the key of the file.
+func fileChunkNonce(counter uint32, last bool) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint32(nonce[7:], counter)
+	if last {
+		nonce[11] = 1
+	}
+
+	return nonce
+}
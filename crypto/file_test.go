@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFileEncryption verifies streaming file encryption and decryption.
+func TestFileEncryption(t *testing.T) {
+	t.Parallel()
+
+	// Initialize a Crypto instance shared by all subtests.
+	crypto := &Crypto{}
+	// key is an AES-256 key used by the subtests.
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	// writeSource creates a source file with the given content in a temporary directory.
+	writeSource := func(t *testing.T, content []byte) (dir, path string) {
+		dir = t.TempDir()
+		path = filepath.Join(dir, "source.bin")
+		assert.NoError(t, os.WriteFile(path, content, 0o600), "Expected no error while writing the source")
+		return dir, path
+	}
+
+	// RoundTrip ensures files of various sizes, including chunk boundaries, survive a round trip.
+	t.Run("RoundTrip", func(t *testing.T) {
+		sizes := []int{0, 1, FileChunkSize - 1, FileChunkSize, FileChunkSize + 1, 3*FileChunkSize + 17}
+		for _, size := range sizes {
+			content := bytes.Repeat([]byte{0xAB}, size)
+			dir, src := writeSource(t, content)
+			encrypted := filepath.Join(dir, "out", "encrypted.bin")
+			decrypted := filepath.Join(dir, "out", "decrypted.bin")
+
+			assert.NoError(t, crypto.EncryptFile(src, encrypted, key, nil), "Expected no error while encrypting %d bytes", size)
+			assert.NoError(t, crypto.DecryptFile(encrypted, decrypted, key, nil), "Expected no error while decrypting %d bytes", size)
+
+			result, err := os.ReadFile(decrypted)
+			assert.NoError(t, err, "Expected the decrypted file to exist")
+			assert.True(t, bytes.Equal(content, result), "Expected the original content for %d bytes", size)
+		}
+	})
+
+	// Progress ensures the callback reports monotonically increasing progress up to the total size.
+	t.Run("Progress", func(t *testing.T) {
+		content := bytes.Repeat([]byte("x"), 2*FileChunkSize+5)
+		dir, src := writeSource(t, content)
+
+		var reports []int64
+		err := crypto.EncryptFile(src, filepath.Join(dir, "encrypted.bin"), key, func(processed, total int64) {
+			assert.Equal(t, int64(len(content)), total, "Unexpected total size")
+			reports = append(reports, processed)
+		})
+
+		assert.NoError(t, err, "Expected no error while encrypting")
+		assert.Equal(t, []int64{FileChunkSize, 2 * FileChunkSize, int64(len(content))}, reports, "Unexpected progress reports")
+	})
+
+	// WrongKey ensures decryption with another key fails and does not create the output.
+	t.Run("WrongKey", func(t *testing.T) {
+		dir, src := writeSource(t, []byte("secret"))
+		encrypted := filepath.Join(dir, "encrypted.bin")
+		decrypted := filepath.Join(dir, "decrypted.bin")
+		assert.NoError(t, crypto.EncryptFile(src, encrypted, key, nil), "Expected no error while encrypting")
+
+		err := crypto.DecryptFile(encrypted, decrypted, []byte("fedcba9876543210fedcba9876543210"), nil)
+		assert.Error(t, err, "Expected an error for the wrong key")
+
+		_, err = os.Stat(decrypted)
+		assert.True(t, os.IsNotExist(err), "Expected no output for a failed decryption")
+	})
+
+	// Truncated ensures dropping the final chunk of a multi-chunk file is detected.
+	t.Run("Truncated", func(t *testing.T) {
+		dir, src := writeSource(t, bytes.Repeat([]byte("y"), 2*FileChunkSize+10))
+		encrypted := filepath.Join(dir, "encrypted.bin")
+		assert.NoError(t, crypto.EncryptFile(src, encrypted, key, nil), "Expected no error while encrypting")
+
+		data, _ := os.ReadFile(encrypted)
+		// Keep the header and the first two full chunks only.
+		truncated := data[:fileHeaderSize+2*(FileChunkSize+fileTagSize)]
+		assert.NoError(t, os.WriteFile(encrypted, truncated, 0o600), "Expected no error while truncating")
+
+		err := crypto.DecryptFile(encrypted, filepath.Join(dir, "decrypted.bin"), key, nil)
+		assert.Error(t, err, "Expected an error for a truncated file")
+	})
+
+	// PerFileKey ensures encrypting the same content twice with the same key yields unrelated ciphertexts,
+	// and that the salt is authenticated.
+	t.Run("PerFileKey", func(t *testing.T) {
+		dir, src := writeSource(t, []byte("same content"))
+		first := filepath.Join(dir, "first.bin")
+		second := filepath.Join(dir, "second.bin")
+		assert.NoError(t, crypto.EncryptFile(src, first, key, nil), "Expected no error while encrypting")
+		assert.NoError(t, crypto.EncryptFile(src, second, key, nil), "Expected no error while encrypting")
+
+		firstData, _ := os.ReadFile(first)
+		secondData, _ := os.ReadFile(second)
+		assert.Equal(t, fileMagic, string(firstData[:len(fileMagic)]), "Expected the current format version")
+		assert.NotEqual(t, firstData[fileHeaderSize:], secondData[fileHeaderSize:], "Expected different ciphertexts")
+
+		// Flip a bit of the salt, which changes the derived key.
+		firstData[fileHeaderSize-1] ^= 1
+		assert.NoError(t, os.WriteFile(first, firstData, 0o600), "Expected no error while modifying the salt")
+		err := crypto.DecryptFile(first, filepath.Join(dir, "decrypted.bin"), key, nil)
+		assert.Error(t, err, "Expected an error for a modified salt")
+	})
+
+	// NotEncrypted ensures files without the expected header are rejected.
+	t.Run("NotEncrypted", func(t *testing.T) {
+		dir, src := writeSource(t, []byte("plain text that is long enough to hold a header"))
+
+		err := crypto.DecryptFile(src, filepath.Join(dir, "decrypted.bin"), key, nil)
+		assert.Error(t, err, "Expected an error for a file without the magic header")
+	})
+
+	// InvalidKey ensures keys of an invalid size are rejected.
+	t.Run("InvalidKey", func(t *testing.T) {
+		dir, src := writeSource(t, []byte("data"))
+
+		err := crypto.EncryptFile(src, filepath.Join(dir, "encrypted.bin"), []byte("short"), nil)
+		assert.Error(t, err, "Expected an error for an invalid key")
+	})
+}
@@ -0,0 +1,114 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Hasher is a hash algorithm with one-shot, streaming and HMAC helpers.
+// Code that needs a digest should accept a Hasher instead of a concrete algorithm,
+// so that every consumer computes digests the same way and the algorithm can be swapped in one place.
+type Hasher interface {
+	// Name returns the name of the algorithm, e.g. "sha256".
+	Name() string
+	// New returns a new streaming hash.Hash for the algorithm.
+	New() hash.Hash
+	// Sum returns the digest of data.
+	Sum(data []byte) []byte
+	// SumHex returns the digest of data encoded as a lowercase hexadecimal string.
+	SumHex(data []byte) string
+	// SumBase64 returns the digest of data encoded as standard base64.
+	SumBase64(data []byte) string
+	// SumReader returns the digest of everything read from r until EOF.
+	SumReader(r io.Reader) ([]byte, error)
+	// HMAC returns the HMAC of data under key using the algorithm.
+	HMAC(key, data []byte) []byte
+}
+
+var (
+	// SHA256 is the SHA-256 Hasher.
+	SHA256 Hasher = &hasher{name: "sha256", newHash: sha256.New}
+	// SHA512 is the SHA-512 Hasher.
+	SHA512 Hasher = &hasher{name: "sha512", newHash: sha512.New}
+	// Blake2b256 is the BLAKE2b-256 Hasher.
+	Blake2b256 Hasher = &hasher{name: "blake2b-256", newHash: func() hash.Hash {
+		// An unkeyed BLAKE2b hash never fails to initialize.
+		h, _ := blake2b.New256(nil)
+		return h
+	}}
+	// Blake2b512 is the BLAKE2b-512 Hasher.
+	Blake2b512 Hasher = &hasher{name: "blake2b-512", newHash: func() hash.Hash {
+		// An unkeyed BLAKE2b hash never fails to initialize.
+		h, _ := blake2b.New512(nil)
+		return h
+	}}
+)
+
+// hasher implements Hasher on top of a hash.Hash constructor.
+type hasher struct {
+	// name is the name of the algorithm.
+	name string
+	// newHash creates a new instance of the hash.
+	newHash func() hash.Hash
+}
+
+// Name returns the name of the algorithm.
+func (h *hasher) Name() string {
+	return h.name
+}
+
+// New returns a new streaming hash.Hash for the algorithm.
+func (h *hasher) New() hash.Hash {
+	return h.newHash()
+}
+
+// Sum returns the digest of data.
+func (h *hasher) Sum(data []byte) []byte {
+	digest := h.newHash()
+	// Writing to a hash never returns an error.
+	_, _ = digest.Write(data)
+
+	return digest.Sum(nil)
+}
+
+// SumHex returns the digest of data encoded as a lowercase hexadecimal string.
+func (h *hasher) SumHex(data []byte) string {
+	return hex.EncodeToString(h.Sum(data))
+}
+
+// SumBase64 returns the digest of data encoded as standard base64.
+func (h *hasher) SumBase64(data []byte) string {
+	return base64.StdEncoding.EncodeToString(h.Sum(data))
+}
+
+// SumReader returns the digest of everything read from r until EOF, without buffering the whole input.
+func (h *hasher) SumReader(r io.Reader) ([]byte, error) {
+	digest := h.newHash()
+	if _, err := io.Copy(digest, r); err != nil {
+		return nil, err
+	}
+
+	return digest.Sum(nil), nil
+}
+
+// HMAC returns the HMAC of data under key using the algorithm.
+func (h *hasher) HMAC(key, data []byte) []byte {
+	mac := hmac.New(h.newHash, key)
+	// Writing to a hash never returns an error.
+	_, _ = mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// VerifyHMAC reports whether mac is the valid HMAC of data under key for the given Hasher.
+// The comparison runs in constant time to avoid leaking timing information.
+func VerifyHMAC(h Hasher, key, data, mac []byte) bool {
+	return hmac.Equal(h.HMAC(key, data), mac)
+}
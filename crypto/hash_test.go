@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingReader is an io.Reader that always fails.
+type failingReader struct{}
+
+// Read returns an error on every call.
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failed")
+}
+
+// TestHasher verifies the hashing helpers against known digests.
+func TestHasher(t *testing.T) {
+	t.Parallel()
+
+	// KnownDigests checks the hexadecimal digests of "abc" for every algorithm.
+	t.Run("KnownDigests", func(t *testing.T) {
+		cases := []struct {
+			hasher   Hasher
+			expected string
+		}{
+			{hasher: SHA256, expected: "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+			{hasher: SHA512, expected: "ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f"},
+			{hasher: Blake2b256, expected: "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+			{hasher: Blake2b512, expected: "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.hasher.Name(), func(t *testing.T) {
+				assert.Equal(t, tt.expected, tt.hasher.SumHex([]byte("abc")), "Unexpected digest")
+			})
+		}
+	})
+
+	// EncodingsAgree ensures the raw, hexadecimal, base64 and streaming forms describe the same digest.
+	t.Run("EncodingsAgree", func(t *testing.T) {
+		data := bytes.Repeat([]byte("payload"), 1000)
+		raw := SHA256.Sum(data)
+
+		streamed, err := SHA256.SumReader(bytes.NewReader(data))
+		assert.NoError(t, err, "Expected no error while streaming")
+		assert.Equal(t, raw, streamed, "Expected the streamed digest to match")
+		assert.Equal(t, hex.EncodeToString(raw), SHA256.SumHex(data), "Expected the hexadecimal digest to match")
+		assert.Equal(t, base64.StdEncoding.EncodeToString(raw), SHA256.SumBase64(data), "Expected the base64 digest to match")
+
+		digest := SHA256.New()
+		_, _ = digest.Write(data)
+		assert.Equal(t, sha256.Sum256(data), [32]byte(digest.Sum(nil)), "Expected New to return a SHA-256 hash")
+	})
+
+	// SumReaderError ensures read errors are propagated.
+	t.Run("SumReaderError", func(t *testing.T) {
+		_, err := SHA512.SumReader(failingReader{})
+		assert.Error(t, err, "Expected the read error to be returned")
+	})
+
+	// HMAC checks a known HMAC-SHA256 value and verification.
+	t.Run("HMAC", func(t *testing.T) {
+		// RFC 4231 test case 2.
+		mac := SHA256.HMAC([]byte("Jefe"), []byte("what do ya want for nothing?"))
+		assert.Equal(t, "5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843", hex.EncodeToString(mac), "Unexpected HMAC")
+
+		assert.True(t, VerifyHMAC(SHA256, []byte("Jefe"), []byte("what do ya want for nothing?"), mac), "Expected the HMAC to verify")
+		assert.False(t, VerifyHMAC(SHA256, []byte("other"), []byte("what do ya want for nothing?"), mac), "Expected a wrong key to fail")
+		assert.False(t, VerifyHMAC(SHA512, []byte("Jefe"), []byte("what do ya want for nothing?"), mac), "Expected another algorithm to fail")
+	})
+}
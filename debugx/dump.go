@@ -0,0 +1,284 @@
+package debugx
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxDepth is the nesting depth Dump descends to when MaxDepth is not set.
+const DefaultMaxDepth = 10
+
+// RedactTag is the struct tag marking fields whose values Dump hides, as in `redact:"true"`.
+const RedactTag = "redact"
+
+// redacted replaces the values of redacted fields.
+const redacted = "<redacted>"
+
+// DumpOptions configures DumpWithOptions.
+type DumpOptions struct {
+	// MaxDepth limits how deep nested values are printed; deeper values are shown as "{...}".
+	// When zero, DefaultMaxDepth is used.
+	MaxDepth int
+	// Indent is the indentation of every nesting level. When empty, two spaces are used.
+	Indent string
+}
+
+// Dump formats v as readable multi-line Go-like syntax for logs and debugging, for example
+//
+//	api.Request{
+//	  ID: 42,
+//	  Token: <redacted>,
+//	  Tags: []string{
+//	    "a",
+//	  },
+//	}
+//
+// Struct fields tagged `redact:"true"` are shown as <redacted>, so secrets do not leak into logs.
+// Pointers are followed, and a pointer back to a value being printed is shown as <cycle> instead of
+// recursing forever. Map entries are sorted by key, so the output is stable. Values implementing
+// error or fmt.Stringer, such as time.Time, are printed with their method; redaction does not apply
+// inside them. Unexported fields are printed too.
+func Dump(v any) string {
+	return DumpWithOptions(v, DumpOptions{})
+}
+
+// DumpWithOptions is like Dump with the given options.
+func DumpWithOptions(v any, opts DumpOptions) string {
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = DefaultMaxDepth
+	}
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+
+	d := dumper{opts: opts, path: make(map[visitedPointer]bool)}
+	if v == nil {
+		return "nil"
+	}
+	d.value(reflect.ValueOf(v), 0)
+
+	return d.out.String()
+}
+
+// visitedPointer identifies a pointer on the current path. The type is part of the key because a pointer
+// to a struct and a pointer to its first field share the same address.
+type visitedPointer struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// dumper holds the state of a single Dump call.
+type dumper struct {
+	// opts holds the configuration, with defaults applied.
+	opts DumpOptions
+	// out receives the output.
+	out strings.Builder
+	// path holds the pointers, maps and slices being printed, to detect cycles.
+	path map[visitedPointer]bool
+}
+
+// value writes v at the given nesting depth.
+func (d *dumper) value(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		d.out.WriteString("nil")
+		return
+	}
+
+	// Let values that know how to format themselves do so. Nil pointers and interfaces are printed as nil
+	// below; an interface type such as error implements itself, but holds no value to call the method on.
+	nilable := v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface
+	if v.CanInterface() && (!nilable || !v.IsNil()) {
+		switch value := v.Interface().(type) {
+		case error:
+			d.out.WriteString(strconv.Quote(value.Error()))
+			return
+		case fmt.Stringer:
+			d.out.WriteString(strconv.Quote(value.String()))
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		d.out.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		d.out.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		d.out.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		d.out.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		d.out.WriteString(strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits()))
+	case reflect.String:
+		d.out.WriteString(strconv.Quote(v.String()))
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		if v.IsNil() {
+			d.out.WriteString(v.Type().String() + "(nil)")
+		} else {
+			fmt.Fprintf(&d.out, "%s(%#x)", v.Type(), v.Pointer())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			d.out.WriteString("nil")
+			return
+		}
+		d.value(v.Elem(), depth)
+	case reflect.Pointer:
+		d.pointer(v, depth)
+	case reflect.Struct:
+		d.structure(v, depth)
+	case reflect.Slice:
+		if v.IsNil() {
+			d.out.WriteString(v.Type().String() + "(nil)")
+			return
+		}
+		// Print byte slices as strings, which is far more readable than a list of numbers.
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(&d.out, "%s(%q)", v.Type(), v.Bytes())
+			return
+		}
+		if d.enter(v) {
+			defer d.leave(v)
+			d.list(v, depth)
+		}
+	case reflect.Array:
+		d.list(v, depth)
+	case reflect.Map:
+		if v.IsNil() {
+			d.out.WriteString(v.Type().String() + "(nil)")
+			return
+		}
+		if d.enter(v) {
+			defer d.leave(v)
+			d.mapping(v, depth)
+		}
+	}
+}
+
+// pointer writes a pointer as & followed by the value it points to.
+func (d *dumper) pointer(v reflect.Value, depth int) {
+	if v.IsNil() {
+		d.out.WriteString("(" + v.Type().String() + ")(nil)")
+		return
+	}
+	if !d.enter(v) {
+		return
+	}
+	defer d.leave(v)
+
+	d.out.WriteString("&")
+	d.value(v.Elem(), depth)
+}
+
+// structure writes a struct with one field per line.
+func (d *dumper) structure(v reflect.Value, depth int) {
+	d.out.WriteString(v.Type().String())
+	if v.NumField() == 0 {
+		d.out.WriteString("{}")
+		return
+	}
+	if depth >= d.opts.MaxDepth {
+		d.out.WriteString("{...}")
+		return
+	}
+
+	d.out.WriteString("{\n")
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		d.indent(depth + 1)
+		d.out.WriteString(field.Name + ": ")
+
+		if redact, _ := strconv.ParseBool(field.Tag.Get(RedactTag)); redact {
+			d.out.WriteString(redacted)
+		} else {
+			d.value(v.Field(i), depth+1)
+		}
+		d.out.WriteString(",\n")
+	}
+	d.indent(depth)
+	d.out.WriteString("}")
+}
+
+// list writes a slice or array with one element per line.
+func (d *dumper) list(v reflect.Value, depth int) {
+	d.out.WriteString(v.Type().String())
+	if v.Len() == 0 {
+		d.out.WriteString("{}")
+		return
+	}
+	if depth >= d.opts.MaxDepth {
+		d.out.WriteString("{...}")
+		return
+	}
+
+	d.out.WriteString("{\n")
+	for i := range v.Len() {
+		d.indent(depth + 1)
+		d.value(v.Index(i), depth+1)
+		d.out.WriteString(",\n")
+	}
+	d.indent(depth)
+	d.out.WriteString("}")
+}
+
+// mapping writes a map with one entry per line, sorted by the formatted keys.
+func (d *dumper) mapping(v reflect.Value, depth int) {
+	d.out.WriteString(v.Type().String())
+	if v.Len() == 0 {
+		d.out.WriteString("{}")
+		return
+	}
+	if depth >= d.opts.MaxDepth {
+		d.out.WriteString("{...}")
+		return
+	}
+
+	// Format the keys first, so the entries can be sorted by them.
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		key := dumper{opts: d.opts, path: d.path}
+		key.value(iter.Key(), d.opts.MaxDepth)
+		entries = append(entries, entry{key: key.out.String(), value: iter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	d.out.WriteString("{\n")
+	for _, e := range entries {
+		d.indent(depth + 1)
+		d.out.WriteString(e.key + ": ")
+		d.value(e.value, depth+1)
+		d.out.WriteString(",\n")
+	}
+	d.indent(depth)
+	d.out.WriteString("}")
+}
+
+// enter records v on the current path and reports whether it may be printed. A value already on the
+// path forms a cycle and is written as <cycle> instead.
+func (d *dumper) enter(v reflect.Value) bool {
+	key := visitedPointer{addr: v.Pointer(), typ: v.Type()}
+	if d.path[key] {
+		d.out.WriteString("<cycle " + v.Type().String() + ">")
+		return false
+	}
+	d.path[key] = true
+
+	return true
+}
+
+// leave removes v from the current path, so values shared without a cycle are printed every time.
+func (d *dumper) leave(v reflect.Value) {
+	delete(d.path, visitedPointer{addr: v.Pointer(), typ: v.Type()})
+}
+
+// indent writes the indentation of the nesting depth.
+func (d *dumper) indent(depth int) {
+	d.out.WriteString(strings.Repeat(d.opts.Indent, depth))
+}
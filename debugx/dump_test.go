@@ -0,0 +1,164 @@
+package debugx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// credentials holds a secret that must not be printed.
+type credentials struct {
+	User     string
+	Password string `redact:"true"`
+}
+
+// request is a nested structure as logged by services.
+type request struct {
+	ID      int
+	Auth    *credentials
+	Tags    []string
+	Headers map[string]string
+	Payload []byte
+	Created time.Time
+	Err     error
+	retries uint8
+}
+
+// node is a linked structure that can form cycles.
+type node struct {
+	Name string
+	Next *node
+}
+
+// TestDump verifies the formatting of nested values.
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	// Structure ensures nested values are printed with redaction and sorted map keys.
+	t.Run("Structure", func(t *testing.T) {
+		req := request{
+			ID:      42,
+			Auth:    &credentials{User: "alice", Password: "hunter2"},
+			Tags:    []string{"a", "b"},
+			Headers: map[string]string{"X-Trace": "1", "Accept": "json"},
+			Payload: []byte("hi"),
+			Created: time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC),
+			Err:     errors.New("boom"),
+			retries: 3,
+		}
+
+		expected := `debugx.request{
+  ID: 42,
+  Auth: &debugx.credentials{
+    User: "alice",
+    Password: <redacted>,
+  },
+  Tags: []string{
+    "a",
+    "b",
+  },
+  Headers: map[string]string{
+    "Accept": "json",
+    "X-Trace": "1",
+  },
+  Payload: []uint8("hi"),
+  Created: "2024-03-01 12:00:00 +0000 UTC",
+  Err: "boom",
+  retries: 3,
+}`
+		assert.Equal(t, expected, Dump(req), "Unexpected dump")
+		assert.NotContains(t, Dump(&req), "hunter2", "Expected the password to be redacted")
+	})
+
+	// Scalars ensures simple and empty values are printed compactly.
+	t.Run("Scalars", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			value    any
+			expected string
+		}{
+			{name: "Nil", value: nil, expected: "nil"},
+			{name: "String", value: "a\"b", expected: `"a\"b"`},
+			{name: "Float", value: 1.5, expected: "1.5"},
+			{name: "Bool", value: true, expected: "true"},
+			{name: "Nil pointer", value: (*node)(nil), expected: "(*debugx.node)(nil)"},
+			{name: "Nil slice", value: []int(nil), expected: "[]int(nil)"},
+			{name: "Empty map", value: map[string]int{}, expected: "map[string]int{}"},
+			{name: "Empty struct", value: struct{}{}, expected: "struct {}{}"},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, Dump(tt.value), "Test case %s failed", tt.name)
+			})
+		}
+	})
+
+	// NilInterfaceFields ensures nil error and Stringer fields are printed as nil instead of panicking.
+	t.Run("NilInterfaceFields", func(t *testing.T) {
+		value := struct {
+			Err   error
+			Label fmt.Stringer
+			N     int
+		}{N: 1}
+
+		expected := `struct { Err error; Label fmt.Stringer; N int }{
+  Err: nil,
+  Label: nil,
+  N: 1,
+}`
+		assert.NotPanics(t, func() { Dump(value) }, "Expected no panic")
+		assert.Equal(t, expected, Dump(value), "Unexpected dump")
+	})
+
+	// Cycle ensures cyclic pointers are detected instead of recursing forever.
+	t.Run("Cycle", func(t *testing.T) {
+		first := &node{Name: "first"}
+		first.Next = &node{Name: "second", Next: first}
+
+		expected := `&debugx.node{
+  Name: "first",
+  Next: &debugx.node{
+    Name: "second",
+    Next: <cycle *debugx.node>,
+  },
+}`
+		assert.Equal(t, expected, Dump(first), "Unexpected dump")
+	})
+
+	// SharedPointer ensures a pointer referenced twice without a cycle is printed both times.
+	t.Run("SharedPointer", func(t *testing.T) {
+		shared := &node{Name: "shared"}
+		dump := Dump([]*node{shared, shared})
+
+		assert.NotContains(t, dump, "<cycle", "Expected no cycle")
+		assert.Equal(t, 2, countOccurrences(dump, `"shared"`), "Expected the shared value to be printed twice")
+	})
+
+	// MaxDepth ensures deep values are cut off.
+	t.Run("MaxDepth", func(t *testing.T) {
+		chain := &node{Name: "1", Next: &node{Name: "2", Next: &node{Name: "3"}}}
+
+		expected := `&debugx.node{
+  Name: "1",
+  Next: &debugx.node{...},
+}`
+		assert.Equal(t, expected, DumpWithOptions(chain, DumpOptions{MaxDepth: 1}), "Unexpected dump")
+	})
+}
+
+// countOccurrences counts the non-overlapping occurrences of sub in s.
+func countOccurrences(s, sub string) int {
+	count := 0
+	for i := 0; i+len(sub) <= len(s); i++ {
+		if s[i:i+len(sub)] == sub {
+			count++
+			i += len(sub) - 1
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,203 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ErrNotProvided is returned by Resolve when no constructor is registered for the requested type.
+var ErrNotProvided = errors.New("di: type not provided")
+
+// ErrCycle is returned by Resolve when constructors depend on each other in a cycle.
+var ErrCycle = errors.New("di: dependency cycle")
+
+// Starter is implemented by components that need to be started after construction, such as servers or consumers.
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper is implemented by components that hold resources to release on shutdown.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// Resolver resolves dependencies. It is implemented by Container and passed to constructors,
+// which use it with Resolve to obtain their own dependencies.
+type Resolver interface {
+	// resolve returns the instance of the type t, constructing it if needed.
+	resolve(t reflect.Type) (any, error)
+}
+
+// Container is a registry of constructors and the singleton instances built by them.
+// Every type is constructed once, on first resolution, and constructors resolve their dependencies
+// themselves, so the construction order follows the dependency graph without explicit wiring.
+// Container is safe for concurrent use by multiple goroutines.
+type Container struct {
+	// mu guards the fields below and serializes construction.
+	mu sync.Mutex
+	// providers holds the registered constructors by the type they construct.
+	providers map[reflect.Type]func(Resolver) (any, error)
+	// instances holds the constructed instances by type.
+	instances map[reflect.Type]any
+	// order lists the constructed instances in construction order, dependencies first.
+	order []any
+	// running is the number of instances in order already handled by Start.
+	running int
+	// stoppable reports for every instance in order whether Stop has to stop it: a Stopper is stoppable from
+	// its construction on, unless it implements Starter as well, in which case it is stoppable once started.
+	stoppable []bool
+}
+
+// New creates an empty Container.
+func New() *Container {
+	return &Container{
+		providers: make(map[reflect.Type]func(Resolver) (any, error)),
+		instances: make(map[reflect.Type]any),
+	}
+}
+
+// Provide registers the constructor of type T, replacing a previously registered one.
+// The constructor is called at most once, when T is resolved for the first time.
+// Providing a type that was already constructed does not affect the existing instance.
+func Provide[T any](c *Container, ctor func(r Resolver) (T, error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.providers[reflect.TypeFor[T]()] = func(r Resolver) (any, error) {
+		return ctor(r)
+	}
+}
+
+// Resolve returns the instance of type T, constructing it and its dependencies on first use.
+// Inside a constructor, pass the Resolver received by the constructor to resolve dependencies.
+func Resolve[T any](r Resolver) (T, error) {
+	instance, err := r.resolve(reflect.TypeFor[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// A constructor of an interface type may return nil, which is stored as an untyped nil.
+	value, _ := instance.(T)
+
+	return value, nil
+}
+
+// Start starts every constructed instance implementing Starter, in construction order, so dependencies
+// are started before the components using them. If an instance fails to start, the instances started
+// so far and the constructed instances implementing only Stopper are stopped in reverse order,
+// and the start error is returned.
+func (c *Container) Start(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Skip the instances started by a previous call, so Start can be called again after new resolutions.
+	for i := c.running; i < len(c.order); i++ {
+		instance := c.order[i]
+		c.running++
+
+		starter, ok := instance.(Starter)
+		if !ok {
+			continue
+		}
+
+		if err := starter.Start(ctx); err != nil {
+			return errors.Join(fmt.Errorf("di: start %T: %w", instance, err), c.stop(ctx))
+		}
+
+		// Stop the instance once it runs.
+		_, c.stoppable[i] = instance.(Stopper)
+	}
+
+	return nil
+}
+
+// Stop stops the instances started by Start and the constructed instances implementing only Stopper,
+// such as connection pools, in reverse construction order, so components are stopped before their
+// dependencies. Every instance is stopped once, even if some fail; the errors are joined.
+func (c *Container) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stop(ctx)
+}
+
+// stop stops the stoppable instances in reverse construction order. The caller must hold the mutex.
+func (c *Container) stop(ctx context.Context) error {
+	var errs []error
+	for i := len(c.order) - 1; i >= 0; i-- {
+		if !c.stoppable[i] {
+			continue
+		}
+		c.stoppable[i] = false
+
+		if err := c.order[i].(Stopper).Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("di: stop %T: %w", c.order[i], err))
+		}
+	}
+	c.running = 0
+
+	return errors.Join(errs...)
+}
+
+// resolve returns the instance of type t, holding the mutex for the whole construction.
+func (c *Container) resolve(t reflect.Type) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return (&resolution{container: c}).resolve(t)
+}
+
+// resolution is the Resolver passed to constructors during a Resolve call.
+// The container mutex is already held, and the types under construction are tracked to detect cycles.
+// Constructors must not keep it for use after they return.
+type resolution struct {
+	// container is the container being resolved from.
+	container *Container
+	// path lists the types under construction, outermost first.
+	path []reflect.Type
+}
+
+// resolve returns the instance of type t, constructing it if needed.
+func (r *resolution) resolve(t reflect.Type) (any, error) {
+	c := r.container
+
+	if instance, ok := c.instances[t]; ok {
+		return instance, nil
+	}
+
+	// A type already under construction means its constructor depends on itself.
+	if slices.Contains(r.path, t) {
+		names := make([]string, 0, len(r.path)+1)
+		for _, typ := range append(r.path, t) {
+			names = append(names, typ.String())
+		}
+		return nil, fmt.Errorf("%w: %s", ErrCycle, strings.Join(names, " -> "))
+	}
+
+	ctor, ok := c.providers[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotProvided, t)
+	}
+
+	// Construct with a resolver that knows the path, so nested dependencies are checked for cycles.
+	instance, err := ctor(&resolution{container: c, path: append(slices.Clip(r.path), t)})
+	if err != nil {
+		return nil, fmt.Errorf("di: construct %s: %w", t, err)
+	}
+
+	c.instances[t] = instance
+	c.order = append(c.order, instance)
+
+	// A Stopper without Start holds its resources from construction on.
+	_, isStarter := instance.(Starter)
+	_, isStopper := instance.(Stopper)
+	c.stoppable = append(c.stoppable, isStopper && !isStarter)
+
+	return instance, nil
+}
@@ -0,0 +1,195 @@
+package di
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// config, repository and service form a small dependency graph used by the tests.
+type (
+	config     struct{ dsn string }
+	repository struct{ cfg *config }
+	service    struct{ repo *repository }
+)
+
+// component records its lifecycle calls into a shared log.
+type component struct {
+	name     string
+	log      *[]string
+	startErr error
+}
+
+// Start records the start of the component.
+func (c *component) Start(context.Context) error {
+	*c.log = append(*c.log, "start "+c.name)
+	return c.startErr
+}
+
+// Stop records the stop of the component.
+func (c *component) Stop(context.Context) error {
+	*c.log = append(*c.log, "stop "+c.name)
+	return nil
+}
+
+// pool is a component that only needs to be stopped, like a connection pool.
+type pool struct {
+	name string
+	log  *[]string
+}
+
+// Stop records the stop of the pool.
+func (p pool) Stop(context.Context) error {
+	*p.log = append(*p.log, "stop "+p.name)
+	return nil
+}
+
+// TestResolve verifies that dependencies are constructed once and on demand.
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	// Graph ensures dependencies are resolved by constructors and shared as singletons.
+	t.Run("Graph", func(t *testing.T) {
+		c := New()
+		calls := 0
+
+		Provide(c, func(Resolver) (*config, error) {
+			calls++
+			return &config{dsn: "memory"}, nil
+		})
+		Provide(c, func(r Resolver) (*repository, error) {
+			cfg, err := Resolve[*config](r)
+			return &repository{cfg: cfg}, err
+		})
+		Provide(c, func(r Resolver) (*service, error) {
+			repo, err := Resolve[*repository](r)
+			return &service{repo: repo}, err
+		})
+
+		svc, err := Resolve[*service](c)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "memory", svc.repo.cfg.dsn, "Expected the dependencies to be injected")
+
+		cfg, err := Resolve[*config](c)
+		assert.NoError(t, err, "Expected no error")
+		assert.Same(t, svc.repo.cfg, cfg, "Expected a single instance")
+		assert.Equal(t, 1, calls, "Expected the constructor to be called once")
+	})
+
+	// NotProvided ensures a missing constructor is reported with the chain of constructors.
+	t.Run("NotProvided", func(t *testing.T) {
+		c := New()
+		Provide(c, func(r Resolver) (*repository, error) {
+			cfg, err := Resolve[*config](r)
+			return &repository{cfg: cfg}, err
+		})
+
+		_, err := Resolve[*repository](c)
+		assert.ErrorIs(t, err, ErrNotProvided, "Expected the missing type to be reported")
+	})
+
+	// Cycle ensures constructors depending on each other are detected.
+	t.Run("Cycle", func(t *testing.T) {
+		c := New()
+		Provide(c, func(r Resolver) (*config, error) {
+			_, err := Resolve[*repository](r)
+			return &config{}, err
+		})
+		Provide(c, func(r Resolver) (*repository, error) {
+			_, err := Resolve[*config](r)
+			return &repository{}, err
+		})
+
+		_, err := Resolve[*config](c)
+		assert.ErrorIs(t, err, ErrCycle, "Expected the cycle to be reported")
+		assert.ErrorContains(t, err, "*di.config -> *di.repository -> *di.config", "Expected the cycle path")
+	})
+
+	// ConstructorError ensures a failed construction is returned and not cached.
+	t.Run("ConstructorError", func(t *testing.T) {
+		c := New()
+		sample := errors.New("sample error")
+		Provide(c, func(Resolver) (*config, error) { return nil, sample })
+
+		_, err := Resolve[*config](c)
+		assert.ErrorIs(t, err, sample, "Expected the constructor error")
+
+		Provide(c, func(Resolver) (*config, error) { return &config{}, nil })
+		_, err = Resolve[*config](c)
+		assert.NoError(t, err, "Expected a later resolution to succeed")
+	})
+}
+
+// TestLifecycle verifies that components are started in dependency order and stopped in reverse.
+func TestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	type database struct{ *component }
+	type server struct{ *component }
+
+	// Order ensures dependencies start first and stop last.
+	t.Run("Order", func(t *testing.T) {
+		var log []string
+		c := New()
+
+		Provide(c, func(Resolver) (database, error) {
+			return database{&component{name: "database", log: &log}}, nil
+		})
+		Provide(c, func(r Resolver) (server, error) {
+			_, err := Resolve[database](r)
+			return server{&component{name: "server", log: &log}}, err
+		})
+
+		_, err := Resolve[server](c)
+		assert.NoError(t, err, "Expected no error")
+
+		assert.NoError(t, c.Start(context.Background()), "Expected no start error")
+		assert.NoError(t, c.Stop(context.Background()), "Expected no stop error")
+		assert.Equal(t, []string{"start database", "start server", "stop server", "stop database"}, log)
+	})
+
+	// StopperOnly ensures components that only release resources are stopped, in reverse construction order.
+	t.Run("StopperOnly", func(t *testing.T) {
+		var log []string
+		c := New()
+
+		Provide(c, func(Resolver) (pool, error) {
+			return pool{name: "pool", log: &log}, nil
+		})
+		Provide(c, func(r Resolver) (server, error) {
+			_, err := Resolve[pool](r)
+			return server{&component{name: "server", log: &log}}, err
+		})
+
+		_, err := Resolve[server](c)
+		assert.NoError(t, err, "Expected no error")
+
+		assert.NoError(t, c.Start(context.Background()), "Expected no start error")
+		assert.NoError(t, c.Stop(context.Background()), "Expected no stop error")
+		assert.NoError(t, c.Stop(context.Background()), "Expected no error stopping again")
+		assert.Equal(t, []string{"start server", "stop server", "stop pool"}, log)
+	})
+
+	// StartFailure ensures started components are stopped when a later one fails to start.
+	t.Run("StartFailure", func(t *testing.T) {
+		var log []string
+		sample := errors.New("sample error")
+		c := New()
+
+		Provide(c, func(Resolver) (database, error) {
+			return database{&component{name: "database", log: &log}}, nil
+		})
+		Provide(c, func(r Resolver) (server, error) {
+			_, err := Resolve[database](r)
+			return server{&component{name: "server", log: &log, startErr: sample}}, err
+		})
+
+		_, err := Resolve[server](c)
+		assert.NoError(t, err, "Expected no error")
+
+		assert.ErrorIs(t, c.Start(context.Background()), sample, "Expected the start error")
+		assert.Equal(t, []string{"start database", "start server", "stop database"}, log)
+	})
+}
@@ -0,0 +1,201 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/SyntaxErrorLineNULL/common"
+)
+
+// ErrClosed is returned by Publish after the bus has been closed.
+var ErrClosed = errors.New("events: bus closed")
+
+// Topic is a named channel of events of type T. The type parameter ties publishers and subscribers
+// of a topic to the same event type at compile time.
+type Topic[T any] struct {
+	// name identifies the topic on the bus.
+	name string
+}
+
+// NewTopic creates a topic with the given name. Topics with the same name share subscribers,
+// so the name should be declared once together with its event type.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name returns the name of the topic.
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// Options configures a Bus.
+type Options struct {
+	// Async delivers events in background goroutines, so Publish does not wait for the handlers.
+	Async bool
+	// OnError is called with the errors and recovered panics of handlers in async mode.
+	// In sync mode these errors are returned by Publish instead.
+	OnError func(topic string, err error)
+}
+
+// Stats holds the delivery counters of a Bus.
+type Stats struct {
+	// Published is the number of published events.
+	Published uint64
+	// Delivered is the number of handler calls that completed without an error.
+	Delivered uint64
+	// Failed is the number of handler calls that returned an error or panicked.
+	Failed uint64
+	// Panics is the number of handler calls that panicked, included in Failed.
+	Panics uint64
+}
+
+// Bus is an in-process publish/subscribe event bus. A panic in a handler is recovered and reported
+// as an error of that handler, so it neither affects other handlers nor crashes the publisher.
+// Bus is safe for concurrent use by multiple goroutines.
+type Bus struct {
+	// opts holds the configuration of the bus.
+	opts Options
+	// mu guards the fields below.
+	mu sync.RWMutex
+	// handlers holds the subscriptions by topic name.
+	handlers map[string][]*subscription
+	// closed reports whether Close has been called.
+	closed bool
+	// pending tracks the deliveries running in background goroutines.
+	pending sync.WaitGroup
+	// counters of the bus, reported by Stats.
+	published, delivered, failed, panics atomic.Uint64
+}
+
+// subscription is a handler registered for a topic.
+type subscription struct {
+	// handle receives the event as any and converts it back to the event type of the topic.
+	handle func(ctx context.Context, event any) error
+}
+
+// NewBus creates a Bus with the given options.
+func NewBus(opts Options) *Bus {
+	return &Bus{opts: opts, handlers: make(map[string][]*subscription)}
+}
+
+// Subscribe registers handler for the events published to topic and returns a function removing it.
+// Handlers of a topic are called in subscription order.
+func Subscribe[T any](b *Bus, topic Topic[T], handler func(ctx context.Context, event T) error) (unsubscribe func()) {
+	sub := &subscription{handle: func(ctx context.Context, event any) error {
+		return handler(ctx, event.(T))
+	}}
+
+	b.mu.Lock()
+	b.handlers[topic.name] = append(b.handlers[topic.name], sub)
+	b.mu.Unlock()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			// Copy the remaining handlers, so deliveries iterating the old slice are not affected.
+			handlers := b.handlers[topic.name]
+			remaining := make([]*subscription, 0, len(handlers))
+			for _, h := range handlers {
+				if h != sub {
+					remaining = append(remaining, h)
+				}
+			}
+			b.handlers[topic.name] = remaining
+		})
+	}
+}
+
+// Publish delivers event to the subscribers of topic. In sync mode it calls the handlers one after another
+// and returns their joined errors. In async mode it returns immediately and the errors are passed to
+// Options.OnError. Publishing to a closed bus returns ErrClosed.
+func Publish[T any](ctx context.Context, b *Bus, topic Topic[T], event T) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrClosed
+	}
+	handlers := b.handlers[topic.name]
+	// Register the async delivery while holding the lock, so Close cannot miss it.
+	if b.opts.Async {
+		b.pending.Add(1)
+	}
+	b.mu.RUnlock()
+
+	b.published.Add(1)
+
+	if !b.opts.Async {
+		errs := make([]error, 0, len(handlers))
+		for _, sub := range handlers {
+			errs = append(errs, b.deliver(ctx, sub, event))
+		}
+		return errors.Join(errs...)
+	}
+
+	go func() {
+		defer b.pending.Done()
+
+		for _, sub := range handlers {
+			if err := b.deliver(ctx, sub, event); err != nil && b.opts.OnError != nil {
+				b.opts.OnError(topic.name, err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Close stops accepting new events and waits until the pending async deliveries complete
+// or ctx is done, in which case the context error is returned.
+func (b *Bus) Close(ctx context.Context) error {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.pending.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Stats returns a snapshot of the delivery counters.
+func (b *Bus) Stats() Stats {
+	return Stats{
+		Published: b.published.Load(),
+		Delivered: b.delivered.Load(),
+		Failed:    b.failed.Load(),
+		Panics:    b.panics.Load(),
+	}
+}
+
+// deliver calls the handler of sub, converting a panic into an error, and updates the counters.
+func (b *Bus) deliver(ctx context.Context, sub *subscription, event any) error {
+	var err error
+	if panicErr := common.Try(func() { err = sub.handle(ctx, event) }); panicErr != nil {
+		b.panics.Add(1)
+		err = fmt.Errorf("events: handler panicked: %w", panicErr)
+	}
+
+	if err != nil {
+		b.failed.Add(1)
+		return err
+	}
+
+	b.delivered.Add(1)
+
+	return nil
+}
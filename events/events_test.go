@@ -0,0 +1,136 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// commandFinished is a sample event published when a command completes.
+type commandFinished struct {
+	Name     string
+	ExitCode int
+}
+
+// finished is the topic used by the tests.
+var finished = NewTopic[commandFinished]("command.finished")
+
+// TestBusSync verifies delivery, error reporting and panic isolation in sync mode.
+func TestBusSync(t *testing.T) {
+	t.Parallel()
+
+	// Delivery ensures every subscriber receives the event in subscription order.
+	t.Run("Delivery", func(t *testing.T) {
+		bus := NewBus(Options{})
+
+		var received []string
+		Subscribe(bus, finished, func(_ context.Context, event commandFinished) error {
+			received = append(received, "first "+event.Name)
+			return nil
+		})
+		Subscribe(bus, finished, func(_ context.Context, event commandFinished) error {
+			received = append(received, "second "+event.Name)
+			return nil
+		})
+
+		err := Publish(context.Background(), bus, finished, commandFinished{Name: "ls"})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, []string{"first ls", "second ls"}, received, "Expected both handlers in order")
+		assert.Equal(t, Stats{Published: 1, Delivered: 2}, bus.Stats(), "Unexpected stats")
+	})
+
+	// PanicIsolation ensures a panicking handler does not prevent delivery to the others.
+	t.Run("PanicIsolation", func(t *testing.T) {
+		bus := NewBus(Options{})
+		sample := errors.New("sample error")
+
+		Subscribe(bus, finished, func(context.Context, commandFinished) error { panic(sample) })
+		Subscribe(bus, finished, func(context.Context, commandFinished) error { return errors.New("handler failed") })
+		delivered := false
+		Subscribe(bus, finished, func(context.Context, commandFinished) error {
+			delivered = true
+			return nil
+		})
+
+		err := Publish(context.Background(), bus, finished, commandFinished{Name: "ls"})
+		assert.ErrorIs(t, err, sample, "Expected the panic to be returned as an error")
+		assert.ErrorContains(t, err, "handler failed", "Expected the handler error")
+		assert.True(t, delivered, "Expected the remaining handler to be called")
+		assert.Equal(t, Stats{Published: 1, Delivered: 1, Failed: 2, Panics: 1}, bus.Stats(), "Unexpected stats")
+	})
+
+	// Unsubscribe ensures a removed handler no longer receives events.
+	t.Run("Unsubscribe", func(t *testing.T) {
+		bus := NewBus(Options{})
+
+		calls := 0
+		unsubscribe := Subscribe(bus, finished, func(context.Context, commandFinished) error {
+			calls++
+			return nil
+		})
+
+		assert.NoError(t, Publish(context.Background(), bus, finished, commandFinished{}), "Expected no error")
+		unsubscribe()
+		unsubscribe()
+		assert.NoError(t, Publish(context.Background(), bus, finished, commandFinished{}), "Expected no error")
+
+		assert.Equal(t, 1, calls, "Expected a single call before unsubscribing")
+	})
+
+	// Closed ensures publishing to a closed bus fails.
+	t.Run("Closed", func(t *testing.T) {
+		bus := NewBus(Options{})
+		assert.NoError(t, bus.Close(context.Background()), "Expected no error")
+
+		err := Publish(context.Background(), bus, finished, commandFinished{})
+		assert.ErrorIs(t, err, ErrClosed, "Expected the closed error")
+	})
+}
+
+// TestBusAsync verifies that async delivery runs in the background and reports errors through OnError.
+func TestBusAsync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		errs   []error
+		events []commandFinished
+	)
+
+	bus := NewBus(Options{Async: true, OnError: func(topic string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}})
+
+	release := make(chan struct{})
+	Subscribe(bus, finished, func(_ context.Context, event commandFinished) error {
+		<-release
+
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+		return nil
+	})
+	Subscribe(bus, finished, func(context.Context, commandFinished) error { panic("boom") })
+
+	// Publish must return while the handler is still blocked.
+	err := Publish(context.Background(), bus, finished, commandFinished{Name: "make", ExitCode: 2})
+	assert.NoError(t, err, "Expected no error")
+
+	// Close must wait for the pending delivery.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Close(ctx), context.DeadlineExceeded, "Expected Close to wait for the handler")
+
+	close(release)
+	assert.NoError(t, bus.Close(context.Background()), "Expected the pending delivery to complete")
+
+	assert.Equal(t, []commandFinished{{Name: "make", ExitCode: 2}}, events, "Expected the event to be delivered")
+	assert.Len(t, errs, 1, "Expected the panic to be reported")
+	assert.ErrorContains(t, errs[0], "panic: boom", "Expected the panic value")
+}
@@ -1,8 +1,10 @@
 package filesystem
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // RecursiveCreatePath ensures that all directories in the specified file path exist.
@@ -32,3 +34,296 @@ func RecursiveCreatePath(filePath string) error {
 	// Return nil to indicate success.
 	return nil
 }
+
+// CreateWithDirs creates or truncates the file at path with the given permissions, creating any missing
+// parent directories first. The file is opened for reading and writing.
+func CreateWithDirs(path string, perm os.FileMode) (*os.File, error) {
+	// Make sure the parent directories exist before opening the file.
+	if err := RecursiveCreatePath(path); err != nil {
+		return nil, err
+	}
+
+	return os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, perm)
+}
+
+// AtomicFile is a file that becomes visible at its final path only once it is committed.
+// Data is written to a temporary file in the same directory, which is renamed over the target path
+// on Commit, so readers never observe a partially written file. Closing the file without committing
+// discards the temporary file and leaves any existing target untouched.
+//
+// On Linux the temporary file is created with O_TMPFILE when the filesystem supports it. Such a file has
+// no name until it is committed, so nothing is left behind if the process crashes before Commit.
+// On other systems, or when O_TMPFILE is unavailable, a regular hidden temporary file is used.
+type AtomicFile struct {
+	// File is the temporary file receiving the data.
+	*os.File
+	// path is the final path of the file.
+	path string
+	// perm is the permission applied to the file before it is renamed.
+	perm os.FileMode
+	// anonymous reports whether the temporary file was created with O_TMPFILE and has no name yet.
+	anonymous bool
+	// done reports whether the file has already been committed or discarded.
+	done bool
+}
+
+// CreateAtomic creates an AtomicFile that will be placed at path with the given permissions on Commit.
+// Missing parent directories are created first.
+func CreateAtomic(path string, perm os.FileMode) (*AtomicFile, error) {
+	// Make sure the target directory exists, as the temporary file is created next to the target.
+	if err := RecursiveCreatePath(path); err != nil {
+		return nil, err
+	}
+
+	// Prefer an anonymous temporary file, which never shows up in the directory before Commit.
+	if file, err := openAnonymousFile(filepath.Dir(path), perm); err == nil {
+		return &AtomicFile{File: file, path: path, perm: perm, anonymous: true}, nil
+	}
+
+	// Fall back to a named temporary file in the same directory, so the final rename stays on one filesystem.
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtomicFile{File: file, path: path, perm: perm}, nil
+}
+
+// Commit flushes the written data to disk and atomically moves the temporary file to its final path.
+func (f *AtomicFile) Commit() error {
+	// A file can only be committed once.
+	if f.done {
+		return os.ErrClosed
+	}
+	f.done = true
+
+	// Flush the data to stable storage before the rename makes it visible.
+	if err := f.File.Sync(); err != nil {
+		_ = f.discard()
+		return err
+	}
+
+	// Apply the requested permissions, since temporary files are created with restricted ones.
+	if err := f.File.Chmod(f.perm); err != nil {
+		_ = f.discard()
+		return err
+	}
+
+	// Give an anonymous file a name in the target directory, so it can be renamed over the target.
+	tempName := f.File.Name()
+	if f.anonymous {
+		linked, err := linkAnonymousFile(f.File, f.path)
+		if err != nil {
+			_ = f.File.Close()
+			return err
+		}
+		tempName = linked
+	}
+
+	if err := f.File.Close(); err != nil {
+		_ = os.Remove(tempName)
+		return err
+	}
+
+	// Replace the target path with the complete file.
+	if err := os.Rename(tempName, f.path); err != nil {
+		_ = os.Remove(tempName)
+		return err
+	}
+
+	return nil
+}
+
+// Close discards the temporary file if it has not been committed. It is safe to call after Commit,
+// which makes `defer file.Close()` a convenient way to clean up on error paths.
+func (f *AtomicFile) Close() error {
+	if f.done {
+		return nil
+	}
+	f.done = true
+
+	return f.discard()
+}
+
+// discard closes the temporary file and removes it unless it is anonymous,
+// in which case the kernel releases it as soon as it is closed.
+func (f *AtomicFile) discard() error {
+	err := f.File.Close()
+	if f.anonymous {
+		return err
+	}
+
+	if removeErr := os.Remove(f.File.Name()); err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
+// RemoveOptions configures RemoveAllSafe.
+type RemoveOptions struct {
+	// AllowedRoot, if set, restricts removal to paths strictly inside this directory.
+	AllowedRoot string
+	// DryRun lists the entries that would be removed without removing anything.
+	DryRun bool
+}
+
+// RemoveFailure describes an entry that could not be removed.
+type RemoveFailure struct {
+	// Path is the path of the entry.
+	Path string
+	// Err is the error returned while removing the entry.
+	Err error
+}
+
+// RemoveResult reports the outcome of RemoveAllSafe.
+type RemoveResult struct {
+	// Removed lists the removed entries, or the entries that would be removed in dry-run mode,
+	// in removal order: the contents of a directory come before the directory itself.
+	Removed []string
+	// Failures lists the entries that could not be removed.
+	Failures []RemoveFailure
+}
+
+// RemoveAllSafe removes path and everything it contains, like os.RemoveAll, but with safety checks.
+// It refuses to remove the filesystem root, the current user's home directory or any of its ancestors,
+// and, when opts.AllowedRoot is set, any path that is not strictly inside that root. Symbolic links are
+// removed, never followed. Unlike os.RemoveAll it does not stop at the first error: every entry that
+// cannot be removed is reported in the result, and the returned error joins all of these failures.
+// A path that does not exist is not an error.
+func RemoveAllSafe(path string, opts RemoveOptions) (*RemoveResult, error) {
+	// Resolve the target to a clean absolute path before running any checks.
+	target, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse to remove protected locations.
+	if err = checkRemovable(target, opts.AllowedRoot); err != nil {
+		return nil, err
+	}
+
+	result := &RemoveResult{}
+
+	// Collect the entries first, so that they can be removed from the deepest level upwards.
+	var entries []string
+	walkErr := filepath.WalkDir(target, func(entry string, _ os.DirEntry, err error) error {
+		if err != nil {
+			// A missing target is not an error; anything else is recorded and the walk continues.
+			if entry == target && os.IsNotExist(err) {
+				return filepath.SkipAll
+			}
+			result.Failures = append(result.Failures, RemoveFailure{Path: entry, Err: err})
+			return nil
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if walkErr != nil {
+		return nil, walkErr
+	}
+
+	// failed holds the entries that could not be removed, so that their parent directories are skipped.
+	failed := make(map[string]bool, len(result.Failures))
+	for _, failure := range result.Failures {
+		markFailed(failed, failure.Path, target)
+	}
+
+	// Walk the entries in reverse, so the contents of every directory are removed before the directory.
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+
+		// A directory with a child that could not be removed cannot be removed either.
+		if failed[entry] {
+			continue
+		}
+
+		if !opts.DryRun {
+			if err = os.Remove(entry); err != nil && !os.IsNotExist(err) {
+				result.Failures = append(result.Failures, RemoveFailure{Path: entry, Err: err})
+				markFailed(failed, entry, target)
+				continue
+			}
+		}
+
+		result.Removed = append(result.Removed, entry)
+	}
+
+	// Join the individual failures into a single error.
+	errs := make([]error, 0, len(result.Failures))
+	for _, failure := range result.Failures {
+		errs = append(errs, &os.PathError{Op: "remove", Path: failure.Path, Err: failure.Err})
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// resolvePath returns the clean absolute form of path with symbolic links in its parent directories resolved.
+// The last element is kept as-is, so a symbolic link is identified by its own path rather than its target.
+func resolvePath(path string) (string, error) {
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve the parent directory if it exists; otherwise fall back to the lexical path.
+	parent, err := filepath.EvalSymlinks(filepath.Dir(absolute))
+	if err != nil {
+		return absolute, nil
+	}
+
+	return filepath.Join(parent, filepath.Base(absolute)), nil
+}
+
+// checkRemovable reports an error if target is a protected location or lies outside the allowed root.
+func checkRemovable(target, allowedRoot string) error {
+	// Never remove the filesystem (or volume) root.
+	if target == filepath.Dir(target) {
+		return errors.New("refusing to remove the filesystem root: " + target)
+	}
+
+	// Never remove the home directory or one of its ancestors.
+	if home, err := os.UserHomeDir(); err == nil {
+		if resolvedHome, err := resolvePath(home); err == nil && isWithin(resolvedHome, target, true) {
+			return errors.New("refusing to remove the home directory or one of its ancestors: " + target)
+		}
+	}
+
+	// Enforce the allowed root, if configured.
+	if allowedRoot != "" {
+		root, err := resolvePath(allowedRoot)
+		if err != nil {
+			return err
+		}
+		if !isWithin(target, root, false) {
+			return errors.New("refusing to remove a path outside of " + root + ": " + target)
+		}
+	}
+
+	return nil
+}
+
+// isWithin reports whether path is inside dir. If inclusive is set, path equal to dir also counts as inside.
+func isWithin(path, dir string, inclusive bool) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+
+	if rel == "." {
+		return inclusive
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// markFailed marks path and all of its ancestors up to root as failed.
+func markFailed(failed map[string]bool, path, root string) {
+	for {
+		failed[path] = true
+		if path == root || path == filepath.Dir(path) {
+			return
+		}
+		path = filepath.Dir(path)
+	}
+}
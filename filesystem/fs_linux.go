@@ -0,0 +1,63 @@
+//go:build linux
+
+package filesystem
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"golang.org/x/sys/unix"
+)
+
+// openAnonymousFile creates an unnamed file in dir using O_TMPFILE.
+// It fails if the kernel or the filesystem does not support O_TMPFILE, and when /proc is not mounted,
+// as in some containers and chroots, since the file could then not be linked on Commit without
+// CAP_DAC_READ_SEARCH and its data would be lost.
+func openAnonymousFile(dir string, perm os.FileMode) (*os.File, error) {
+	fd, err := unix.Open(dir, unix.O_TMPFILE|unix.O_RDWR|unix.O_CLOEXEC, uint32(perm.Perm()))
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: dir, Err: err}
+	}
+
+	// Probe the path linkAnonymousFile links through, so the caller can fall back to a named file now.
+	if err := unix.Access(procFDPath(fd), unix.F_OK); err != nil {
+		_ = unix.Close(fd)
+		return nil, &os.PathError{Op: "access", Path: procFDPath(fd), Err: err}
+	}
+
+	return os.NewFile(uintptr(fd), dir), nil
+}
+
+// procFDPath returns the path of the file descriptor fd under /proc.
+func procFDPath(fd int) string {
+	return "/proc/self/fd/" + strconv.Itoa(fd)
+}
+
+// linkAnonymousFile gives the anonymous file a hidden temporary name next to path using linkat
+// and returns that name, so the caller can rename it over the target.
+func linkAnonymousFile(file *os.File, path string) (string, error) {
+	// Pick a random hidden name in the target directory; linkat cannot replace an existing file.
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+	tempName := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp-"+hex.EncodeToString(suffix))
+
+	// Link the descriptor through /proc, which works without CAP_DAC_READ_SEARCH unlike AT_EMPTY_PATH.
+	// Should /proc have gone away since the file was opened, try AT_EMPTY_PATH, which is permitted
+	// with that capability.
+	fd := int(file.Fd())
+	source := procFDPath(fd)
+	err := unix.Linkat(unix.AT_FDCWD, source, unix.AT_FDCWD, tempName, unix.AT_SYMLINK_FOLLOW)
+	if err != nil && unix.Linkat(fd, "", unix.AT_FDCWD, tempName, unix.AT_EMPTY_PATH) == nil {
+		err = nil
+	}
+	if err != nil {
+		return "", &os.LinkError{Op: "linkat", Old: source, New: tempName, Err: err}
+	}
+
+	return tempName, nil
+}
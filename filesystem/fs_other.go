@@ -0,0 +1,18 @@
+//go:build !linux
+
+package filesystem
+
+import (
+	"errors"
+	"os"
+)
+
+// openAnonymousFile is not supported outside Linux, so AtomicFile always uses a named temporary file.
+func openAnonymousFile(string, os.FileMode) (*os.File, error) {
+	return nil, errors.ErrUnsupported
+}
+
+// linkAnonymousFile is never called outside Linux, as no anonymous files are created there.
+func linkAnonymousFile(*os.File, string) (string, error) {
+	return "", errors.ErrUnsupported
+}
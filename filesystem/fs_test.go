@@ -86,3 +86,217 @@ func TestRecursiveCreatePath(t *testing.T) {
 		_ = os.RemoveAll(existingDir)
 	})
 }
+
+// TestCreateAtomic tests that an AtomicFile only becomes visible at its final path once committed.
+func TestCreateAtomic(t *testing.T) {
+	t.Parallel()
+
+	// CommitPublishesFile verifies that committed data appears at the target path with the requested permissions,
+	// creating missing parent directories along the way.
+	t.Run("CommitPublishesFile", func(t *testing.T) {
+		baseDir := t.TempDir()
+		target := filepath.Join(baseDir, "nested", "file.txt")
+
+		file, err := CreateAtomic(target, 0o640)
+		assert.NoError(t, err, "Expected no error while creating the atomic file")
+
+		_, err = file.Write([]byte("content"))
+		assert.NoError(t, err, "Expected no error while writing")
+
+		// The target must not exist before the commit.
+		_, err = os.Stat(target)
+		assert.True(t, os.IsNotExist(err), "Expected the target to be absent before commit")
+
+		assert.NoError(t, file.Commit(), "Expected no error while committing")
+		assert.NoError(t, file.Close(), "Expected Close after Commit to be a no-op")
+
+		data, err := os.ReadFile(target)
+		assert.NoError(t, err, "Expected the target to exist after commit")
+		assert.Equal(t, "content", string(data), "Unexpected file content")
+
+		info, _ := os.Stat(target)
+		assert.Equal(t, os.FileMode(0o640), info.Mode().Perm(), "Unexpected file permissions")
+
+		// No temporary files must be left behind.
+		entries, _ := os.ReadDir(filepath.Dir(target))
+		assert.Len(t, entries, 1, "Expected only the committed file in the directory")
+	})
+
+	// CloseDiscardsFile verifies that closing without a commit leaves the existing target untouched.
+	t.Run("CloseDiscardsFile", func(t *testing.T) {
+		baseDir := t.TempDir()
+		target := filepath.Join(baseDir, "file.txt")
+		assert.NoError(t, os.WriteFile(target, []byte("original"), 0o600), "Expected no error while preparing the target")
+
+		file, err := CreateAtomic(target, 0o600)
+		assert.NoError(t, err, "Expected no error while creating the atomic file")
+		_, _ = file.Write([]byte("partial"))
+		assert.NoError(t, file.Close(), "Expected no error while discarding")
+		assert.ErrorIs(t, file.Commit(), os.ErrClosed, "Expected a commit after close to fail")
+
+		data, _ := os.ReadFile(target)
+		assert.Equal(t, "original", string(data), "Expected the target to stay unchanged")
+
+		entries, _ := os.ReadDir(baseDir)
+		assert.Len(t, entries, 1, "Expected the temporary file to be removed")
+	})
+
+	// AnonymousFileIsHidden verifies that a file created with O_TMPFILE does not appear in the directory
+	// before it is committed. The check is skipped where anonymous files are not supported.
+	t.Run("AnonymousFileIsHidden", func(t *testing.T) {
+		baseDir := t.TempDir()
+		target := filepath.Join(baseDir, "file.txt")
+
+		file, err := CreateAtomic(target, 0o600)
+		assert.NoError(t, err, "Expected no error while creating the atomic file")
+		defer file.Close()
+
+		if !file.anonymous {
+			t.Skip("anonymous temporary files are not supported on this system")
+		}
+
+		entries, _ := os.ReadDir(baseDir)
+		assert.Empty(t, entries, "Expected no directory entry before commit")
+
+		_, _ = file.Write([]byte("content"))
+		assert.NoError(t, file.Commit(), "Expected no error while committing")
+
+		data, _ := os.ReadFile(target)
+		assert.Equal(t, "content", string(data), "Unexpected file content")
+	})
+}
+
+// TestCreateWithDirs verifies that CreateWithDirs creates missing parent directories and truncates existing files.
+func TestCreateWithDirs(t *testing.T) {
+	t.Parallel()
+
+	// CreatesParents verifies that the file is created along with its missing parent directories.
+	t.Run("CreatesParents", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "a", "b", "file.txt")
+
+		file, err := CreateWithDirs(target, 0o600)
+		assert.NoError(t, err, "Expected no error while creating the file")
+		_, err = file.Write([]byte("content"))
+		assert.NoError(t, err, "Expected no error while writing")
+		assert.NoError(t, file.Close(), "Expected no error while closing")
+
+		data, err := os.ReadFile(target)
+		assert.NoError(t, err, "Expected the file to exist")
+		assert.Equal(t, "content", string(data), "Unexpected file content")
+	})
+
+	// TruncatesExisting verifies that an existing file is truncated.
+	t.Run("TruncatesExisting", func(t *testing.T) {
+		target := filepath.Join(t.TempDir(), "file.txt")
+		assert.NoError(t, os.WriteFile(target, []byte("original"), 0o600), "Expected no error while preparing the file")
+
+		file, err := CreateWithDirs(target, 0o600)
+		assert.NoError(t, err, "Expected no error while opening the file")
+		assert.NoError(t, file.Close(), "Expected no error while closing")
+
+		data, _ := os.ReadFile(target)
+		assert.Empty(t, data, "Expected the file to be truncated")
+	})
+}
+
+// TestRemoveAllSafe tests that RemoveAllSafe removes trees while refusing protected locations.
+func TestRemoveAllSafe(t *testing.T) {
+	t.Parallel()
+
+	// createTree creates a small directory tree and returns its root.
+	createTree := func(t *testing.T) string {
+		root := filepath.Join(t.TempDir(), "tree")
+		assert.NoError(t, RecursiveCreatePath(filepath.Join(root, "a", "b", "file.txt")), "Expected no error while creating directories")
+		assert.NoError(t, os.WriteFile(filepath.Join(root, "a", "b", "file.txt"), []byte("x"), 0o600), "Expected no error while writing")
+		assert.NoError(t, os.WriteFile(filepath.Join(root, "top.txt"), []byte("y"), 0o600), "Expected no error while writing")
+		return root
+	}
+
+	// RemovesTree verifies that the whole tree is removed, contents before their directories.
+	t.Run("RemovesTree", func(t *testing.T) {
+		root := createTree(t)
+
+		result, err := RemoveAllSafe(root, RemoveOptions{AllowedRoot: filepath.Dir(root)})
+		assert.NoError(t, err, "Expected no error while removing")
+		assert.Len(t, result.Removed, 5, "Expected every entry to be removed")
+		assert.Equal(t, root, result.Removed[len(result.Removed)-1], "Expected the root to be removed last")
+
+		_, err = os.Stat(root)
+		assert.True(t, os.IsNotExist(err), "Expected the tree to be gone")
+	})
+
+	// DryRun verifies that nothing is removed while the entries are still listed.
+	t.Run("DryRun", func(t *testing.T) {
+		root := createTree(t)
+
+		result, err := RemoveAllSafe(root, RemoveOptions{DryRun: true})
+		assert.NoError(t, err, "Expected no error in dry-run mode")
+		assert.Len(t, result.Removed, 5, "Expected every entry to be listed")
+
+		_, err = os.Stat(filepath.Join(root, "a", "b", "file.txt"))
+		assert.NoError(t, err, "Expected the tree to stay intact")
+	})
+
+	// MissingPath verifies that a missing path is not an error.
+	t.Run("MissingPath", func(t *testing.T) {
+		result, err := RemoveAllSafe(filepath.Join(t.TempDir(), "missing"), RemoveOptions{})
+		assert.NoError(t, err, "Expected no error for a missing path")
+		assert.Empty(t, result.Removed, "Expected nothing to be removed")
+	})
+
+	// SymlinkNotFollowed verifies that a symbolic link is removed without touching its target.
+	t.Run("SymlinkNotFollowed", func(t *testing.T) {
+		outside := createTree(t)
+		root := filepath.Join(t.TempDir(), "links")
+		assert.NoError(t, os.Mkdir(root, 0o755), "Expected no error while creating the directory")
+		assert.NoError(t, os.Symlink(outside, filepath.Join(root, "link")), "Expected no error while creating the link")
+
+		_, err := RemoveAllSafe(root, RemoveOptions{})
+		assert.NoError(t, err, "Expected no error while removing")
+
+		_, err = os.Stat(filepath.Join(outside, "top.txt"))
+		assert.NoError(t, err, "Expected the link target to stay intact")
+	})
+
+	// RefusesProtectedPaths verifies that the root, the home directory and paths outside the allowed root are refused.
+	t.Run("RefusesProtectedPaths", func(t *testing.T) {
+		_, err := RemoveAllSafe(string(filepath.Separator), RemoveOptions{DryRun: true})
+		assert.Error(t, err, "Expected the filesystem root to be refused")
+
+		if home, homeErr := os.UserHomeDir(); homeErr == nil {
+			_, err = RemoveAllSafe(home, RemoveOptions{DryRun: true})
+			assert.Error(t, err, "Expected the home directory to be refused")
+
+			_, err = RemoveAllSafe(filepath.Dir(home), RemoveOptions{DryRun: true})
+			assert.Error(t, err, "Expected an ancestor of the home directory to be refused")
+		}
+
+		root := createTree(t)
+		_, err = RemoveAllSafe(root, RemoveOptions{AllowedRoot: filepath.Join(root, "a"), DryRun: true})
+		assert.Error(t, err, "Expected a path outside the allowed root to be refused")
+
+		_, err = RemoveAllSafe(root, RemoveOptions{AllowedRoot: root, DryRun: true})
+		assert.Error(t, err, "Expected the allowed root itself to be refused")
+
+		_, err = RemoveAllSafe(filepath.Join(root, "a", "..", ".."), RemoveOptions{AllowedRoot: root, DryRun: true})
+		assert.Error(t, err, "Expected a path escaping the allowed root to be refused")
+	})
+
+	// ReportsFailures verifies that entries which cannot be removed are reported while the rest is removed.
+	t.Run("ReportsFailures", func(t *testing.T) {
+		// Permission checks do not apply to the root user.
+		if os.Geteuid() == 0 {
+			t.Skip("permission checks are bypassed when running as root")
+		}
+
+		root := createTree(t)
+		locked := filepath.Join(root, "a", "b")
+		assert.NoError(t, os.Chmod(locked, 0o500), "Expected no error while locking the directory")
+		t.Cleanup(func() { _ = os.Chmod(locked, 0o755) })
+
+		result, err := RemoveAllSafe(root, RemoveOptions{})
+		assert.Error(t, err, "Expected an error for the locked directory")
+		assert.NotEmpty(t, result.Failures, "Expected the failure to be reported")
+		assert.Contains(t, result.Removed, filepath.Join(root, "top.txt"), "Expected unrelated entries to be removed")
+	})
+}
@@ -0,0 +1,220 @@
+package flags
+
+import (
+	"context"
+	"hash/fnv"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+)
+
+// Flag is the definition of a feature flag.
+// A flag is on for a subject when it is enabled, the subject matches every rule,
+// and the subject falls into the rollout percentage.
+type Flag struct {
+	// Name identifies the flag.
+	Name string `json:"name"`
+	// Enabled switches the flag on or off for every subject.
+	Enabled bool `json:"enabled"`
+	// Percentage is the share of subjects, from 0 to 100, the flag is on for. Nil means all subjects,
+	// while zero means none, so a rollout can be rolled back to zero without turning the flag on for everyone.
+	Percentage *float64 `json:"percentage,omitempty"`
+	// Rules restrict the flag to subjects with matching attributes.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// Rule matches subjects whose attribute has one of the listed values.
+type Rule struct {
+	// Attribute is the name of the subject attribute, for example "country".
+	Attribute string `json:"attribute"`
+	// Values are the accepted values of the attribute.
+	Values []string `json:"values"`
+}
+
+// Subject is the entity a flag is evaluated for, such as a user or a tenant.
+type Subject struct {
+	// Key identifies the subject. The same key always falls into the same rollout bucket,
+	// so a subject does not flip between variants of a partially rolled out flag.
+	Key string
+	// Attributes are matched against the rules of a flag.
+	Attributes map[string]string
+}
+
+// Source loads flag definitions, for example from a configuration file or the environment.
+type Source interface {
+	// Load returns the current flag definitions.
+	Load(ctx context.Context) ([]Flag, error)
+}
+
+// Store holds the flag definitions loaded from its sources and evaluates them.
+// Store is safe for concurrent use by multiple goroutines.
+type Store struct {
+	// sources are loaded in order, so later sources override flags of earlier ones.
+	sources []Source
+	// mu guards the fields below.
+	mu sync.RWMutex
+	// flags holds the loaded flags by name.
+	flags map[string]Flag
+	// watchers receive the flags changed by a reload.
+	watchers []chan Flag
+}
+
+// NewStore creates a Store for the given sources. Call Load to load the flags before evaluating them.
+func NewStore(sources ...Source) *Store {
+	return &Store{sources: sources, flags: make(map[string]Flag)}
+}
+
+// Load loads the flags from all sources and replaces the current definitions.
+// When several sources define the same flag, the last one wins. Flags that were added, changed
+// or removed are sent to the watchers; a removed flag is sent as a disabled flag with its name only.
+// If any source fails, the current definitions are kept and the error is returned.
+func (s *Store) Load(ctx context.Context) error {
+	flags := make(map[string]Flag)
+	for _, source := range s.sources {
+		loaded, err := source.Load(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, flag := range loaded {
+			flags[flag.Name] = flag
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Collect the flags that differ from the current definitions.
+	var changed []Flag
+	for name, flag := range flags {
+		if current, ok := s.flags[name]; !ok || !reflect.DeepEqual(current, flag) {
+			changed = append(changed, flag)
+		}
+	}
+	for name := range s.flags {
+		if _, ok := flags[name]; !ok {
+			changed = append(changed, Flag{Name: name})
+		}
+	}
+
+	s.flags = flags
+	s.notify(changed)
+
+	return nil
+}
+
+// Poll reloads the flags every interval until ctx is done, and returns the context error.
+// Errors of individual reloads are passed to onError, if set, and the previous definitions stay in effect.
+func (s *Store) Poll(ctx context.Context, interval time.Duration, onError func(error)) error {
+	for {
+		if err := common.SleepCtx(ctx, interval); err != nil {
+			return err
+		}
+
+		if err := s.Load(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// Watch returns a channel receiving every flag changed by a later Load, until ctx is done.
+// The channel is buffered; if the receiver falls behind and the buffer is full, changes are dropped
+// rather than blocking the reload, so receivers should re-read the flags they care about with Get.
+func (s *Store) Watch(ctx context.Context) <-chan Flag {
+	ch := make(chan Flag, 16)
+
+	s.mu.Lock()
+	s.watchers = append(s.watchers, ch)
+	s.mu.Unlock()
+
+	// Unregister and close the channel once the context is done.
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.watchers = slices.DeleteFunc(s.watchers, func(watcher chan Flag) bool { return watcher == ch })
+		close(ch)
+	}()
+
+	return ch
+}
+
+// Get returns the definition of the named flag and whether it is defined.
+func (s *Store) Get(name string) (Flag, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	flag, ok := s.flags[name]
+
+	return flag, ok
+}
+
+// Enabled reports whether the named flag is on for the subject. Undefined flags are off.
+func (s *Store) Enabled(name string, subject Subject) bool {
+	flag, ok := s.Get(name)
+
+	return ok && flag.Evaluate(subject)
+}
+
+// Evaluate reports whether the flag is on for the subject.
+func (f Flag) Evaluate(subject Subject) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	// The subject must match every rule.
+	for _, rule := range f.Rules {
+		value, ok := subject.Attributes[rule.Attribute]
+		if !ok || !slices.Contains(rule.Values, value) {
+			return false
+		}
+	}
+
+	// Without a percentage, or with a zero or full one, the result does not depend on the subject key.
+	switch {
+	case f.Percentage == nil || *f.Percentage >= 100:
+		return true
+	case *f.Percentage <= 0:
+		return false
+	}
+
+	return bucket(f.Name, subject.Key) < *f.Percentage
+}
+
+// notify sends the changed flags to the watchers without blocking. The caller must hold the mutex.
+func (s *Store) notify(changed []Flag) {
+	for _, watcher := range s.watchers {
+		for _, flag := range changed {
+			select {
+			case watcher <- flag:
+			default:
+			}
+		}
+	}
+}
+
+// bucket maps the subject key to a stable position in [0, 100) for the named flag.
+// The flag name is part of the hash, so a subject does not land in the same bucket for every flag.
+func bucket(name, key string) float64 {
+	h := fnv.New64a()
+	// Writing to an FNV hash never returns an error.
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+
+	// FNV alone spreads similar keys poorly, so mix the bits with the MurmurHash3 finalizer.
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+
+	// Use a resolution of 0.01 percent.
+	return float64(x%10000) / 100
+}
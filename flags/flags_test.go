@@ -0,0 +1,192 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// switchingSource is a Source whose flags and error can be changed between loads.
+type switchingSource struct {
+	flags []Flag
+	err   error
+}
+
+// Load returns the current flags or error of the source.
+func (s *switchingSource) Load(context.Context) ([]Flag, error) {
+	return s.flags, s.err
+}
+
+// TestFlagEvaluate verifies the evaluation of the enabled switch, rules and rollout percentage.
+func TestFlagEvaluate(t *testing.T) {
+	t.Parallel()
+
+	// Disabled ensures a disabled flag is off regardless of the subject.
+	t.Run("Disabled", func(t *testing.T) {
+		flag := Flag{Name: "beta"}
+		assert.False(t, flag.Evaluate(Subject{Key: "user-1"}), "Expected a disabled flag to be off")
+	})
+
+	// Rules ensures the subject must match every rule.
+	t.Run("Rules", func(t *testing.T) {
+		flag := Flag{Name: "beta", Enabled: true, Rules: []Rule{
+			{Attribute: "country", Values: []string{"LV", "EE"}},
+			{Attribute: "plan", Values: []string{"pro"}},
+		}}
+
+		assert.True(t, flag.Evaluate(Subject{Attributes: map[string]string{"country": "LV", "plan": "pro"}}), "Expected a matching subject")
+		assert.False(t, flag.Evaluate(Subject{Attributes: map[string]string{"country": "LV", "plan": "free"}}), "Expected a rule mismatch")
+		assert.False(t, flag.Evaluate(Subject{Attributes: map[string]string{"country": "LV"}}), "Expected a missing attribute to mismatch")
+	})
+
+	// Percentage ensures the rollout covers roughly the requested share of subjects, stably.
+	t.Run("Percentage", func(t *testing.T) {
+		flag := Flag{Name: "beta", Enabled: true, Percentage: common.Ptr(25.0)}
+
+		enabled := 0
+		for i := 0; i < 10000; i++ {
+			subject := Subject{Key: fmt.Sprintf("user-%d", i)}
+			if flag.Evaluate(subject) {
+				enabled++
+			}
+			assert.Equal(t, flag.Evaluate(subject), flag.Evaluate(subject), "Expected a stable result for the subject")
+		}
+
+		assert.InDelta(t, 2500, enabled, 200, "Expected about a quarter of the subjects")
+	})
+
+	// PercentageBounds ensures an unset percentage covers every subject and a zero percentage none.
+	t.Run("PercentageBounds", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			percentage *float64
+			expected   bool
+		}{
+			{name: "Unset", percentage: nil, expected: true},
+			{name: "Zero", percentage: common.Ptr(0.0), expected: false},
+			{name: "Full", percentage: common.Ptr(100.0), expected: true},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				flag := Flag{Name: "rollout", Enabled: true, Percentage: tt.percentage}
+				for i := 0; i < 100; i++ {
+					subject := Subject{Key: fmt.Sprintf("user-%d", i)}
+					assert.Equal(t, tt.expected, flag.Evaluate(subject), "Test case %s failed", tt.name)
+				}
+			})
+		}
+	})
+}
+
+// TestStore verifies loading, overriding, watching and evaluating flags.
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	// Override ensures later sources override flags of earlier ones.
+	t.Run("Override", func(t *testing.T) {
+		store := NewStore(
+			StaticSource{{Name: "a", Enabled: true}, {Name: "b", Enabled: true}},
+			StaticSource{{Name: "b"}},
+		)
+		assert.NoError(t, store.Load(context.Background()), "Expected no error")
+
+		assert.True(t, store.Enabled("a", Subject{}), "Expected flag a to be on")
+		assert.False(t, store.Enabled("b", Subject{}), "Expected flag b to be overridden")
+		assert.False(t, store.Enabled("missing", Subject{}), "Expected an undefined flag to be off")
+	})
+
+	// FailedLoad ensures the previous definitions are kept when a source fails.
+	t.Run("FailedLoad", func(t *testing.T) {
+		source := &switchingSource{flags: []Flag{{Name: "a", Enabled: true}}}
+		store := NewStore(source)
+		assert.NoError(t, store.Load(context.Background()), "Expected no error")
+
+		source.err = errors.New("source unavailable")
+		assert.Error(t, store.Load(context.Background()), "Expected the source error")
+		assert.True(t, store.Enabled("a", Subject{}), "Expected the previous definition to be kept")
+
+	})
+
+	// Watch ensures changed and removed flags are delivered to watchers.
+	t.Run("Watch", func(t *testing.T) {
+		source := &switchingSource{flags: []Flag{{Name: "a", Enabled: true}, {Name: "b", Enabled: true}}}
+		store := NewStore(source)
+		assert.NoError(t, store.Load(context.Background()), "Expected no error")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		changes := store.Watch(ctx)
+
+		source.flags = []Flag{{Name: "a", Enabled: true, Percentage: common.Ptr(50.0)}}
+		assert.NoError(t, store.Load(context.Background()), "Expected no error")
+
+		received := map[string]Flag{}
+		for len(received) < 2 {
+			flag := <-changes
+			received[flag.Name] = flag
+		}
+		assert.Equal(t, common.Ptr(50.0), received["a"].Percentage, "Expected the changed flag")
+		assert.False(t, received["b"].Enabled, "Expected the removed flag to be disabled")
+
+		cancel()
+		for range changes {
+		}
+	})
+
+	// Poll ensures flags are reloaded periodically until the context is done.
+	t.Run("Poll", func(t *testing.T) {
+		store := NewStore(StaticSource{{Name: "a", Enabled: true}})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		err := store.Poll(ctx, 10*time.Millisecond, nil)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the context error")
+		assert.True(t, store.Enabled("a", Subject{}), "Expected the flags to be reloaded")
+	})
+}
+
+// TestFileSource verifies that flags are decoded from a JSON file.
+func TestFileSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "flags.json")
+	content := `[{"name": "beta", "enabled": true, "percentage": 10, "rules": [{"attribute": "plan", "values": ["pro"]}]}]`
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600), "Expected the file to be written")
+
+	flags, err := FileSource{Path: path}.Load(context.Background())
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, []Flag{{Name: "beta", Enabled: true, Percentage: common.Ptr(10.0), Rules: []Rule{{Attribute: "plan", Values: []string{"pro"}}}}}, flags)
+
+	_, err = FileSource{Path: filepath.Join(t.TempDir(), "missing.json")}.Load(context.Background())
+	assert.ErrorIs(t, err, os.ErrNotExist, "Expected an error for a missing file")
+}
+
+// TestEnvSource verifies that flags are parsed from prefixed environment variables.
+func TestEnvSource(t *testing.T) {
+	t.Setenv("TESTFLAG_NEW_UI", "true")
+	t.Setenv("TESTFLAG_OLD_UI", "false")
+	t.Setenv("TESTFLAG_ROLLOUT", "30%")
+	t.Setenv("TESTFLAG_ROLLED_BACK", "0%")
+
+	flags, err := EnvSource{Prefix: "TESTFLAG_"}.Load(context.Background())
+	assert.NoError(t, err, "Expected no error")
+	assert.ElementsMatch(t, []Flag{
+		{Name: "new_ui", Enabled: true},
+		{Name: "old_ui"},
+		{Name: "rollout", Enabled: true, Percentage: common.Ptr(30.0)},
+		{Name: "rolled_back", Enabled: true, Percentage: common.Ptr(0.0)},
+	}, flags)
+
+	t.Setenv("TESTFLAG_BROKEN", "sometimes")
+	_, err = EnvSource{Prefix: "TESTFLAG_"}.Load(context.Background())
+	assert.ErrorContains(t, err, "TESTFLAG_BROKEN", "Expected the invalid variable in the error")
+}
@@ -0,0 +1,88 @@
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// StaticSource is a Source returning a fixed list of flags, useful for defaults and tests.
+type StaticSource []Flag
+
+// Load returns the flags of the source.
+func (s StaticSource) Load(context.Context) ([]Flag, error) {
+	return s, nil
+}
+
+// FileSource is a Source reading a JSON array of flags from a configuration file.
+// The file is read again on every Load, so changes are picked up by Store.Poll.
+type FileSource struct {
+	// Path is the path of the JSON file.
+	Path string
+}
+
+// Load reads and decodes the flags from the file.
+func (s FileSource) Load(context.Context) ([]Flag, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("flags: %w", err)
+	}
+
+	var flags []Flag
+	if err = json.Unmarshal(data, &flags); err != nil {
+		return nil, fmt.Errorf("flags: decode %s: %w", s.Path, err)
+	}
+
+	return flags, nil
+}
+
+// EnvSource is a Source reading flags from environment variables starting with Prefix.
+// The rest of the variable name, lower-cased, is the flag name: with the prefix "FLAG_",
+// FLAG_NEW_UI=true defines the flag "new_ui". A value is either a boolean, which enables or disables
+// the flag, or a number between 0 and 100 optionally followed by "%", which enables the flag
+// for that percentage of subjects. Environment flags cannot define rules.
+type EnvSource struct {
+	// Prefix selects the environment variables defining flags.
+	Prefix string
+}
+
+// Load parses the flags from the environment.
+func (s EnvSource) Load(context.Context) ([]Flag, error) {
+	var flags []Flag
+
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+
+		name, ok := strings.CutPrefix(key, s.Prefix)
+		if !ok || name == "" {
+			continue
+		}
+
+		flag, err := parseEnvFlag(strings.ToLower(name), value)
+		if err != nil {
+			return nil, fmt.Errorf("flags: %s: %w", key, err)
+		}
+		flags = append(flags, flag)
+	}
+
+	return flags, nil
+}
+
+// parseEnvFlag parses the value of an environment variable into a flag.
+func parseEnvFlag(name, value string) (Flag, error) {
+	value = strings.TrimSpace(value)
+
+	if enabled, err := strconv.ParseBool(value); err == nil {
+		return Flag{Name: name, Enabled: enabled}, nil
+	}
+
+	percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+	if err != nil || percentage < 0 || percentage > 100 {
+		return Flag{}, fmt.Errorf("invalid value %q, expected a boolean or a percentage", value)
+	}
+
+	return Flag{Name: name, Enabled: true, Percentage: &percentage}, nil
+}
@@ -0,0 +1,213 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"runtime/debug"
+)
+
+// Ptr returns a pointer to a copy of the given value.
+// It is useful for filling optional pointer fields with literals, which cannot be addressed directly.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Coalesce returns the first of the given values that is not the zero value of its type.
+// If all values are zero, or no values are given, the zero value is returned.
+func Coalesce[T comparable](values ...T) T {
+	var zero T
+
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+
+	return zero
+}
+
+// Must returns v if err is nil and panics with err otherwise.
+// It is meant for initialization code where an error means a programming mistake,
+// for example parsing a constant URL: var endpoint = common.Must(url.Parse("https://example.com")).
+func Must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// Try calls fn and converts a panic raised by it into an error, which is returned.
+// A panic with an error value is returned as that error, any other value is wrapped into an error
+// describing it. If fn returns normally, Try returns nil.
+func Try(fn func()) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = recoveredError(rec)
+		}
+	}()
+
+	fn()
+
+	return nil
+}
+
+// Ignore explicitly discards err. It marks places where an error is deliberately not handled,
+// such as closing a read-only file, so they are distinguishable from forgotten error checks.
+func Ignore(_ error) {}
+
+// SafeGo runs fn in a new goroutine and recovers from any panic raised by it.
+// The recovered panic is passed to onPanic as an error; a panic with a non-error value
+// is wrapped into an error describing that value. A nil onPanic silently drops the panic.
+// If a crash handler is installed, a crash report is written for the panic as well.
+func SafeGo(fn func(), onPanic func(err error)) {
+	go func() {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			// The goroutine survives the panic, but it is still worth a report.
+			if _, err := WriteCrashReport(rec, debug.Stack()); err != nil {
+				fmt.Fprintf(os.Stderr, "common: write crash report: %v\n", err)
+			}
+
+			if onPanic != nil {
+				onPanic(recoveredError(rec))
+			}
+		}()
+
+		fn()
+	}()
+}
+
+// recoveredError converts a recovered panic value into an error.
+// Error values are kept as they are, so that callers can inspect them with errors.Is and errors.As.
+func recoveredError(rec any) error {
+	if err := GetRecoverError(rec); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("panic: %v", rec)
+}
+
+// DeepClone returns a deep copy of v. Pointers, slices, maps, arrays, interfaces and exported struct fields
+// are copied recursively, so the clone shares no mutable memory reachable through them with the original.
+// Shared and cyclic pointers, maps and slices are preserved in the clone. Unexported struct fields, channels and functions
+// cannot be copied through reflection and are copied shallowly.
+func DeepClone[T any](v T) T {
+	value := reflect.ValueOf(&v).Elem()
+
+	// Copy into a new variable of type T, which also works when T is an interface type.
+	clone := reflect.New(value.Type())
+	deepCopy(clone.Elem(), value, make(map[visitedPointer]reflect.Value))
+
+	return *clone.Interface().(*T)
+}
+
+// visitedPointer identifies a pointer, map or slice copied by DeepClone. The type is part of the key because
+// a pointer to a struct and a pointer to its first field share the same address, and the length because
+// slices of different lengths can start at the same element.
+type visitedPointer struct {
+	addr   uintptr
+	typ    reflect.Type
+	length int
+}
+
+// deepCopy recursively copies src into dst, which must be a settable value of the same type.
+// The visited map holds the clones of already copied pointers, maps and slices, keyed by their address,
+// type and, for slices, length.
+func deepCopy(dst, src reflect.Value, visited map[visitedPointer]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+
+		// Reuse the clone of a pointer seen before, which keeps shared and cyclic references intact.
+		key := visitedPointer{addr: src.Pointer(), typ: src.Type()}
+		if clone, ok := visited[key]; ok {
+			dst.Set(clone)
+			return
+		}
+
+		clone := reflect.New(src.Type().Elem())
+		visited[key] = clone
+		deepCopy(clone.Elem(), src.Elem(), visited)
+		dst.Set(clone)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		// Reuse the clone of a slice seen before, registering a new clone before copying the elements,
+		// so a slice containing itself, for example through an interface, does not recurse forever.
+		key := visitedPointer{addr: src.Pointer(), typ: src.Type(), length: src.Len()}
+		if clone, ok := visited[key]; ok {
+			dst.Set(clone)
+			return
+		}
+
+		clone := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		visited[key] = clone
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(clone.Index(i), src.Index(i), visited)
+		}
+		dst.Set(clone)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopy(dst.Index(i), src.Index(i), visited)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		// Like slices, maps can contain themselves through interface values.
+		visitedKey := visitedPointer{addr: src.Pointer(), typ: src.Type()}
+		if clone, ok := visited[visitedKey]; ok {
+			dst.Set(clone)
+			return
+		}
+
+		clone := reflect.MakeMapWithSize(src.Type(), src.Len())
+		visited[visitedKey] = clone
+		iter := src.MapRange()
+		for iter.Next() {
+			key := reflect.New(src.Type().Key()).Elem()
+			deepCopy(key, iter.Key(), visited)
+			elem := reflect.New(src.Type().Elem()).Elem()
+			deepCopy(elem, iter.Value(), visited)
+			clone.SetMapIndex(key, elem)
+		}
+		dst.Set(clone)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		// Clone the dynamic value held by the interface.
+		clone := reflect.New(src.Elem().Type()).Elem()
+		deepCopy(clone, src.Elem(), visited)
+		dst.Set(clone)
+
+	case reflect.Struct:
+		// Copy the whole struct first, so unexported fields keep their (shallow) values.
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).IsExported() {
+				deepCopy(dst.Field(i), src.Field(i), visited)
+			}
+		}
+
+	default:
+		// Basic kinds are copied by value, channels and functions are shared.
+		dst.Set(src)
+	}
+}
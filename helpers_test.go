@@ -0,0 +1,197 @@
+package common
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPtr verifies that Ptr returns a pointer to a copy of the given value.
+func TestPtr(t *testing.T) {
+	t.Parallel()
+
+	value := 42
+	ptr := Ptr(value)
+
+	assert.Equal(t, 42, *ptr, "Expected the pointer to hold the value")
+
+	// Changing the pointed-to value must not affect the original variable.
+	*ptr = 7
+	assert.Equal(t, 42, value, "Expected Ptr to point to a copy")
+}
+
+// TestCoalesce verifies that Coalesce returns the first non-zero value.
+func TestCoalesce(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "b", Coalesce("", "b", "c"), "Expected the first non-empty string")
+	assert.Equal(t, 3, Coalesce(0, 0, 3), "Expected the first non-zero integer")
+	assert.Equal(t, "", Coalesce("", ""), "Expected the zero value when all values are zero")
+	assert.Equal(t, 0, Coalesce[int](), "Expected the zero value without arguments")
+}
+
+// TestMust verifies that Must returns the value on success and panics with the error on failure.
+func TestMust(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 1, Must(1, nil), "Expected the value to be returned")
+
+	sample := errors.New("sample error")
+	assert.PanicsWithError(t, sample.Error(), func() { Must(0, sample) }, "Expected Must to panic with the error")
+}
+
+// TestTry verifies that Try converts panics into errors.
+func TestTry(t *testing.T) {
+	t.Parallel()
+
+	sample := errors.New("sample error")
+
+	assert.NoError(t, Try(func() {}), "Expected no error without a panic")
+	assert.ErrorIs(t, Try(func() { panic(sample) }), sample, "Expected the original error")
+	assert.EqualError(t, Try(func() { panic(42) }), "panic: 42", "Expected the panic value in the error")
+	assert.ErrorIs(t, Try(func() { Must(0, sample) }), sample, "Expected Try to recover from Must")
+}
+
+// TestSafeGo verifies that SafeGo recovers panics raised in the started goroutine.
+func TestSafeGo(t *testing.T) {
+	t.Parallel()
+
+	// ErrorPanic ensures a panic with an error value is reported as that error.
+	t.Run("ErrorPanic", func(t *testing.T) {
+		sample := errors.New("sample error")
+		recovered := make(chan error, 1)
+
+		SafeGo(func() { panic(sample) }, func(err error) { recovered <- err })
+
+		select {
+		case err := <-recovered:
+			assert.ErrorIs(t, err, sample, "Expected the original error")
+		case <-time.After(time.Second):
+			t.Fatal("Expected the panic to be reported")
+		}
+	})
+
+	// ValuePanic ensures a panic with a non-error value is wrapped into an error.
+	t.Run("ValuePanic", func(t *testing.T) {
+		recovered := make(chan error, 1)
+
+		SafeGo(func() { panic("boom") }, func(err error) { recovered <- err })
+
+		select {
+		case err := <-recovered:
+			assert.EqualError(t, err, "panic: boom", "Expected the panic value in the error")
+		case <-time.After(time.Second):
+			t.Fatal("Expected the panic to be reported")
+		}
+	})
+
+	// NoPanic ensures the handler is not called when fn returns normally.
+	t.Run("NoPanic", func(t *testing.T) {
+		done := make(chan struct{})
+		called := make(chan error, 1)
+
+		SafeGo(func() { close(done) }, func(err error) { called <- err })
+
+		<-done
+		select {
+		case <-called:
+			t.Fatal("Expected the handler not to be called")
+		case <-time.After(20 * time.Millisecond):
+		}
+	})
+}
+
+// TestDeepClone verifies that DeepClone copies values without sharing mutable memory.
+func TestDeepClone(t *testing.T) {
+	t.Parallel()
+
+	type node struct {
+		Name     string
+		Tags     []string
+		Attrs    map[string]*int
+		Next     *node
+		Any      any
+		internal int
+	}
+
+	// Nested ensures slices, maps, pointers and interfaces are copied recursively.
+	t.Run("Nested", func(t *testing.T) {
+		original := &node{
+			Name:     "root",
+			Tags:     []string{"a", "b"},
+			Attrs:    map[string]*int{"x": Ptr(1)},
+			Next:     &node{Name: "child"},
+			Any:      []int{1, 2},
+			internal: 5,
+		}
+
+		clone := DeepClone(original)
+		assert.Equal(t, original, clone, "Expected the clone to equal the original")
+
+		// Mutating the clone must leave the original untouched.
+		clone.Tags[0] = "changed"
+		*clone.Attrs["x"] = 100
+		clone.Next.Name = "changed"
+		clone.Any.([]int)[0] = 100
+
+		assert.Equal(t, "a", original.Tags[0], "Expected the slice to be copied")
+		assert.Equal(t, 1, *original.Attrs["x"], "Expected the map values to be copied")
+		assert.Equal(t, "child", original.Next.Name, "Expected the pointer to be copied")
+		assert.Equal(t, 1, original.Any.([]int)[0], "Expected the interface value to be copied")
+		assert.Equal(t, 5, clone.internal, "Expected unexported fields to be copied shallowly")
+	})
+
+	// Cycle ensures cyclic references are preserved instead of recursing forever.
+	t.Run("Cycle", func(t *testing.T) {
+		original := &node{Name: "loop"}
+		original.Next = original
+
+		clone := DeepClone(original)
+
+		assert.NotSame(t, original, clone, "Expected a new node")
+		assert.Same(t, clone, clone.Next, "Expected the cycle to point to the clone")
+	})
+
+	// CyclicMap ensures a map containing itself is cloned instead of recursing forever.
+	t.Run("CyclicMap", func(t *testing.T) {
+		original := map[string]any{"name": "loop"}
+		original["self"] = original
+
+		clone := DeepClone(original)
+		clone["name"] = "clone"
+
+		assert.Equal(t, "loop", original["name"], "Expected the original to stay unchanged")
+		assert.Equal(t, "clone", clone["self"].(map[string]any)["name"], "Expected the cycle to point to the clone")
+	})
+
+	// CyclicSlice ensures a slice containing itself is cloned instead of recursing forever.
+	t.Run("CyclicSlice", func(t *testing.T) {
+		original := []any{"loop", nil}
+		original[1] = original
+
+		clone := DeepClone(original)
+		clone[0] = "clone"
+
+		assert.Equal(t, "loop", original[0], "Expected the original to stay unchanged")
+		assert.Equal(t, "clone", clone[1].([]any)[0], "Expected the cycle to point to the clone")
+	})
+
+	// SubSlices ensures slices sharing an array but differing in length are cloned with their own lengths.
+	t.Run("SubSlices", func(t *testing.T) {
+		backing := []int{1, 2, 3}
+		original := [][]int{backing[:1], backing[:3]}
+
+		clone := DeepClone(original)
+
+		assert.Equal(t, original, clone, "Expected equal slices")
+	})
+
+	// Nil ensures nil values are cloned as nil.
+	t.Run("Nil", func(t *testing.T) {
+		assert.Nil(t, DeepClone[*node](nil), "Expected a nil pointer")
+		assert.Nil(t, DeepClone[[]int](nil), "Expected a nil slice")
+		assert.Nil(t, DeepClone[any](nil), "Expected a nil interface")
+	})
+}
@@ -0,0 +1,195 @@
+package http
+
+import (
+	"context"
+	"errors"
+	stdhttp "net/http"
+	"sync"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+)
+
+// DefaultAsyncWorkers is the number of workers of an AsyncInvoker when Workers is not set.
+const DefaultAsyncWorkers = 4
+
+// DefaultAsyncQueueSize is the number of calls an AsyncInvoker queues when QueueSize is not set.
+const DefaultAsyncQueueSize = 128
+
+var (
+	// ErrQueueFull is passed to the callback of a call rejected because the queue of the AsyncInvoker is full.
+	ErrQueueFull = errors.New("http: async queue full")
+	// ErrInvokerClosed is passed to the callback of a call made after the AsyncInvoker was closed.
+	ErrInvokerClosed = errors.New("http: async invoker closed")
+)
+
+// RejectionPolicy decides which call is discarded when the queue of an AsyncInvoker is full.
+type RejectionPolicy int
+
+const (
+	// RejectNewest discards the call being made, keeping the queued ones.
+	RejectNewest RejectionPolicy = iota
+	// RejectOldest discards the longest queued call to make room for the new one, favoring fresh data.
+	RejectOldest
+)
+
+// AsyncInvokerOptions configures an AsyncInvoker.
+type AsyncInvokerOptions struct {
+	// Workers is the number of calls sent concurrently. When zero, DefaultAsyncWorkers is used.
+	Workers int
+	// QueueSize is the number of calls waiting for a worker. When zero, DefaultAsyncQueueSize is used.
+	QueueSize int
+	// Policy decides which call is discarded when the queue is full.
+	Policy RejectionPolicy
+	// Timeout limits every call, including reading the body in the callback. Zero means no limit.
+	Timeout time.Duration
+	// OnPanic, if set, receives the panics of callbacks as errors. A panicking callback neither stops its
+	// worker nor crashes the program; without OnPanic, the panic is dropped.
+	OnPanic func(err error)
+}
+
+// asyncCall is a queued call.
+type asyncCall struct {
+	ctx      context.Context
+	req      *stdhttp.Request
+	callback func(*stdhttp.Response, error)
+}
+
+// AsyncInvoker sends requests in the background with a fixed number of workers and a bounded queue,
+// for low-priority calls such as telemetry that must never block the caller. When the queue is full,
+// a call is discarded according to the rejection policy instead of waiting. AsyncInvoker is safe for
+// concurrent use.
+type AsyncInvoker struct {
+	// client sends the requests.
+	client *stdhttp.Client
+	// opts holds the configuration, with defaults applied.
+	opts AsyncInvokerOptions
+	// queue holds the calls waiting for a worker.
+	queue chan asyncCall
+	// wg tracks the running workers.
+	wg sync.WaitGroup
+	// mu guards closed and sending to the queue.
+	mu sync.Mutex
+	// closed reports whether Close has been called.
+	closed bool
+}
+
+// NewAsyncInvoker creates an AsyncInvoker sending the requests with client and starts its workers.
+// When client is nil, http.DefaultClient is used. Close must be called to stop the workers.
+func NewAsyncInvoker(client *stdhttp.Client, opts AsyncInvokerOptions) *AsyncInvoker {
+	if client == nil {
+		client = stdhttp.DefaultClient
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultAsyncWorkers
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultAsyncQueueSize
+	}
+
+	a := &AsyncInvoker{client: client, opts: opts, queue: make(chan asyncCall, opts.QueueSize)}
+	a.wg.Add(opts.Workers)
+	for range opts.Workers {
+		go a.work()
+	}
+
+	return a
+}
+
+// InvokeAsync queues req and returns immediately. The callback, which may be nil, is called exactly once
+// from a worker with the response or the error; the response body is closed after the callback returns.
+// The call keeps the values of ctx, such as metadata, but not its cancellation, so it outlives the request
+// that triggered it. A rejected call has its callback called with ErrQueueFull or ErrInvokerClosed;
+// a call rejected by InvokeAsync itself has it called before InvokeAsync returns.
+func (a *AsyncInvoker) InvokeAsync(ctx context.Context, req *stdhttp.Request, callback func(*stdhttp.Response, error)) {
+	call := asyncCall{ctx: context.WithoutCancel(ctx), req: req, callback: callback}
+
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		call.finish(nil, ErrInvokerClosed, a.opts.OnPanic)
+		return
+	}
+
+	var rejected *asyncCall
+	select {
+	case a.queue <- call:
+	default:
+		rejected = &call
+		if a.opts.Policy == RejectOldest {
+			// Make room by discarding the oldest call, unless a worker took it meanwhile.
+			select {
+			case oldest := <-a.queue:
+				rejected = &oldest
+			default:
+			}
+			// Only InvokeAsync sends while holding the mutex, so there is room now.
+			a.queue <- call
+		}
+	}
+	a.mu.Unlock()
+
+	if rejected != nil {
+		rejected.finish(nil, ErrQueueFull, a.opts.OnPanic)
+	}
+}
+
+// Close stops accepting calls, waits until the queued calls have been sent and their callbacks returned,
+// and stops the workers. If ctx is done first, Close returns its error while the remaining calls still
+// complete in the background. Calling Close more than once only waits again.
+func (a *AsyncInvoker) Close(ctx context.Context) error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		a.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work sends the queued calls until the queue is closed.
+func (a *AsyncInvoker) work() {
+	defer a.wg.Done()
+
+	for call := range a.queue {
+		a.send(call)
+	}
+}
+
+// send performs a single call and reports the result to its callback.
+func (a *AsyncInvoker) send(call asyncCall) {
+	ctx := call.ctx
+	if a.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.opts.Timeout)
+		defer cancel()
+	}
+
+	resp, err := a.client.Do(call.req.WithContext(ctx))
+	call.finish(resp, err, a.opts.OnPanic)
+}
+
+// finish calls the callback and closes the response body. A panic of the callback is recovered
+// and passed to onPanic, if set.
+func (c asyncCall) finish(resp *stdhttp.Response, err error, onPanic func(error)) {
+	if c.callback != nil {
+		if panicErr := common.Try(func() { c.callback(resp, err) }); panicErr != nil && onPanic != nil {
+			onPanic(panicErr)
+		}
+	}
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAsyncInvoker verifies background calls, rejection policies and closing.
+func TestAsyncInvoker(t *testing.T) {
+	t.Parallel()
+
+	// Delivery ensures every call reaches the server and its callback sees the response.
+	t.Run("Delivery", func(t *testing.T) {
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			_, _ = io.WriteString(w, r.URL.Path)
+		}))
+		defer server.Close()
+
+		invoker := NewAsyncInvoker(server.Client(), AsyncInvokerOptions{Workers: 2})
+
+		var mu sync.Mutex
+		var bodies []string
+		for _, path := range []string{"/a", "/b", "/c"} {
+			// A canceled context must not stop the call, which outlives the triggering request.
+			ctx, cancel := context.WithCancel(context.Background())
+			req, _ := stdhttp.NewRequest(stdhttp.MethodPost, server.URL+path, nil)
+			invoker.InvokeAsync(ctx, req, func(resp *stdhttp.Response, err error) {
+				assert.NoError(t, err, "Expected no error")
+				data, _ := io.ReadAll(resp.Body)
+
+				mu.Lock()
+				defer mu.Unlock()
+				bodies = append(bodies, string(data))
+			})
+			cancel()
+		}
+
+		assert.NoError(t, invoker.Close(context.Background()), "Expected Close to succeed")
+		assert.ElementsMatch(t, []string{"/a", "/b", "/c"}, bodies, "Expected every call to complete")
+	})
+
+	// Rejection ensures full queues discard calls according to the policy without blocking.
+	t.Run("Rejection", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			policy   RejectionPolicy
+			rejected string
+		}{
+			{name: "Newest", policy: RejectNewest, rejected: "/third"},
+			{name: "Oldest", policy: RejectOldest, rejected: "/second"},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				started := make(chan struct{}, 3)
+				release := make(chan struct{})
+				server := httptest.NewServer(stdhttp.HandlerFunc(func(_ stdhttp.ResponseWriter, _ *stdhttp.Request) {
+					started <- struct{}{}
+					<-release
+				}))
+				defer server.Close()
+
+				invoker := NewAsyncInvoker(server.Client(), AsyncInvokerOptions{Workers: 1, QueueSize: 1, Policy: tt.policy})
+
+				var mu sync.Mutex
+				results := map[string]error{}
+				invoke := func(path string) {
+					req, _ := stdhttp.NewRequest(stdhttp.MethodPost, server.URL+path, nil)
+					invoker.InvokeAsync(context.Background(), req, func(_ *stdhttp.Response, err error) {
+						mu.Lock()
+						defer mu.Unlock()
+						results[path] = err
+					})
+				}
+
+				// The first call occupies the worker, the second fills the queue.
+				invoke("/first")
+				<-started
+				invoke("/second")
+				invoke("/third")
+
+				close(release)
+				assert.NoError(t, invoker.Close(context.Background()), "Expected Close to succeed")
+
+				assert.Len(t, results, 3, "Expected every callback to be called")
+				for path, err := range results {
+					if path == tt.rejected {
+						assert.ErrorIs(t, err, ErrQueueFull, "Expected %s to be rejected", path)
+					} else {
+						assert.NoError(t, err, "Expected %s to be sent", path)
+					}
+				}
+			})
+		}
+	})
+
+	// Closed ensures calls after Close are rejected.
+	t.Run("Closed", func(t *testing.T) {
+		invoker := NewAsyncInvoker(nil, AsyncInvokerOptions{})
+		assert.NoError(t, invoker.Close(context.Background()), "Expected Close to succeed")
+
+		var got error
+		req, _ := stdhttp.NewRequest(stdhttp.MethodGet, "http://localhost/", nil)
+		invoker.InvokeAsync(context.Background(), req, func(_ *stdhttp.Response, err error) { got = err })
+
+		assert.ErrorIs(t, got, ErrInvokerClosed, "Expected the call to be rejected")
+	})
+
+	// Panic ensures a panicking callback is reported and the worker keeps serving calls.
+	t.Run("Panic", func(t *testing.T) {
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {}))
+		defer server.Close()
+
+		var panics []error
+		invoker := NewAsyncInvoker(server.Client(), AsyncInvokerOptions{
+			Workers: 1,
+			OnPanic: func(err error) { panics = append(panics, err) },
+		})
+
+		completed := false
+		req, _ := stdhttp.NewRequest(stdhttp.MethodGet, server.URL, nil)
+		invoker.InvokeAsync(context.Background(), req, func(*stdhttp.Response, error) { panic("boom") })
+		invoker.InvokeAsync(context.Background(), req.Clone(context.Background()), func(_ *stdhttp.Response, err error) {
+			assert.NoError(t, err, "Expected no error")
+			completed = true
+		})
+
+		assert.NoError(t, invoker.Close(context.Background()), "Expected Close to succeed")
+		assert.True(t, completed, "Expected the worker to survive the panic")
+		if assert.Len(t, panics, 1, "Expected the panic to be reported") {
+			assert.ErrorContains(t, panics[0], "boom", "Unexpected panic error")
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	stdhttp "net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/metrics"
+)
+
+// ErrNoHealthyEndpoints is returned by a Balancer whose resolver returned no endpoints.
+var ErrNoHealthyEndpoints = errors.New("http: no endpoints to balance over")
+
+// DefaultResolveInterval is how long a Balancer uses the resolved endpoints before resolving them again.
+const DefaultResolveInterval = 30 * time.Second
+
+// errorLatency is the latency recorded for a failed request, so failing endpoints are avoided
+// by the LowestLatency strategy even when they fail fast.
+const errorLatency = time.Second
+
+// Resolver returns the endpoints of a service, as base URLs such as "http://10.0.0.1:8080".
+type Resolver interface {
+	Resolve(ctx context.Context) ([]string, error)
+}
+
+// StaticResolver is a Resolver returning a fixed list of endpoints.
+type StaticResolver []string
+
+// Resolve implements Resolver.
+func (r StaticResolver) Resolve(context.Context) ([]string, error) {
+	return r, nil
+}
+
+// ResolverFunc adapts a function to the Resolver interface.
+type ResolverFunc func(ctx context.Context) ([]string, error)
+
+// Resolve implements Resolver.
+func (f ResolverFunc) Resolve(ctx context.Context) ([]string, error) {
+	return f(ctx)
+}
+
+// SRVResolver is a Resolver looking up the endpoints in DNS SRV records, such as _http._tcp.api.example.com.
+type SRVResolver struct {
+	// Service, Proto and Name form the queried name, as described by net.LookupSRV.
+	Service, Proto, Name string
+	// Scheme is the scheme of the endpoints. When empty, "http" is used.
+	Scheme string
+	// Resolver performs the lookup. When nil, net.DefaultResolver is used.
+	Resolver *net.Resolver
+}
+
+// Resolve implements Resolver.
+func (r SRVResolver) Resolve(ctx context.Context) ([]string, error) {
+	resolver := r.Resolver
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	scheme := r.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	_, records, err := resolver.LookupSRV(ctx, r.Service, r.Proto, r.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		endpoints = append(endpoints, scheme+"://"+net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+
+	return endpoints, nil
+}
+
+// Strategy selects the endpoint of a request.
+type Strategy int
+
+const (
+	// RoundRobin uses the endpoints in turn.
+	RoundRobin Strategy = iota
+	// LeastPending uses the endpoint with the fewest requests in flight.
+	LeastPending
+	// LowestLatency uses the endpoint with the lowest moving average of the latency, weighted by the
+	// requests in flight, which quickly moves traffic away from slow or failing replicas.
+	LowestLatency
+)
+
+// endpointStats tracks the load of an endpoint.
+type endpointStats struct {
+	// pending is the number of requests in flight.
+	pending int
+	// latency is the moving average of the latency in seconds.
+	latency *metrics.EWMA
+}
+
+// Balancer spreads requests over the endpoints returned by a Resolver, following a Strategy.
+// The endpoints are resolved again after the resolve interval, keeping the statistics of endpoints
+// that remain. Balancer is safe for concurrent use.
+type Balancer struct {
+	// resolver returns the endpoints.
+	resolver Resolver
+	// strategy selects the endpoint of a request.
+	strategy Strategy
+	// interval is how long the resolved endpoints are used.
+	interval time.Duration
+	// mu guards the fields below.
+	mu sync.Mutex
+	// endpoints are the current endpoints in the order of the resolver.
+	endpoints []string
+	// stats holds the statistics of the current endpoints.
+	stats map[string]*endpointStats
+	// resolved is the time of the last resolution.
+	resolved time.Time
+	// next is the position of the next endpoint for RoundRobin.
+	next int
+}
+
+// NewBalancer creates a Balancer over the endpoints of resolver. A non-positive interval uses DefaultResolveInterval.
+func NewBalancer(resolver Resolver, strategy Strategy, interval time.Duration) *Balancer {
+	if interval <= 0 {
+		interval = DefaultResolveInterval
+	}
+
+	return &Balancer{resolver: resolver, strategy: strategy, interval: interval, stats: make(map[string]*endpointStats)}
+}
+
+// Pick selects the endpoint for a request. The returned done function must be called once the request
+// is complete, with its error, to keep the statistics of the endpoint current.
+func (b *Balancer) Pick(ctx context.Context) (string, func(err error), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.refresh(ctx); err != nil {
+		return "", nil, err
+	}
+	if len(b.endpoints) == 0 {
+		return "", nil, ErrNoHealthyEndpoints
+	}
+
+	endpoint := b.choose()
+	stats := b.stats[endpoint]
+	stats.pending++
+
+	start := time.Now()
+	var once sync.Once
+	done := func(err error) {
+		once.Do(func() {
+			latency := time.Since(start)
+			if err != nil {
+				latency = max(latency, errorLatency)
+			}
+			stats.latency.Add(latency.Seconds())
+
+			b.mu.Lock()
+			stats.pending--
+			b.mu.Unlock()
+		})
+	}
+
+	return endpoint, done, nil
+}
+
+// choose returns the endpoint selected by the strategy. The caller must hold the mutex.
+func (b *Balancer) choose() string {
+	switch b.strategy {
+	case LeastPending:
+		best := b.endpoints[b.next%len(b.endpoints)]
+		// Start at the round-robin position, so ties are spread over the endpoints.
+		for i := range b.endpoints {
+			candidate := b.endpoints[(b.next+i)%len(b.endpoints)]
+			if b.stats[candidate].pending < b.stats[best].pending {
+				best = candidate
+			}
+		}
+		b.next++
+		return best
+
+	case LowestLatency:
+		best, bestScore := "", 0.0
+		for i := range b.endpoints {
+			candidate := b.endpoints[(b.next+i)%len(b.endpoints)]
+			stats := b.stats[candidate]
+			score := stats.latency.Value() * float64(stats.pending+1)
+			if best == "" || score < bestScore {
+				best, bestScore = candidate, score
+			}
+		}
+		b.next++
+		return best
+	}
+
+	endpoint := b.endpoints[b.next%len(b.endpoints)]
+	b.next++
+
+	return endpoint
+}
+
+// refresh resolves the endpoints if the interval has passed. The caller must hold the mutex.
+// A failed resolution keeps the previous endpoints, if there are any.
+func (b *Balancer) refresh(ctx context.Context) error {
+	if !b.resolved.IsZero() && time.Since(b.resolved) < b.interval {
+		return nil
+	}
+
+	endpoints, err := b.resolver.Resolve(ctx)
+	if err != nil {
+		if len(b.endpoints) > 0 {
+			return nil
+		}
+		return fmt.Errorf("http: resolve endpoints: %w", err)
+	}
+
+	stats := make(map[string]*endpointStats, len(endpoints))
+	for _, endpoint := range endpoints {
+		if existing, ok := b.stats[endpoint]; ok {
+			stats[endpoint] = existing
+		} else {
+			stats[endpoint] = &endpointStats{latency: metrics.NewEWMA(0.3)}
+		}
+	}
+
+	b.endpoints = endpoints
+	b.stats = stats
+	b.resolved = time.Now()
+
+	return nil
+}
+
+// BalancedTransport is an http.RoundTripper sending every request to an endpoint picked by a Balancer.
+// The scheme, host and path prefix of the endpoint replace those of the request, as with InvokeWithFallback.
+type BalancedTransport struct {
+	// Base performs the requests. When nil, http.DefaultTransport is used.
+	Base stdhttp.RoundTripper
+	// Balancer picks the endpoint of every request.
+	Balancer *Balancer
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *BalancedTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = stdhttp.DefaultTransport
+	}
+
+	endpoint, done, err := t.Balancer.Pick(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := rebase(req.URL, endpoint)
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+
+	// A RoundTripper must not modify the request of its caller.
+	req = req.Clone(req.Context())
+	req.URL = target
+	req.Host = ""
+
+	resp, err := base.RoundTrip(req)
+	if err == nil && resp.StatusCode >= stdhttp.StatusInternalServerError {
+		done(errors.New(resp.Status))
+	} else {
+		done(err)
+	}
+
+	return resp, err
+}
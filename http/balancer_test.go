@@ -0,0 +1,123 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBalancer verifies the endpoint selection of the strategies.
+func TestBalancer(t *testing.T) {
+	t.Parallel()
+
+	endpoints := StaticResolver{"http://a", "http://b", "http://c"}
+
+	// RoundRobin ensures endpoints are used in turn.
+	t.Run("RoundRobin", func(t *testing.T) {
+		balancer := NewBalancer(endpoints, RoundRobin, 0)
+
+		var picked []string
+		for range 4 {
+			endpoint, done, err := balancer.Pick(context.Background())
+			assert.NoError(t, err, "Expected no error")
+			done(nil)
+			picked = append(picked, endpoint)
+		}
+
+		assert.Equal(t, []string{"http://a", "http://b", "http://c", "http://a"}, picked, "Expected the endpoints in turn")
+	})
+
+	// LeastPending ensures busy endpoints are avoided.
+	t.Run("LeastPending", func(t *testing.T) {
+		balancer := NewBalancer(endpoints, LeastPending, 0)
+
+		first, _, _ := balancer.Pick(context.Background())
+		second, _, _ := balancer.Pick(context.Background())
+		third, doneThird, _ := balancer.Pick(context.Background())
+		assert.ElementsMatch(t, []string{"http://a", "http://b", "http://c"}, []string{first, second, third}, "Expected every endpoint once")
+
+		doneThird(nil)
+		next, _, _ := balancer.Pick(context.Background())
+		assert.Equal(t, third, next, "Expected the idle endpoint")
+	})
+
+	// LowestLatency ensures failing endpoints are avoided.
+	t.Run("LowestLatency", func(t *testing.T) {
+		balancer := NewBalancer(StaticResolver{"http://a", "http://b"}, LowestLatency, 0)
+
+		for range 2 {
+			endpoint, done, _ := balancer.Pick(context.Background())
+			if endpoint == "http://a" {
+				done(errors.New("connection refused"))
+			} else {
+				done(nil)
+			}
+		}
+
+		for range 3 {
+			endpoint, done, _ := balancer.Pick(context.Background())
+			done(nil)
+			assert.Equal(t, "http://b", endpoint, "Expected the fast endpoint")
+		}
+	})
+
+	// ResolveErrors ensures resolution errors are reported only without known endpoints.
+	t.Run("ResolveErrors", func(t *testing.T) {
+		fail := false
+		resolver := ResolverFunc(func(context.Context) ([]string, error) {
+			if fail {
+				return nil, errors.New("dns down")
+			}
+			return []string{"http://a"}, nil
+		})
+
+		balancer := NewBalancer(resolver, RoundRobin, time.Nanosecond)
+		_, _, err := balancer.Pick(context.Background())
+		assert.NoError(t, err, "Expected no error")
+
+		fail = true
+		endpoint, _, err := balancer.Pick(context.Background())
+		assert.NoError(t, err, "Expected the previous endpoints to be kept")
+		assert.Equal(t, "http://a", endpoint, "Unexpected endpoint")
+
+		_, _, err = NewBalancer(resolver, RoundRobin, 0).Pick(context.Background())
+		assert.Error(t, err, "Expected the resolution error")
+
+		_, _, err = NewBalancer(StaticResolver{}, RoundRobin, 0).Pick(context.Background())
+		assert.ErrorIs(t, err, ErrNoHealthyEndpoints, "Expected an error without endpoints")
+	})
+}
+
+// TestBalancedTransport verifies that requests are spread over the endpoints.
+func TestBalancedTransport(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(name string) *httptest.Server {
+		return httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			_, _ = io.WriteString(w, name+" "+r.URL.Path)
+		}))
+	}
+	first, second := newServer("first"), newServer("second")
+	defer first.Close()
+	defer second.Close()
+
+	balancer := NewBalancer(StaticResolver{first.URL, second.URL}, RoundRobin, 0)
+	client := &stdhttp.Client{Transport: &BalancedTransport{Balancer: balancer}}
+
+	var bodies []string
+	for range 2 {
+		resp, err := client.Get("http://service/status")
+		assert.NoError(t, err, "Expected no error")
+		data, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		bodies = append(bodies, string(data))
+	}
+
+	assert.Equal(t, []string{"first /status", "second /status"}, bodies, "Expected both replicas to be used")
+}
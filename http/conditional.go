@@ -0,0 +1,240 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	stdhttp "net/http"
+	"strings"
+	"sync"
+
+	"github.com/SyntaxErrorLineNULL/common/container"
+)
+
+// DefaultConditionalEntries is the number of responses a ConditionalTransport keeps when MaxEntries is not set.
+const DefaultConditionalEntries = 256
+
+// DefaultConditionalBodySize is the largest body a ConditionalTransport keeps when MaxBodySize is not set.
+const DefaultConditionalBodySize = 1 << 20
+
+// ConditionalTransport is an http.RoundTripper that revalidates cached GET responses with conditional
+// requests. Responses carrying an ETag or Last-Modified header are kept; later requests for the same URL
+// send If-None-Match or If-Modified-Since, and a 304 Not Modified answer is turned into a copy of the
+// cached response, so callers always see the full body while the server only sends it when it changed.
+// Requests that already carry conditional headers are passed through unchanged, and so are requests carrying
+// credentials in an Authorization or Cookie header, since their responses may differ between users.
+// Responses marked with Cache-Control no-store or private, or with Vary: *, are not kept. A cached response
+// with a Vary header is only revalidated for requests having the same values of the listed headers.
+// ConditionalTransport is safe for concurrent use.
+type ConditionalTransport struct {
+	// Base performs the requests. When nil, http.DefaultTransport is used.
+	Base stdhttp.RoundTripper
+	// MaxEntries limits the number of cached responses, evicting the least recently used.
+	// When zero, DefaultConditionalEntries is used.
+	MaxEntries int
+	// MaxBodySize is the largest body that is cached; larger responses are passed through.
+	// When zero, DefaultConditionalBodySize is used.
+	MaxBodySize int64
+
+	// cache holds the responses by URL, created on first use.
+	cache *container.LRU[string, *cachedResponse]
+	// once guards the creation of the cache.
+	once sync.Once
+}
+
+// cachedResponse is a response kept for revalidation.
+type cachedResponse struct {
+	status int
+	header stdhttp.Header
+	body   []byte
+	// vary holds the values of the request headers listed in the Vary header of the response.
+	vary map[string]string
+}
+
+// NewConditionalTransport creates a ConditionalTransport performing the requests with base.
+func NewConditionalTransport(base stdhttp.RoundTripper) *ConditionalTransport {
+	return &ConditionalTransport{Base: base}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ConditionalTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = stdhttp.DefaultTransport
+	}
+
+	if req.Method != stdhttp.MethodGet || req.Header.Get("If-None-Match") != "" || req.Header.Get("If-Modified-Since") != "" ||
+		req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != "" {
+		return base.RoundTrip(req)
+	}
+
+	cache := t.entries()
+	key := req.URL.String()
+
+	// Revalidate the cached response, if there is one for the same variant. The request is cloned,
+	// as a RoundTripper must not modify the request of its caller.
+	cached, ok := cache.Get(key)
+	ok = ok && cached.matches(req)
+	if ok {
+		req = req.Clone(req.Context())
+		if etag := cached.header.Get("ETag"); etag != "" {
+			SetIfNoneMatch(req, etag)
+		}
+		if lastModified := cached.header.Get("Last-Modified"); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == stdhttp.StatusNotModified && ok {
+		// The body of a 304 response is empty, but must still be closed to reuse the connection.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		return cached.response(req, resp), nil
+	}
+
+	if !storable(resp) {
+		// The resource changed into something that cannot or must not be revalidated.
+		if ok {
+			cache.Remove(key)
+		}
+		return resp, nil
+	}
+
+	return t.store(cache, key, req, resp)
+}
+
+// storable reports whether resp can be revalidated and may be kept by a shared cache.
+func storable(resp *stdhttp.Response) bool {
+	if resp.StatusCode != stdhttp.StatusOK || (resp.Header.Get("ETag") == "" && resp.Header.Get("Last-Modified") == "") {
+		return false
+	}
+
+	for _, value := range resp.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			name, _, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if strings.EqualFold(name, "no-store") || strings.EqualFold(name, "private") {
+				return false
+			}
+		}
+	}
+
+	// A response varying on something other than request headers cannot be matched to later requests.
+	for _, field := range varyFields(resp.Header) {
+		if field == "*" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// varyFields returns the header names listed in the Vary header, in canonical form.
+func varyFields(header stdhttp.Header) []string {
+	var fields []string
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				fields = append(fields, stdhttp.CanonicalHeaderKey(field))
+			}
+		}
+	}
+
+	return fields
+}
+
+// store reads the body of resp and caches the response to req if the body is small enough.
+// The returned response has a body holding the same data.
+func (t *ConditionalTransport) store(
+	cache *container.LRU[string, *cachedResponse],
+	key string,
+	req *stdhttp.Request,
+	resp *stdhttp.Response,
+) (*stdhttp.Response, error) {
+	limit := t.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultConditionalBodySize
+	}
+
+	// Read one byte more than the limit to detect larger bodies.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		// Hand the caller the bytes read so far followed by the rest of the body.
+		resp.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(body), resp.Body), Closer: resp.Body}
+		cache.Remove(key)
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	// Remember the request headers the response varies on, so other variants are not revalidated against it.
+	vary := make(map[string]string)
+	for _, field := range varyFields(resp.Header) {
+		vary[field] = strings.Join(req.Header.Values(field), ",")
+	}
+
+	cache.Set(key, &cachedResponse{status: resp.StatusCode, header: resp.Header.Clone(), body: body, vary: vary})
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	return resp, nil
+}
+
+// entries returns the cache, creating it on first use.
+func (t *ConditionalTransport) entries() *container.LRU[string, *cachedResponse] {
+	t.once.Do(func() {
+		capacity := t.MaxEntries
+		if capacity <= 0 {
+			capacity = DefaultConditionalEntries
+		}
+		t.cache = container.NewLRU[string, *cachedResponse](capacity, nil)
+	})
+
+	return t.cache
+}
+
+// matches reports whether req asks for the same variant as the request of the cached response.
+func (c *cachedResponse) matches(req *stdhttp.Request) bool {
+	for field, value := range c.vary {
+		if strings.Join(req.Header.Values(field), ",") != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// response builds the response for req from the cached one, with the headers of the 304 response,
+// which may carry updated metadata such as Date or Cache-Control, taking precedence.
+func (c *cachedResponse) response(req *stdhttp.Request, notModified *stdhttp.Response) *stdhttp.Response {
+	header := c.header.Clone()
+	for name, values := range notModified.Header {
+		header[name] = values
+	}
+
+	return &stdhttp.Response{
+		Status:        fmt.Sprintf("%d %s", c.status, stdhttp.StatusText(c.status)),
+		StatusCode:    c.status,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
+
+// readCloser combines a reader with the closer of another stream.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConditionalTransport verifies that unchanged resources are served from the cache after revalidation.
+func TestConditionalTransport(t *testing.T) {
+	t.Parallel()
+
+	var (
+		body        atomic.Value
+		fullBodies  atomic.Int32
+		notModified atomic.Int32
+	)
+	body.Store("version 1")
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		current := body.Load().(string)
+		etag := ETag([]byte(current), false)
+
+		w.Header().Set("ETag", etag)
+		if ETagMatches(r.Header.Get("If-None-Match"), etag) {
+			notModified.Add(1)
+			w.WriteHeader(stdhttp.StatusNotModified)
+			return
+		}
+
+		fullBodies.Add(1)
+		_, _ = io.WriteString(w, current)
+	}))
+	defer server.Close()
+
+	client := &stdhttp.Client{Transport: NewConditionalTransport(nil)}
+	get := func() (int, string) {
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err, "Expected no error")
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(resp.Body)
+		assert.NoError(t, err, "Expected no error")
+
+		return resp.StatusCode, string(data)
+	}
+
+	status, data := get()
+	assert.Equal(t, stdhttp.StatusOK, status, "Unexpected status")
+	assert.Equal(t, "version 1", data, "Unexpected body")
+
+	status, data = get()
+	assert.Equal(t, stdhttp.StatusOK, status, "Expected the 304 to be turned into the cached response")
+	assert.Equal(t, "version 1", data, "Expected the cached body")
+	assert.Equal(t, int32(1), notModified.Load(), "Expected the request to be revalidated")
+
+	body.Store("version 2")
+	_, data = get()
+	assert.Equal(t, "version 2", data, "Expected the changed body")
+	assert.Equal(t, int32(2), fullBodies.Load(), "Expected two full responses")
+}
+
+// TestConditionalTransportLargeBody verifies that bodies above the limit are passed through intact.
+func TestConditionalTransportLargeBody(t *testing.T) {
+	t.Parallel()
+
+	payload := strings.Repeat("x", 100)
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		assert.Empty(t, r.Header.Get("If-None-Match"), "Expected large responses not to be cached")
+		w.Header().Set("ETag", `"large"`)
+		_, _ = io.WriteString(w, payload)
+	}))
+	defer server.Close()
+
+	client := &stdhttp.Client{Transport: &ConditionalTransport{MaxBodySize: 10}}
+	for range 2 {
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err, "Expected no error")
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, payload, string(data), "Expected the complete body")
+	}
+}
+
+// TestConditionalTransportBypass verifies that responses which may differ between users or must not
+// be stored are not revalidated from the cache.
+func TestConditionalTransportBypass(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		// header is set on the response of the server.
+		header map[string]string
+		// first and second are the request headers of the two requests.
+		first, second map[string]string
+		// revalidated reports whether the second request is expected to be conditional.
+		revalidated bool
+	}{
+		{name: "Cacheable", revalidated: true},
+		{name: "Authorization", first: map[string]string{"Authorization": "Bearer a"}, second: map[string]string{"Authorization": "Bearer b"}},
+		{name: "Cookie", first: map[string]string{"Cookie": "session=a"}, second: map[string]string{"Cookie": "session=a"}},
+		{name: "No store", header: map[string]string{"Cache-Control": "no-store"}},
+		{name: "Private", header: map[string]string{"Cache-Control": "max-age=60, private"}},
+		{name: "Vary all", header: map[string]string{"Vary": "*"}},
+		{
+			name:   "Vary other variant",
+			header: map[string]string{"Vary": "Accept-Language"},
+			first:  map[string]string{"Accept-Language": "en"},
+			second: map[string]string{"Accept-Language": "de"},
+		},
+		{
+			name:        "Vary same variant",
+			header:      map[string]string{"Vary": "accept-language"},
+			first:       map[string]string{"Accept-Language": "en"},
+			second:      map[string]string{"Accept-Language": "en"},
+			revalidated: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditional atomic.Int32
+			server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+				if r.Header.Get("If-None-Match") != "" {
+					conditional.Add(1)
+				}
+				for name, value := range tt.header {
+					w.Header().Set(name, value)
+				}
+				w.Header().Set("ETag", `"v1"`)
+				_, _ = io.WriteString(w, "body")
+			}))
+			defer server.Close()
+
+			client := &stdhttp.Client{Transport: NewConditionalTransport(nil)}
+			for _, header := range []map[string]string{tt.first, tt.second} {
+				req, err := stdhttp.NewRequest(stdhttp.MethodGet, server.URL, nil)
+				assert.NoError(t, err, "Expected no error")
+				for name, value := range header {
+					req.Header.Set(name, value)
+				}
+
+				resp, err := client.Do(req)
+				assert.NoError(t, err, "Expected no error")
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+			}
+
+			assert.Equal(t, tt.revalidated, conditional.Load() == 1, "Test case %s failed", tt.name)
+		})
+	}
+}
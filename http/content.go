@@ -0,0 +1,104 @@
+package http
+
+import (
+	"io"
+	"mime"
+	stdhttp "net/http"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultContentType is the content type of data whose type cannot be determined.
+const DefaultContentType = "application/octet-stream"
+
+// sniffLen is the number of bytes considered by the content sniffing algorithm.
+const sniffLen = 512
+
+// preferredExtensions maps content types to the extension used for files of that type, where the
+// system MIME tables list several extensions and the first of them in sorted order is a poor choice.
+var preferredExtensions = map[string]string{
+	"application/gzip":         ".gz",
+	"application/json":         ".json",
+	"application/octet-stream": ".bin",
+	"application/pdf":          ".pdf",
+	"application/xml":          ".xml",
+	"application/zip":          ".zip",
+	"image/gif":                ".gif",
+	"image/jpeg":               ".jpg",
+	"image/png":                ".png",
+	"image/svg+xml":            ".svg",
+	"image/webp":               ".webp",
+	"text/css":                 ".css",
+	"text/csv":                 ".csv",
+	"text/html":                ".html",
+	"text/javascript":          ".js",
+	"text/plain":               ".txt",
+	"text/xml":                 ".xml",
+}
+
+// DetectContentType determines the content type of the data in r using the algorithm of
+// net/http.DetectContentType, which looks at the first 512 bytes. The position of r is restored
+// afterwards, so the data can still be read or uploaded in full. It always returns a valid content type,
+// falling back to DefaultContentType.
+func DetectContentType(r io.ReadSeeker) (string, error) {
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	if _, err = r.Seek(start, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return stdhttp.DetectContentType(buf[:n]), nil
+}
+
+// ExtensionByType returns the file extension, including the leading dot, for the content type,
+// for example ".json" for "application/json; charset=utf-8". Parameters of the content type are ignored.
+// It returns an empty string if the type is unknown.
+func ExtensionByType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	if ext, ok := preferredExtensions[mediaType]; ok {
+		return ext
+	}
+
+	extensions, err := mime.ExtensionsByType(mediaType)
+	if err != nil || len(extensions) == 0 {
+		return ""
+	}
+
+	return extensions[0]
+}
+
+// TypeByExtension returns the content type for the file extension, given with or without the leading dot.
+// It returns DefaultContentType if the extension is unknown.
+func TypeByExtension(ext string) string {
+	if ext == "" {
+		return DefaultContentType
+	}
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	if contentType := mime.TypeByExtension(strings.ToLower(ext)); contentType != "" {
+		return contentType
+	}
+
+	return DefaultContentType
+}
+
+// TypeByFilename returns the content type for the extension of the file name, or DefaultContentType
+// if the name has no known extension.
+func TypeByFilename(name string) string {
+	return TypeByExtension(filepath.Ext(name))
+}
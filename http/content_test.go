@@ -0,0 +1,67 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDetectContentType verifies that the content type is sniffed without consuming the data.
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for common file formats.
+	cases := []struct {
+		name     string
+		data     []byte
+		expected string
+	}{
+		{name: "PNG", data: []byte("\x89PNG\r\n\x1a\nrest of the image"), expected: "image/png"},
+		{name: "HTML", data: []byte("<!DOCTYPE html><html></html>"), expected: "text/html; charset=utf-8"},
+		{name: "Plain text", data: []byte("hello"), expected: "text/plain; charset=utf-8"},
+		{name: "Empty", data: nil, expected: "text/plain; charset=utf-8"},
+		{name: "Binary", data: []byte{0x00, 0x01, 0x02}, expected: DefaultContentType},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bytes.NewReader(tt.data)
+
+			contentType, err := DetectContentType(reader)
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, contentType, "Test case %s failed", tt.name)
+
+			rest, _ := io.ReadAll(reader)
+			assert.Equal(t, len(tt.data), len(rest), "Expected the data not to be consumed in test case %s", tt.name)
+		})
+	}
+
+	// The position is restored rather than reset to the start.
+	reader := strings.NewReader("skip<html>")
+	_, _ = reader.Seek(4, io.SeekStart)
+	contentType, err := DetectContentType(reader)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "text/html; charset=utf-8", contentType, "Expected sniffing from the current position")
+	position, _ := reader.Seek(0, io.SeekCurrent)
+	assert.Equal(t, int64(4), position, "Expected the position to be restored")
+}
+
+// TestExtensionMapping verifies the mapping between content types and file extensions.
+func TestExtensionMapping(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ".json", ExtensionByType("application/json; charset=utf-8"), "Expected parameters to be ignored")
+	assert.Equal(t, ".jpg", ExtensionByType("image/jpeg"), "Expected the preferred extension")
+	assert.Equal(t, ".txt", ExtensionByType("text/plain; charset=utf-8"), "Expected the preferred extension")
+	assert.Equal(t, "", ExtensionByType("application/x-unknown-type"), "Expected no extension for an unknown type")
+	assert.Equal(t, "", ExtensionByType(";;"), "Expected no extension for an invalid type")
+
+	assert.Equal(t, "image/png", TypeByExtension(".png"), "Expected the type of the extension")
+	assert.Equal(t, "image/png", TypeByExtension("PNG"), "Expected the dot and the case to be optional")
+	assert.Equal(t, DefaultContentType, TypeByExtension(".unknownext"), "Expected the default type")
+	assert.Equal(t, "application/pdf", TypeByFilename("report.final.pdf"), "Expected the type of the file name")
+	assert.Equal(t, DefaultContentType, TypeByFilename("README"), "Expected the default type without an extension")
+}
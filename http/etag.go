@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/hex"
+	stdhttp "net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/crypto"
+)
+
+// etagLen is the number of hexadecimal digits of the digest used in an ETag.
+// 128 bits are plenty to tell versions of a resource apart and keep the header short.
+const etagLen = 32
+
+// ETag returns an entity tag for data, derived from its SHA-256 digest, such as "\"3a6eb079...\"".
+// A weak tag, prefixed with W/, tells caches that equal tags only mean semantically equivalent content,
+// for example when the same data may be served with different compression.
+func ETag(data []byte, weak bool) string {
+	return formatETag(crypto.SHA256.SumHex(data)[:etagLen], weak)
+}
+
+// FileETag returns an entity tag for the contents of the file at path, reading it in a streaming fashion.
+func FileETag(path string, weak bool) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	digest, err := crypto.SHA256.SumReader(file)
+	if err != nil {
+		return "", err
+	}
+
+	return formatETag(hex.EncodeToString(digest)[:etagLen], weak), nil
+}
+
+// ETagMatches reports whether the If-None-Match header value matches the entity tag, using the weak
+// comparison required for If-None-Match: the W/ prefix is ignored on both sides. The header may list
+// several tags separated by commas or be "*", which matches any tag.
+func ETagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return etag != ""
+	}
+
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetIfNoneMatch makes req conditional on the resource no longer having the entity tag, so the server
+// answers with 304 Not Modified if the cached version is still current.
+func SetIfNoneMatch(req *stdhttp.Request, etag string) {
+	req.Header.Set("If-None-Match", etag)
+}
+
+// SetIfModifiedSince makes req conditional on the resource having changed after t,
+// so the server answers with 304 Not Modified otherwise.
+func SetIfModifiedSince(req *stdhttp.Request, t time.Time) {
+	req.Header.Set("If-Modified-Since", t.UTC().Format(stdhttp.TimeFormat))
+}
+
+// formatETag quotes the opaque tag and marks it as weak if requested.
+func formatETag(tag string, weak bool) string {
+	if weak {
+		return `W/"` + tag + `"`
+	}
+
+	return `"` + tag + `"`
+}
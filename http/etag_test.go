@@ -0,0 +1,61 @@
+package http
+
+import (
+	stdhttp "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestETag verifies the format of strong and weak entity tags.
+func TestETag(t *testing.T) {
+	t.Parallel()
+
+	strong := ETag([]byte("content"), false)
+	weak := ETag([]byte("content"), true)
+
+	assert.Len(t, strong, etagLen+2, "Expected a quoted tag")
+	assert.True(t, strings.HasPrefix(strong, `"`) && strings.HasSuffix(strong, `"`), "Expected a quoted tag")
+	assert.Equal(t, "W/"+strong, weak, "Expected the weak tag to differ only by the prefix")
+	assert.NotEqual(t, strong, ETag([]byte("other"), false), "Expected different content to have a different tag")
+
+	path := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(path, []byte("content"), 0o600), "Expected the file to be written")
+
+	fileTag, err := FileETag(path, false)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, strong, fileTag, "Expected the file tag to match the tag of its contents")
+
+	_, err = FileETag(filepath.Join(t.TempDir(), "missing"), false)
+	assert.Error(t, err, "Expected an error for a missing file")
+}
+
+// TestETagMatches verifies the weak comparison of If-None-Match.
+func TestETagMatches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, ETagMatches(`"abc"`, `"abc"`), "Expected equal tags to match")
+	assert.True(t, ETagMatches(`W/"abc"`, `"abc"`), "Expected the weak prefix to be ignored")
+	assert.True(t, ETagMatches(`"x", W/"abc"`, `W/"abc"`), "Expected a tag in a list to match")
+	assert.True(t, ETagMatches("*", `"abc"`), "Expected the wildcard to match")
+	assert.False(t, ETagMatches(`"x"`, `"abc"`), "Expected different tags not to match")
+	assert.False(t, ETagMatches("", `"abc"`), "Expected an empty header not to match")
+}
+
+// TestConditionalHeaders verifies the headers set on conditional requests.
+func TestConditionalHeaders(t *testing.T) {
+	t.Parallel()
+
+	req, err := stdhttp.NewRequest(stdhttp.MethodGet, "http://example.com", nil)
+	assert.NoError(t, err, "Expected no error")
+
+	SetIfNoneMatch(req, `"abc"`)
+	SetIfModifiedSince(req, time.Date(2024, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+
+	assert.Equal(t, `"abc"`, req.Header.Get("If-None-Match"), "Unexpected If-None-Match")
+	assert.Equal(t, "Wed, 01 May 2024 10:00:00 GMT", req.Header.Get("If-Modified-Since"), "Expected the time in GMT")
+}
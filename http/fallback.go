@@ -0,0 +1,88 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdhttp "net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrNoEndpoints is returned by InvokeWithFallback when no endpoint is given.
+var ErrNoEndpoints = errors.New("http: no endpoints")
+
+// InvokeWithFallback sends req to each of the endpoints in order until one of them answers, for services
+// deployed in several regions. An endpoint is a base URL such as "https://eu.example.com/api"; its scheme,
+// host and path prefix replace those of req, while the path and query of req are kept. Only failures to
+// get a response, such as refused connections or DNS errors, move on to the next endpoint: any HTTP
+// response, including an error status, is returned as is. Requests with a body are only sent to more than
+// one endpoint if the body can be replayed through Request.GetBody. If all endpoints fail, the errors
+// of every attempt are returned joined together.
+func InvokeWithFallback(ctx context.Context, client *stdhttp.Client, req *stdhttp.Request, endpoints []string) (*stdhttp.Response, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	if client == nil {
+		client = stdhttp.DefaultClient
+	}
+
+	replayable := req.Body == nil || req.Body == stdhttp.NoBody || req.GetBody != nil
+
+	var errs []error
+	for i, endpoint := range endpoints {
+		target, err := rebase(req.URL, endpoint)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		attempt := req.Clone(ctx)
+		attempt.URL = target
+		// Let the transport derive the Host header from the new URL.
+		attempt.Host = ""
+		if i > 0 && req.GetBody != nil {
+			if attempt.Body, err = req.GetBody(); err != nil {
+				return nil, errors.Join(append(errs, err)...)
+			}
+		}
+
+		resp, err := client.Do(attempt)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", endpoint, err))
+
+		// Stop when the caller gave up or the body cannot be sent again.
+		if ctx.Err() != nil || !replayable {
+			break
+		}
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+// rebase returns u with the scheme, user information and host of the endpoint,
+// and its path prefixed with the path of the endpoint.
+func rebase(u *url.URL, endpoint string) (*url.URL, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("http: invalid endpoint %q: %w", endpoint, err)
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("http: invalid endpoint %q: scheme and host required", endpoint)
+	}
+
+	target := *u
+	target.Scheme = base.Scheme
+	target.Host = base.Host
+	target.User = base.User
+
+	// Join the paths with exactly one slash between them; the raw path is dropped, as it no longer matches.
+	if prefix := strings.TrimSuffix(base.Path, "/"); prefix != "" {
+		target.Path = prefix + "/" + strings.TrimPrefix(u.Path, "/")
+		target.RawPath = ""
+	}
+
+	return &target, nil
+}
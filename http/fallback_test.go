@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInvokeWithFallback verifies that unreachable endpoints are skipped and the request is rebased.
+func TestInvokeWithFallback(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, r.URL.RequestURI()+" "+string(body))
+	}))
+	defer server.Close()
+
+	// A closed server refuses connections.
+	down := httptest.NewServer(stdhttp.NotFoundHandler())
+	down.Close()
+
+	// Fallback ensures the next endpoint is used after a connection failure, with the body replayed.
+	t.Run("Fallback", func(t *testing.T) {
+		req, _ := stdhttp.NewRequest(stdhttp.MethodPost, "http://placeholder/items?limit=5", strings.NewReader("payload"))
+
+		resp, err := InvokeWithFallback(context.Background(), nil, req, []string{down.URL, server.URL + "/api/"})
+		assert.NoError(t, err, "Expected the second endpoint to answer")
+		defer resp.Body.Close()
+
+		data, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "/api/items?limit=5 payload", string(data), "Expected the rebased URL and the full body")
+	})
+
+	// AllFail ensures the errors of all endpoints are reported.
+	t.Run("AllFail", func(t *testing.T) {
+		req, _ := stdhttp.NewRequest(stdhttp.MethodGet, "http://placeholder/", nil)
+
+		_, err := InvokeWithFallback(context.Background(), nil, req, []string{down.URL, "not a url"})
+		assert.Error(t, err, "Expected an error")
+		assert.Contains(t, err.Error(), down.URL, "Expected the failed endpoint in the error")
+		assert.Contains(t, err.Error(), "invalid endpoint", "Expected the invalid endpoint in the error")
+	})
+
+	// NoEndpoints ensures an empty list is rejected.
+	t.Run("NoEndpoints", func(t *testing.T) {
+		req, _ := stdhttp.NewRequest(stdhttp.MethodGet, "http://placeholder/", nil)
+
+		_, err := InvokeWithFallback(context.Background(), nil, req, nil)
+		assert.ErrorIs(t, err, ErrNoEndpoints, "Expected ErrNoEndpoints")
+	})
+}
@@ -0,0 +1,227 @@
+package headerx
+
+import (
+	"errors"
+	"math"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNoFilename is returned by ParseContentDisposition when the header names no usable file.
+	ErrNoFilename = errors.New("headerx: no filename")
+	// ErrInvalidRange is returned by ParseRange for a malformed Range header.
+	ErrInvalidRange = errors.New("headerx: invalid range")
+	// ErrUnsatisfiableRange is returned by ParseRange when no range overlaps the content,
+	// which a server answers with 416 Range Not Satisfiable.
+	ErrUnsatisfiableRange = errors.New("headerx: unsatisfiable range")
+)
+
+// maxRetryAfterSeconds is the longest delay in seconds that fits into a time.Duration.
+const maxRetryAfterSeconds = math.MaxInt64 / int64(time.Second)
+
+// ParseRetryAfter parses a Retry-After header, given either as a number of seconds or as an HTTP date,
+// and returns how long to wait from now. A date in the past yields zero. It returns false when the value
+// is empty or malformed, so callers fall back to their own backoff. Delays too long for a time.Duration
+// are clamped to the longest one, about 292 years, rather than overflowing to a negative wait.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(min(seconds, maxRetryAfterSeconds)) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// ParseLinkHeader parses a Link header as used for pagination, such as
+// `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=9>; rel="last"`,
+// and returns the URL of every relation type. A link with several relation types, such as rel="next last",
+// is returned under each of them; when a relation type occurs more than once, the first link wins.
+// Links without a relation type and malformed entries are skipped. The URLs are returned as written,
+// so relative references must be resolved against the request URL by the caller.
+func ParseLinkHeader(value string) map[string]string {
+	links := make(map[string]string)
+
+	for rest := value; ; {
+		start := strings.IndexByte(rest, '<')
+		if start < 0 {
+			return links
+		}
+		end := strings.IndexByte(rest[start:], '>')
+		if end < 0 {
+			return links
+		}
+		target := rest[start+1 : start+end]
+		rest = rest[start+end+1:]
+
+		// The parameters of the link extend to the next comma outside of a quoted string.
+		params, next := splitLinkParams(rest)
+		rest = next
+
+		for _, param := range params {
+			name, val, ok := strings.Cut(param, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(name), "rel") {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+				rel = strings.ToLower(rel)
+				if _, exists := links[rel]; !exists {
+					links[rel] = target
+				}
+			}
+		}
+	}
+}
+
+// splitLinkParams splits the semicolon separated parameters following a link target up to the comma
+// ending the link, and returns them together with the text after that comma.
+func splitLinkParams(s string) ([]string, string) {
+	var params []string
+	quoted := false
+	begin := 0
+
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case '\\':
+			if quoted {
+				i++
+			}
+		case ';':
+			if !quoted {
+				params = append(params, s[begin:i])
+				begin = i + 1
+			}
+		case ',':
+			if !quoted {
+				return append(params, s[begin:i]), s[i+1:]
+			}
+		}
+	}
+
+	return append(params, s[begin:]), ""
+}
+
+// ParseContentDisposition returns the file name of a Content-Disposition header such as
+// `attachment; filename="report.pdf"`. The extended filename* parameter of RFC 6266, which carries
+// non-ASCII names, takes precedence over filename. Directory components are removed, so the result can be
+// joined to a download directory without escaping it. ErrNoFilename is returned when no name is given.
+func ParseContentDisposition(value string) (string, error) {
+	// ParseMediaType decodes filename* and returns it as filename.
+	_, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.ReplaceAll(params["filename"], `\`, "/")
+	name = path.Base(path.Clean("/" + name))
+	if name == "/" || name == "." || name == ".." {
+		return "", ErrNoFilename
+	}
+
+	return name, nil
+}
+
+// Range is a byte range of a resource, as requested with a Range header.
+type Range struct {
+	// Start is the offset of the first byte.
+	Start int64
+	// Length is the number of bytes.
+	Length int64
+}
+
+// ContentRange formats the range as the value of a Content-Range header for content of the given size.
+func (r Range) ContentRange(size int64) string {
+	return "bytes " + strconv.FormatInt(r.Start, 10) + "-" + strconv.FormatInt(r.Start+r.Length-1, 10) + "/" + strconv.FormatInt(size, 10)
+}
+
+// ParseRange parses a Range header such as "bytes=0-499,-500" for content of the given size.
+// Ranges reaching past the end are shortened to the content, and ranges starting past the end are dropped.
+// An empty header yields no ranges. ErrInvalidRange is returned for a malformed header and
+// ErrUnsatisfiableRange when none of the ranges overlaps the content.
+func ParseRange(value string, size int64) ([]Range, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	const prefix = "bytes="
+	if !strings.HasPrefix(value, prefix) {
+		return nil, ErrInvalidRange
+	}
+
+	var ranges []Range
+	skipped := false
+	for _, spec := range strings.Split(value[len(prefix):], ",") {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		first, last, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, ErrInvalidRange
+		}
+		first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+		var r Range
+		if first == "" {
+			// A suffix range such as "-500" selects the last bytes.
+			n, err := strconv.ParseInt(last, 10, 64)
+			if err != nil || n < 0 {
+				return nil, ErrInvalidRange
+			}
+			if n == 0 || size == 0 {
+				skipped = true
+				continue
+			}
+			n = min(n, size)
+			r = Range{Start: size - n, Length: n}
+		} else {
+			start, err := strconv.ParseInt(first, 10, 64)
+			if err != nil || start < 0 {
+				return nil, ErrInvalidRange
+			}
+			if start >= size {
+				skipped = true
+				continue
+			}
+
+			end := size - 1
+			if last != "" {
+				if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+					return nil, ErrInvalidRange
+				}
+				end = min(end, size-1)
+			}
+			r = Range{Start: start, Length: end - start + 1}
+		}
+
+		ranges = append(ranges, r)
+	}
+
+	if len(ranges) == 0 {
+		if skipped {
+			return nil, ErrUnsatisfiableRange
+		}
+		return nil, ErrInvalidRange
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,145 @@
+package headerx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseRetryAfter verifies parsing delays given in seconds and as dates.
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "Empty", value: "", ok: false},
+		{name: "Seconds", value: "120", expected: 2 * time.Minute, ok: true},
+		{name: "Zero seconds", value: "0", expected: 0, ok: true},
+		{name: "Negative seconds", value: "-5", ok: false},
+		{name: "Overflowing seconds", value: "99999999999", expected: time.Duration(maxRetryAfterSeconds) * time.Second, ok: true},
+		{name: "Future date", value: "Fri, 01 Mar 2024 12:00:30 GMT", expected: 30 * time.Second, ok: true},
+		{name: "Past date", value: "Fri, 01 Mar 2024 11:00:00 GMT", expected: 0, ok: true},
+		{name: "Garbage", value: "soon", ok: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ParseRetryAfter(tt.value, now)
+
+			assert.Equal(t, tt.ok, ok, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, delay, "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestParseLinkHeader verifies extracting the URLs of the relation types.
+func TestParseLinkHeader(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected map[string]string
+	}{
+		{name: "Empty", value: "", expected: map[string]string{}},
+		{
+			name:  "Pagination",
+			value: `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=9>; rel="last"`,
+			expected: map[string]string{
+				"next": "https://api.example.com/items?page=2",
+				"last": "https://api.example.com/items?page=9",
+			},
+		},
+		{
+			name:     "Several relation types",
+			value:    `</items?page=9>; rel="next last"`,
+			expected: map[string]string{"next": "/items?page=9", "last": "/items?page=9"},
+		},
+		{
+			name:     "Comma in URL and quoted parameter",
+			value:    `</a,b>; title="x, y"; REL=Next, </c>`,
+			expected: map[string]string{"next": "/a,b"},
+		},
+		{name: "Malformed", value: `<https://example.com; rel="next"`, expected: map[string]string{}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseLinkHeader(tt.value), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestParseContentDisposition verifies extracting safe file names.
+func TestParseContentDisposition(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected string
+		err      error
+	}{
+		{name: "Quoted", value: `attachment; filename="report.pdf"`, expected: "report.pdf"},
+		{name: "Extended", value: `attachment; filename="a.txt"; filename*=UTF-8''%C3%BCber.txt`, expected: "über.txt"},
+		{name: "Path traversal", value: `attachment; filename="../../etc/passwd"`, expected: "passwd"},
+		{name: "Windows path", value: `attachment; filename="C:\\temp\\data.csv"`, expected: "data.csv"},
+		{name: "No filename", value: `inline`, err: ErrNoFilename},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			name, err := ParseContentDisposition(tt.value)
+
+			assert.ErrorIs(t, err, tt.err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, name, "Test case %s failed", tt.name)
+		})
+	}
+
+	// Malformed ensures invalid headers are reported.
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := ParseContentDisposition(`attachment; filename="unterminated`)
+		assert.Error(t, err, "Expected an error")
+	})
+}
+
+// TestParseRange verifies parsing byte ranges against the content size.
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		name     string
+		value    string
+		expected []Range
+		err      error
+	}{
+		{name: "Empty", value: "", expected: nil},
+		{name: "Closed", value: "bytes=0-499", expected: []Range{{Start: 0, Length: 500}}},
+		{name: "Open", value: "bytes=900-", expected: []Range{{Start: 900, Length: 100}}},
+		{name: "Suffix", value: "bytes=-100", expected: []Range{{Start: 900, Length: 100}}},
+		{name: "Suffix larger than content", value: "bytes=-5000", expected: []Range{{Start: 0, Length: 1000}}},
+		{name: "End past content", value: "bytes=990-2000", expected: []Range{{Start: 990, Length: 10}}},
+		{
+			name:     "Several ranges",
+			value:    "bytes=0-9, 2000-3000, 20-29",
+			expected: []Range{{Start: 0, Length: 10}, {Start: 20, Length: 10}},
+		},
+		{name: "Unsatisfiable", value: "bytes=1000-", err: ErrUnsatisfiableRange},
+		{name: "Wrong unit", value: "items=0-1", err: ErrInvalidRange},
+		{name: "Reversed", value: "bytes=10-5", err: ErrInvalidRange},
+		{name: "Not a number", value: "bytes=a-5", err: ErrInvalidRange},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := ParseRange(tt.value, 1000)
+
+			assert.ErrorIs(t, err, tt.err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, ranges, "Test case %s failed", tt.name)
+		})
+	}
+
+	// ContentRange ensures ranges format as Content-Range values.
+	t.Run("ContentRange", func(t *testing.T) {
+		assert.Equal(t, "bytes 900-999/1000", Range{Start: 900, Length: 100}.ContentRange(1000), "Unexpected Content-Range")
+	})
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"maps"
+	stdhttp "net/http"
+	"net/textproto"
+)
+
+// Common metadata keys. Keys are header names, so metadata is sent under the key it is stored with.
+const (
+	// RequestIDHeader identifies a request across the services handling it.
+	RequestIDHeader = "X-Request-ID"
+	// TenantHeader names the tenant a request is made for.
+	TenantHeader = "X-Tenant-ID"
+)
+
+// metaKey is the context key under which the metadata is stored.
+type metaKey struct{}
+
+// WithMeta returns a copy of ctx carrying the metadata value under key, in addition to the metadata
+// already in ctx. The key is a header name and is canonicalized, so "x-request-id" and "X-Request-Id"
+// are the same key. The metadata of ctx itself is not modified.
+func WithMeta(ctx context.Context, key, value string) context.Context {
+	current, _ := ctx.Value(metaKey{}).(map[string]string)
+
+	// Copy on write, so contexts derived earlier keep their metadata.
+	meta := make(map[string]string, len(current)+1)
+	maps.Copy(meta, current)
+	meta[textproto.CanonicalMIMEHeaderKey(key)] = value
+
+	return context.WithValue(ctx, metaKey{}, meta)
+}
+
+// Meta returns the metadata value stored under key in ctx and whether it is present.
+func Meta(ctx context.Context, key string) (string, bool) {
+	meta, _ := ctx.Value(metaKey{}).(map[string]string)
+	value, ok := meta[textproto.CanonicalMIMEHeaderKey(key)]
+
+	return value, ok
+}
+
+// MetaFromContext returns a copy of all metadata stored in ctx, keyed by canonical header name.
+// It returns nil when ctx carries no metadata.
+func MetaFromContext(ctx context.Context) map[string]string {
+	meta, _ := ctx.Value(metaKey{}).(map[string]string)
+
+	return maps.Clone(meta)
+}
+
+// MetaTransport is an http.RoundTripper that sends the metadata of the request context as headers,
+// so values such as the request ID follow a request to the services it calls. Headers already set
+// on the request take precedence over the metadata. The request passed in is not modified.
+type MetaTransport struct {
+	// Base performs the requests. When nil, http.DefaultTransport is used.
+	Base stdhttp.RoundTripper
+	// Keys limits the metadata sent to these keys. When empty, all metadata is sent.
+	Keys []string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *MetaTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = stdhttp.DefaultTransport
+	}
+
+	meta := MetaFromContext(req.Context())
+	if len(t.Keys) > 0 {
+		selected := make(map[string]string, len(t.Keys))
+		for _, key := range t.Keys {
+			if value, ok := meta[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+				selected[textproto.CanonicalMIMEHeaderKey(key)] = value
+			}
+		}
+		meta = selected
+	}
+
+	// A RoundTripper must not modify the request, so the headers are set on a clone.
+	var clone *stdhttp.Request
+	for key, value := range meta {
+		if req.Header.Get(key) != "" {
+			continue
+		}
+		if clone == nil {
+			clone = req.Clone(req.Context())
+		}
+		clone.Header.Set(key, value)
+	}
+	if clone != nil {
+		req = clone
+	}
+
+	return base.RoundTrip(req)
+}
+
+// ExtractMeta returns a middleware storing the listed request headers as metadata in the request
+// context, where handlers read them with Meta and MetaTransport forwards them to downstream services.
+// Headers missing from the request are skipped.
+func ExtractMeta(keys ...string) func(stdhttp.Handler) stdhttp.Handler {
+	return func(next stdhttp.Handler) stdhttp.Handler {
+		return stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			ctx := r.Context()
+			for _, key := range keys {
+				if value := r.Header.Get(key); value != "" {
+					ctx = WithMeta(ctx, key, value)
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
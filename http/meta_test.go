@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithMeta verifies storing and reading metadata in a context.
+func TestWithMeta(t *testing.T) {
+	t.Parallel()
+
+	// CanonicalKeys ensures keys are matched regardless of case.
+	t.Run("CanonicalKeys", func(t *testing.T) {
+		ctx := WithMeta(context.Background(), "x-request-id", "abc")
+
+		value, ok := Meta(ctx, RequestIDHeader)
+		assert.True(t, ok, "Expected the value to be present")
+		assert.Equal(t, "abc", value, "Unexpected value")
+	})
+
+	// CopyOnWrite ensures derived contexts do not change their parents.
+	t.Run("CopyOnWrite", func(t *testing.T) {
+		parent := WithMeta(context.Background(), RequestIDHeader, "abc")
+		child := WithMeta(parent, TenantHeader, "acme")
+
+		assert.Equal(t, map[string]string{"X-Request-Id": "abc"}, MetaFromContext(parent), "Expected the parent to stay unchanged")
+		assert.Equal(t, map[string]string{"X-Request-Id": "abc", "X-Tenant-Id": "acme"}, MetaFromContext(child), "Unexpected child metadata")
+	})
+
+	// Missing ensures a context without metadata reports nothing.
+	t.Run("Missing", func(t *testing.T) {
+		_, ok := Meta(context.Background(), RequestIDHeader)
+
+		assert.False(t, ok, "Expected no value")
+		assert.Nil(t, MetaFromContext(context.Background()), "Expected nil metadata")
+	})
+}
+
+// TestMetaPropagation verifies that metadata extracted by the middleware is forwarded by MetaTransport.
+func TestMetaPropagation(t *testing.T) {
+	t.Parallel()
+
+	// The downstream service records the headers it receives.
+	var received stdhttp.Header
+	downstream := httptest.NewServer(stdhttp.HandlerFunc(func(_ stdhttp.ResponseWriter, r *stdhttp.Request) {
+		received = r.Header.Clone()
+	}))
+	defer downstream.Close()
+
+	client := &stdhttp.Client{Transport: &MetaTransport{Keys: []string{RequestIDHeader, TenantHeader}}}
+
+	// The upstream service calls the downstream service with its request context.
+	upstream := httptest.NewServer(ExtractMeta(RequestIDHeader, TenantHeader)(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		ctx := WithMeta(r.Context(), "X-Internal", "not forwarded")
+		req, _ := stdhttp.NewRequestWithContext(ctx, stdhttp.MethodGet, downstream.URL, nil)
+		req.Header.Set(TenantHeader, "explicit")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			w.WriteHeader(stdhttp.StatusBadGateway)
+			return
+		}
+		resp.Body.Close()
+
+		assert.Empty(t, req.Header.Get(RequestIDHeader), "Expected the original request to stay unchanged")
+	})))
+	defer upstream.Close()
+
+	req, _ := stdhttp.NewRequest(stdhttp.MethodGet, upstream.URL, nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	req.Header.Set(TenantHeader, "acme")
+
+	resp, err := stdhttp.DefaultClient.Do(req)
+	assert.NoError(t, err, "Expected no error")
+	resp.Body.Close()
+
+	assert.Equal(t, stdhttp.StatusOK, resp.StatusCode, "Unexpected status")
+	assert.Equal(t, "abc", received.Get(RequestIDHeader), "Expected the request ID to be forwarded")
+	assert.Equal(t, "explicit", received.Get(TenantHeader), "Expected explicit headers to take precedence")
+	assert.Empty(t, received.Get("X-Internal"), "Expected unlisted metadata not to be forwarded")
+}
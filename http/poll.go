@@ -0,0 +1,160 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	stdhttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+	"github.com/SyntaxErrorLineNULL/common/http/headerx"
+)
+
+// DefaultPollInterval is the delay before the first poll of PollUntil when Interval is not set.
+const DefaultPollInterval = time.Second
+
+// DefaultPollMaxInterval is the longest delay between polls of PollUntil when MaxInterval is not set.
+const DefaultPollMaxInterval = 30 * time.Second
+
+// PollOptions configures PollUntil.
+type PollOptions struct {
+	// Client sends the requests. When nil, http.DefaultClient is used.
+	Client *stdhttp.Client
+	// Interval is the delay before the first poll. It doubles after every poll up to MaxInterval.
+	// When zero, DefaultPollInterval is used.
+	Interval time.Duration
+	// MaxInterval is the longest delay between polls, also capping the delay a Retry-After header asks for.
+	// When zero, DefaultPollMaxInterval is used.
+	MaxInterval time.Duration
+	// Timeout is the deadline of the whole operation, including all polls. Zero means no limit besides ctx.
+	Timeout time.Duration
+}
+
+// PollUntil implements the common pattern of APIs creating a job and then reporting its status at a URL.
+// It sends the request made by makeReq and passes every response to isDone, which decides whether the job
+// has finished; the body is buffered, so isDone may read it. While the job is running, PollUntil waits with
+// a growing delay, or as long as a Retry-After header asks for up to MaxInterval, and polls again.
+// When a response carries a Location header, as a 201 Created or 202 Accepted answer to the creation request
+// usually does, the following polls are GET requests to that URL with the headers of the first request;
+// otherwise makeReq is called again for every poll. Like redirects in net/http, credentials such as the
+// Authorization and Cookie headers are only sent along if the URL has the scheme and host of the first request.
+// When isDone reports completion, the body of the final response is decoded as JSON into the result.
+// An error of isDone, of a request or of ctx and the Timeout ends polling and is returned.
+func PollUntil[T any](
+	ctx context.Context,
+	makeReq func(ctx context.Context) (*stdhttp.Request, error),
+	isDone func(*stdhttp.Response) (bool, error),
+	opts PollOptions,
+) (T, error) {
+	var result T
+
+	client := opts.Client
+	if client == nil {
+		client = stdhttp.DefaultClient
+	}
+	delay := opts.Interval
+	if delay <= 0 {
+		delay = DefaultPollInterval
+	}
+	maxDelay := opts.MaxInterval
+	if maxDelay <= 0 {
+		maxDelay = DefaultPollMaxInterval
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	first, err := makeReq(ctx)
+	if err != nil {
+		return result, err
+	}
+
+	// statusURL is the URL to poll once the server announced one.
+	var statusURL *url.URL
+	req := first
+	for {
+		resp, err := client.Do(req)
+		if err != nil {
+			return result, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return result, fmt.Errorf("http: reading poll response: %w", err)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		done, err := isDone(resp)
+		if err != nil {
+			return result, err
+		}
+		if done {
+			if len(bytes.TrimSpace(body)) > 0 {
+				if err := json.Unmarshal(body, &result); err != nil {
+					return result, fmt.Errorf("http: decoding poll result: %w", err)
+				}
+			}
+			return result, nil
+		}
+
+		// Follow the status URL announced by the server.
+		location, err := resp.Location()
+		switch {
+		case err == nil:
+			statusURL = location
+		case !errors.Is(err, stdhttp.ErrNoLocation):
+			return result, fmt.Errorf("http: invalid Location header: %w", err)
+		}
+
+		// The server may tell how long the job still needs.
+		wait := delay
+		if retryAfter, ok := headerx.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			wait = min(retryAfter, maxDelay)
+		}
+		if err := common.SleepCtx(ctx, wait); err != nil {
+			return result, err
+		}
+		delay = min(delay*2, maxDelay)
+
+		if statusURL == nil {
+			req, err = makeReq(ctx)
+		} else {
+			req, err = stdhttp.NewRequestWithContext(ctx, stdhttp.MethodGet, statusURL.String(), nil)
+			if err == nil {
+				req.Header = pollHeader(first, statusURL)
+			}
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+}
+
+// credentialHeaders are the headers net/http drops when following a redirect to another host.
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization", "Www-Authenticate", "Cookie", "Cookie2"}
+
+// pollHeader returns the headers of the first request for polling statusURL, without the credentials
+// unless statusURL has the same scheme and host, so a Location pointing elsewhere cannot obtain them.
+func pollHeader(first *stdhttp.Request, statusURL *url.URL) stdhttp.Header {
+	header := first.Header.Clone()
+	if header == nil {
+		header = make(stdhttp.Header)
+	}
+
+	if !strings.EqualFold(statusURL.Scheme, first.URL.Scheme) || !strings.EqualFold(statusURL.Host, first.URL.Host) {
+		for _, key := range credentialHeaders {
+			header.Del(key)
+		}
+	}
+
+	return header
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// job is the status resource of the test server.
+type job struct {
+	State  string `json:"state"`
+	Result int    `json:"result"`
+}
+
+// jobDone reports whether the job in the response has finished.
+func jobDone(resp *stdhttp.Response) (bool, error) {
+	var status job
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return false, err
+	}
+	if status.State == "failed" {
+		return false, errors.New("job failed")
+	}
+
+	return status.State == "done", nil
+}
+
+// TestPollUntil verifies polling of a job status URL.
+func TestPollUntil(t *testing.T) {
+	t.Parallel()
+
+	// newServer starts a server creating a job that finishes after the given number of polls.
+	newServer := func(polls int32, finalState string) (*httptest.Server, *atomic.Int32) {
+		var count atomic.Int32
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			switch {
+			case r.Method == stdhttp.MethodPost && r.URL.Path == "/jobs":
+				w.Header().Set("Location", "/jobs/1")
+				w.WriteHeader(stdhttp.StatusAccepted)
+				_, _ = io.WriteString(w, `{"state":"queued"}`)
+			case r.Method == stdhttp.MethodGet && r.URL.Path == "/jobs/1" && r.Header.Get("Authorization") == "Bearer token":
+				if count.Add(1) < polls {
+					_, _ = io.WriteString(w, `{"state":"running"}`)
+					return
+				}
+				_, _ = io.WriteString(w, `{"state":"`+finalState+`","result":42}`)
+			default:
+				w.WriteHeader(stdhttp.StatusBadRequest)
+				_, _ = io.WriteString(w, `{"state":"failed"}`)
+			}
+		}))
+
+		return server, &count
+	}
+
+	// makeReq creates the job.
+	makeReq := func(url string) func(ctx context.Context) (*stdhttp.Request, error) {
+		return func(ctx context.Context) (*stdhttp.Request, error) {
+			req, err := stdhttp.NewRequestWithContext(ctx, stdhttp.MethodPost, url+"/jobs", strings.NewReader(`{}`))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Authorization", "Bearer token")
+			return req, nil
+		}
+	}
+
+	// FollowsLocation ensures the status URL is polled until the job is done.
+	t.Run("FollowsLocation", func(t *testing.T) {
+		server, count := newServer(3, "done")
+		defer server.Close()
+
+		result, err := PollUntil[job](context.Background(), makeReq(server.URL), jobDone, PollOptions{Interval: time.Millisecond})
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, job{State: "done", Result: 42}, result, "Unexpected result")
+		assert.Equal(t, int32(3), count.Load(), "Unexpected number of polls")
+	})
+
+	// CrossOriginLocation ensures credentials are not sent to a status URL on another host.
+	t.Run("CrossOriginLocation", func(t *testing.T) {
+		var leaked atomic.Bool
+		status := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			if r.Header.Get("Authorization") != "" || r.Header.Get("Cookie") != "" {
+				leaked.Store(true)
+			}
+			assert.Equal(t, "1", r.Header.Get("X-Trace"), "Expected other headers to be kept")
+			_, _ = io.WriteString(w, `{"state":"done","result":7}`)
+		}))
+		defer status.Close()
+
+		creator := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+			w.Header().Set("Location", status.URL+"/jobs/1")
+			w.WriteHeader(stdhttp.StatusAccepted)
+			_, _ = io.WriteString(w, `{"state":"queued"}`)
+		}))
+		defer creator.Close()
+
+		create := func(ctx context.Context) (*stdhttp.Request, error) {
+			req, err := makeReq(creator.URL)(ctx)
+			if err == nil {
+				req.Header.Set("Cookie", "session=secret")
+				req.Header.Set("X-Trace", "1")
+			}
+			return req, err
+		}
+
+		result, err := PollUntil[job](context.Background(), create, jobDone, PollOptions{Interval: time.Millisecond})
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, 7, result.Result, "Unexpected result")
+		assert.False(t, leaked.Load(), "Expected no credentials to be sent to another host")
+	})
+
+	// RetryAfterCapped ensures a long Retry-After is capped by MaxInterval.
+	t.Run("RetryAfterCapped", func(t *testing.T) {
+		var polls atomic.Int32
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+			if polls.Add(1) < 3 {
+				w.Header().Set("Retry-After", "3600")
+				_, _ = io.WriteString(w, `{"state":"running"}`)
+				return
+			}
+			_, _ = io.WriteString(w, `{"state":"done"}`)
+		}))
+		defer server.Close()
+
+		start := time.Now()
+		_, err := PollUntil[job](context.Background(), makeReq(server.URL), jobDone, PollOptions{
+			Interval:    time.Millisecond,
+			MaxInterval: 10 * time.Millisecond,
+		})
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Less(t, time.Since(start), 5*time.Second, "Expected the Retry-After delay to be capped")
+	})
+
+	// IsDoneError ensures an error of isDone stops polling.
+	t.Run("IsDoneError", func(t *testing.T) {
+		server, _ := newServer(2, "failed")
+		defer server.Close()
+
+		_, err := PollUntil[job](context.Background(), makeReq(server.URL), jobDone, PollOptions{Interval: time.Millisecond})
+
+		assert.EqualError(t, err, "job failed", "Expected the error of isDone")
+	})
+
+	// Timeout ensures polling stops at the overall deadline.
+	t.Run("Timeout", func(t *testing.T) {
+		server, _ := newServer(1<<30, "done")
+		defer server.Close()
+
+		_, err := PollUntil[job](context.Background(), makeReq(server.URL), jobDone, PollOptions{
+			Interval: time.Millisecond,
+			Timeout:  50 * time.Millisecond,
+		})
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the deadline to be exceeded")
+	})
+}
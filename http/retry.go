@@ -0,0 +1,222 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	stdhttp "net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+	"github.com/SyntaxErrorLineNULL/common/http/headerx"
+	"github.com/SyntaxErrorLineNULL/common/metrics"
+)
+
+// RetryBudget limits retries to a fraction of the requests over a sliding window, so that retries stop
+// once most requests fail, as during an outage, instead of multiplying the load on a struggling service.
+// A small number of retries is always allowed, so clients with little traffic can still retry.
+// A budget is typically shared by all requests of a client. RetryBudget is safe for concurrent use.
+type RetryBudget struct {
+	// ratio is the allowed number of retries per request.
+	ratio float64
+	// minRetries is the number of retries allowed within the window regardless of the ratio.
+	minRetries int64
+	// mu serializes the check and the recording of a retry.
+	mu sync.Mutex
+	// requests and retries count the events within the window.
+	requests, retries *metrics.RateEstimator
+	// denied counts the retries denied within the window.
+	denied *metrics.RateEstimator
+	// totalRequests, totalRetries and exhausted count the events since the budget was created.
+	totalRequests, totalRetries, exhausted atomic.Int64
+}
+
+// RetryBudgetStats reports the activity of a RetryBudget since its creation.
+type RetryBudgetStats struct {
+	// Requests is the number of requests recorded.
+	Requests int64
+	// Retries is the number of retries granted.
+	Retries int64
+	// Exhausted is the number of retries denied because the budget was used up.
+	Exhausted int64
+}
+
+// NewRetryBudget creates a RetryBudget allowing ratio retries per request, for example 0.2 for one retry
+// per five requests, plus minRetries retries within every window. A non-positive window falls back to ten seconds.
+func NewRetryBudget(ratio float64, minRetries int, window time.Duration) *RetryBudget {
+	if window <= 0 {
+		window = 10 * time.Second
+	}
+
+	return &RetryBudget{
+		ratio:      max(ratio, 0),
+		minRetries: int64(max(minRetries, 0)),
+		requests:   metrics.NewRateEstimator(window),
+		retries:    metrics.NewRateEstimator(window),
+		denied:     metrics.NewRateEstimator(window),
+	}
+}
+
+// Request records a request, which adds to the budget for retries.
+func (b *RetryBudget) Request() {
+	b.requests.Incr()
+	b.totalRequests.Add(1)
+}
+
+// TryRetry reports whether a retry is allowed, recording it if so.
+// A denied retry is counted as budget exhaustion.
+func (b *RetryBudget) TryRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	allowed := float64(b.minRetries) + b.ratio*float64(b.requests.Count())
+	if float64(b.retries.Count()+1) > allowed {
+		b.exhausted.Add(1)
+		b.denied.Incr()
+		return false
+	}
+
+	b.retries.Incr()
+	b.totalRetries.Add(1)
+
+	return true
+}
+
+// Stats returns the activity of the budget since its creation.
+func (b *RetryBudget) Stats() RetryBudgetStats {
+	return RetryBudgetStats{
+		Requests:  b.totalRequests.Load(),
+		Retries:   b.totalRetries.Load(),
+		Exhausted: b.exhausted.Load(),
+	}
+}
+
+// ExhaustionRate returns the number of retries per second denied because the budget was used up,
+// averaged over the window. A rising rate signals that the service behind the budget is failing.
+func (b *RetryBudget) ExhaustionRate() float64 {
+	return b.denied.Rate()
+}
+
+// DefaultRetryAttempts is the number of attempts of a RetryTransport when MaxAttempts is not set.
+const DefaultRetryAttempts = 3
+
+// DefaultRetryBackoff is the delay before the first retry of a RetryTransport when Backoff is not set.
+const DefaultRetryBackoff = 100 * time.Millisecond
+
+// DefaultMaxRetryAfter is the longest Retry-After delay a RetryTransport honors when MaxRetryAfter is not set.
+const DefaultMaxRetryAfter = time.Minute
+
+// RetryTransport is an http.RoundTripper retrying idempotent requests that failed with a transport error
+// or with 502, 503 or 504. The delay doubles with every attempt and is randomly adjusted by up to
+// a fifth, so many clients do not retry in lockstep, unless the response carries a Retry-After header,
+// which is honored instead, up to MaxRetryAfter. When a Budget is set, every retry must be granted by it.
+// Requests with a body are only retried if the body can be replayed through Request.GetBody.
+type RetryTransport struct {
+	// Base performs the requests. When nil, http.DefaultTransport is used.
+	Base stdhttp.RoundTripper
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// When zero, DefaultRetryAttempts is used.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. When zero, DefaultRetryBackoff is used.
+	Backoff time.Duration
+	// Budget, if set, limits the retries across all requests sharing it.
+	Budget *RetryBudget
+	// MaxRetryAfter is the longest Retry-After delay honored. When the server asks to wait longer,
+	// the response is returned instead of stalling the request. When zero, DefaultMaxRetryAfter is used.
+	MaxRetryAfter time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *stdhttp.Request) (*stdhttp.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = stdhttp.DefaultTransport
+	}
+	attempts := t.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultRetryAttempts
+	}
+	backoff := t.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	maxRetryAfter := t.MaxRetryAfter
+	if maxRetryAfter <= 0 {
+		maxRetryAfter = DefaultMaxRetryAfter
+	}
+
+	if t.Budget != nil {
+		t.Budget.Request()
+	}
+
+	replayable := isIdempotent(req.Method) && (req.Body == nil || req.Body == stdhttp.NoBody || req.GetBody != nil)
+
+	for attempt := 1; ; attempt++ {
+		resp, err := base.RoundTrip(req)
+		if !replayable || attempt >= attempts || !shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		// The delay grows with every attempt, unless the server asks for a specific one.
+		// A server asking to wait too long is not retried, without spending the budget.
+		delay := common.Jitter(backoff<<(attempt-1), 0.2)
+		if resp != nil {
+			if retryAfter, ok := headerx.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				if retryAfter > maxRetryAfter {
+					return resp, err
+				}
+				delay = retryAfter
+			}
+		}
+
+		if t.Budget != nil && !t.Budget.TryRetry() {
+			return resp, err
+		}
+
+		// Release the connection of the failed attempt before trying again.
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+
+		if sleepErr := common.SleepCtx(req.Context(), delay); sleepErr != nil {
+			return nil, sleepErr
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// isIdempotent reports whether requests with the method can be repeated safely.
+func isIdempotent(method string) bool {
+	switch method {
+	case stdhttp.MethodGet, stdhttp.MethodHead, stdhttp.MethodOptions, stdhttp.MethodTrace, stdhttp.MethodPut, stdhttp.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// shouldRetry reports whether the result of an attempt is worth retrying.
+func shouldRetry(resp *stdhttp.Response, err error) bool {
+	if err != nil {
+		// A cancelled request must not be retried.
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case stdhttp.StatusBadGateway, stdhttp.StatusServiceUnavailable, stdhttp.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"io"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRetryBudget verifies that retries are limited to the ratio plus the minimum.
+func TestRetryBudget(t *testing.T) {
+	t.Parallel()
+
+	budget := NewRetryBudget(0.5, 1, time.Minute)
+
+	// Only the minimum is available before any request.
+	assert.True(t, budget.TryRetry(), "Expected the minimum retry to be granted")
+	assert.False(t, budget.TryRetry(), "Expected the budget to be exhausted")
+
+	// Every two requests add one retry.
+	for range 4 {
+		budget.Request()
+	}
+	assert.True(t, budget.TryRetry(), "Expected a retry earned by requests")
+	assert.True(t, budget.TryRetry(), "Expected a retry earned by requests")
+	assert.False(t, budget.TryRetry(), "Expected the budget to be exhausted")
+
+	assert.Equal(t, RetryBudgetStats{Requests: 4, Retries: 3, Exhausted: 2}, budget.Stats(), "Unexpected stats")
+	assert.Greater(t, budget.ExhaustionRate(), 0.0, "Expected the denied retries to be measured")
+}
+
+// TestRetryTransport verifies retries of failed requests and their limits.
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+
+	// newServer starts a server failing the first failures requests with 503, asking to retry immediately,
+	// and counting all requests.
+	newServer := func(failures int32) (*httptest.Server, *atomic.Int32) {
+		var calls atomic.Int32
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if calls.Add(1) <= failures {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(stdhttp.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write(append([]byte("ok:"), body...))
+		}))
+		return server, &calls
+	}
+
+	// RetriesUntilSuccess ensures transient failures are retried with the body replayed.
+	t.Run("RetriesUntilSuccess", func(t *testing.T) {
+		server, calls := newServer(2)
+		defer server.Close()
+
+		client := &stdhttp.Client{Transport: &RetryTransport{Backoff: time.Millisecond}}
+		req, _ := stdhttp.NewRequest(stdhttp.MethodPut, server.URL, strings.NewReader("payload"))
+
+		resp, err := client.Do(req)
+		assert.NoError(t, err, "Expected no error")
+		defer resp.Body.Close()
+
+		data, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, stdhttp.StatusOK, resp.StatusCode, "Expected the last attempt to succeed")
+		assert.Equal(t, "ok:payload", string(data), "Expected the body to be replayed")
+		assert.Equal(t, int32(3), calls.Load(), "Expected three attempts")
+	})
+
+	// RetryAfter ensures the delay requested by the server replaces the backoff.
+	t.Run("RetryAfter", func(t *testing.T) {
+		server, calls := newServer(1)
+		defer server.Close()
+
+		client := &stdhttp.Client{Transport: &RetryTransport{Backoff: time.Hour}}
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err, "Expected no error")
+		resp.Body.Close()
+
+		assert.Equal(t, stdhttp.StatusOK, resp.StatusCode, "Expected the retry to succeed")
+		assert.Equal(t, int32(2), calls.Load(), "Expected two attempts")
+	})
+
+	// RetryAfterTooLong ensures a server asking to wait longer than MaxRetryAfter is not retried.
+	t.Run("RetryAfterTooLong", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(stdhttp.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		budget := NewRetryBudget(0, 1, time.Minute)
+		client := &stdhttp.Client{Transport: &RetryTransport{MaxRetryAfter: time.Second, Budget: budget}}
+		resp, err := client.Get(server.URL)
+		assert.NoError(t, err, "Expected no error")
+		resp.Body.Close()
+
+		assert.Equal(t, stdhttp.StatusServiceUnavailable, resp.StatusCode, "Expected the failure to be returned")
+		assert.Equal(t, int32(1), calls.Load(), "Expected a single attempt")
+		assert.Equal(t, int64(0), budget.Stats().Retries, "Expected the budget not to be spent")
+	})
+
+	// NonIdempotent ensures POST requests are not retried.
+	t.Run("NonIdempotent", func(t *testing.T) {
+		server, calls := newServer(1)
+		defer server.Close()
+
+		client := &stdhttp.Client{Transport: &RetryTransport{Backoff: time.Millisecond}}
+		resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+		assert.NoError(t, err, "Expected no error")
+		resp.Body.Close()
+
+		assert.Equal(t, stdhttp.StatusServiceUnavailable, resp.StatusCode, "Expected the failure to be returned")
+		assert.Equal(t, int32(1), calls.Load(), "Expected a single attempt")
+	})
+
+	// BudgetExhausted ensures retries stop once the shared budget is used up.
+	t.Run("BudgetExhausted", func(t *testing.T) {
+		server, calls := newServer(100)
+		defer server.Close()
+
+		budget := NewRetryBudget(0, 1, time.Minute)
+		client := &stdhttp.Client{Transport: &RetryTransport{Backoff: time.Millisecond, MaxAttempts: 5, Budget: budget}}
+
+		for range 2 {
+			resp, err := client.Get(server.URL)
+			assert.NoError(t, err, "Expected no error")
+			resp.Body.Close()
+		}
+
+		assert.Equal(t, int32(3), calls.Load(), "Expected a single retry across both requests")
+		assert.Equal(t, RetryBudgetStats{Requests: 2, Retries: 1, Exhausted: 2}, budget.Stats(), "Unexpected stats")
+	})
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"context"
+	"net"
+	stdhttp "net/http"
+)
+
+// TransportOption configures a transport created by NewTransport.
+type TransportOption func(*stdhttp.Transport)
+
+// NewTransport creates an http.Transport with the settings of http.DefaultTransport, such as proxies from
+// the environment and connection pooling, modified by the options. The result can be wrapped by the other
+// transports of this package, so requests to local daemons go through the same retry and balancing stack.
+func NewTransport(opts ...TransportOption) *stdhttp.Transport {
+	transport := stdhttp.DefaultTransport.(*stdhttp.Transport).Clone()
+	for _, opt := range opts {
+		opt(transport)
+	}
+
+	return transport
+}
+
+// WithDialContext makes the transport open connections with dial instead of the default dialer,
+// for example to route them through a tunnel or to pin the address a host name resolves to.
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) TransportOption {
+	return func(t *stdhttp.Transport) {
+		t.DialContext = dial
+	}
+}
+
+// WithUnixSocket makes the transport connect to the Unix domain socket at path for every request,
+// whatever the host of the URL, to call local daemons such as Docker (/var/run/docker.sock).
+// Requests still need a URL with a host, conventionally "http://localhost/...". Proxies are disabled,
+// as they cannot reach the socket.
+func WithUnixSocket(path string) TransportOption {
+	return func(t *stdhttp.Transport) {
+		var dialer net.Dialer
+		t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", path)
+		}
+		t.Proxy = nil
+	}
+}
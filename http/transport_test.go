@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net"
+	stdhttp "net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWithUnixSocket verifies that requests are sent over a Unix domain socket.
+func TestWithUnixSocket(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "daemon.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("Unix domain sockets are not available: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, r *stdhttp.Request) {
+		_, _ = io.WriteString(w, "daemon "+r.URL.Path)
+	}))
+	server.Listener = listener
+	server.Start()
+	defer server.Close()
+
+	client := &stdhttp.Client{Transport: NewTransport(WithUnixSocket(path))}
+
+	resp, err := client.Get("http://localhost/v1/version")
+	assert.NoError(t, err, "Expected no error")
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "daemon /v1/version", string(data), "Unexpected response")
+}
+
+// TestWithDialContext verifies that the custom dialer is used.
+func TestWithDialContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(stdhttp.HandlerFunc(func(w stdhttp.ResponseWriter, _ *stdhttp.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	var dialed []string
+	transport := NewTransport(WithDialContext(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialed = append(dialed, addr)
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, server.Listener.Addr().String())
+	}))
+
+	resp, err := (&stdhttp.Client{Transport: transport}).Get("http://service.internal:1234/")
+	assert.NoError(t, err, "Expected no error")
+	resp.Body.Close()
+
+	assert.Equal(t, []string{"service.internal:1234"}, dialed, "Expected the custom dialer to be used")
+	assert.NotSame(t, stdhttp.DefaultTransport, transport, "Expected the default transport to stay unchanged")
+}
@@ -0,0 +1,217 @@
+package iox
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultPipeSize is the buffer size of a pipe created with a non-positive size.
+const DefaultPipeSize = 32 * 1024
+
+// BufferedPipe creates a synchronous in-memory pipe like io.Pipe, but with a buffer of size bytes, so
+// writers only block once the buffer is full instead of waiting for every read. Both ends support
+// deadlines: a blocked Read or Write fails with os.ErrDeadlineExceeded once its deadline has passed,
+// so a process feeding the stdin of a stalled command cannot hang forever.
+// The ends are safe for concurrent use.
+func BufferedPipe(size int) (*PipeReader, *PipeWriter) {
+	if size <= 0 {
+		size = DefaultPipeSize
+	}
+
+	p := &pipe{size: size, changed: make(chan struct{})}
+
+	return &PipeReader{p: p}, &PipeWriter{p: p}
+}
+
+// pipe is the state shared by both ends of a BufferedPipe.
+type pipe struct {
+	// size is the capacity of the buffer.
+	size int
+	// mu guards the fields below.
+	mu sync.Mutex
+	// buf holds the written data not read yet.
+	buf bytes.Buffer
+	// readErr is returned by reads once the buffer is drained, set when the writer is closed.
+	readErr error
+	// writeErr is returned by writes, set when the reader is closed.
+	writeErr error
+	// readDeadline and writeDeadline limit blocking reads and writes; zero means no deadline.
+	readDeadline, writeDeadline time.Time
+	// changed is closed and replaced whenever the state changes, waking up all blocked calls.
+	changed chan struct{}
+}
+
+// broadcast wakes up all blocked calls. The caller must hold the mutex.
+func (p *pipe) broadcast() {
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// wait blocks until the state changes or the deadline passes, returning os.ErrDeadlineExceeded in the latter case.
+// The caller must hold the mutex, which is released while waiting.
+func (p *pipe) wait(deadline time.Time) error {
+	changed := p.changed
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return os.ErrDeadlineExceeded
+		}
+		timer := time.NewTimer(remaining)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	p.mu.Unlock()
+	defer p.mu.Lock()
+
+	select {
+	case <-changed:
+		return nil
+	case <-timeout:
+		return os.ErrDeadlineExceeded
+	}
+}
+
+// PipeReader is the read end of a BufferedPipe.
+type PipeReader struct {
+	p *pipe
+}
+
+// Read reads buffered data, blocking until data is written, the writer is closed or the read deadline passes.
+// After the writer is closed, the remaining data is read first and then the close error is returned.
+func (r *PipeReader) Read(b []byte) (int, error) {
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for {
+		if p.writeErr != nil {
+			return 0, io.ErrClosedPipe
+		}
+		if p.buf.Len() > 0 {
+			n, _ := p.buf.Read(b)
+			p.broadcast()
+			return n, nil
+		}
+		if p.readErr != nil {
+			return 0, p.readErr
+		}
+		if err := p.wait(p.readDeadline); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// SetReadDeadline sets the deadline for blocked and future reads. A zero time means no deadline.
+func (r *PipeReader) SetReadDeadline(t time.Time) error {
+	r.p.mu.Lock()
+	defer r.p.mu.Unlock()
+
+	r.p.readDeadline = t
+	r.p.broadcast()
+
+	return nil
+}
+
+// Close closes the reader. Later writes fail with io.ErrClosedPipe.
+func (r *PipeReader) Close() error {
+	return r.CloseWithError(nil)
+}
+
+// CloseWithError closes the reader, so later writes fail with err, or io.ErrClosedPipe if err is nil.
+// Buffered data is discarded. Closing an end again keeps the first error.
+func (r *PipeReader) CloseWithError(err error) error {
+	if err == nil {
+		err = io.ErrClosedPipe
+	}
+
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.writeErr == nil {
+		p.writeErr = err
+		p.buf.Reset()
+		p.broadcast()
+	}
+
+	return nil
+}
+
+// PipeWriter is the write end of a BufferedPipe.
+type PipeWriter struct {
+	p *pipe
+}
+
+// Write writes b into the buffer, blocking while it is full until the reader makes room, the reader
+// is closed or the write deadline passes. It returns the number of bytes written before an error.
+func (w *PipeWriter) Write(b []byte) (int, error) {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	written := 0
+	for {
+		if p.writeErr != nil {
+			return written, p.writeErr
+		}
+		if p.readErr != nil {
+			return written, io.ErrClosedPipe
+		}
+		if len(b) == 0 {
+			return written, nil
+		}
+
+		if space := p.size - p.buf.Len(); space > 0 {
+			n, _ := p.buf.Write(b[:min(len(b), space)])
+			written += n
+			b = b[n:]
+			p.broadcast()
+			continue
+		}
+
+		if err := p.wait(p.writeDeadline); err != nil {
+			return written, err
+		}
+	}
+}
+
+// SetWriteDeadline sets the deadline for blocked and future writes. A zero time means no deadline.
+func (w *PipeWriter) SetWriteDeadline(t time.Time) error {
+	w.p.mu.Lock()
+	defer w.p.mu.Unlock()
+
+	w.p.writeDeadline = t
+	w.p.broadcast()
+
+	return nil
+}
+
+// Close closes the writer. Reads return the remaining data and then io.EOF.
+func (w *PipeWriter) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError closes the writer, so reads return the remaining data and then err, or io.EOF if err is nil.
+// Closing an end again keeps the first error.
+func (w *PipeWriter) CloseWithError(err error) error {
+	if err == nil {
+		err = io.EOF
+	}
+
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.readErr == nil {
+		p.readErr = err
+		p.broadcast()
+	}
+
+	return nil
+}
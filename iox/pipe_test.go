@@ -0,0 +1,80 @@
+package iox
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBufferedPipe verifies buffering, closing and deadlines of the pipe.
+func TestBufferedPipe(t *testing.T) {
+	t.Parallel()
+
+	// Buffered ensures writes up to the buffer size do not wait for a reader.
+	t.Run("Buffered", func(t *testing.T) {
+		reader, writer := BufferedPipe(8)
+
+		n, err := writer.Write([]byte("12345678"))
+		assert.NoError(t, err, "Expected the write to fit into the buffer")
+		assert.Equal(t, 8, n, "Unexpected written size")
+		assert.NoError(t, writer.Close(), "Expected no error on close")
+
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err, "Expected the data followed by EOF")
+		assert.Equal(t, "12345678", string(data), "Unexpected data")
+	})
+
+	// LargeWrite ensures writes larger than the buffer complete as the reader makes room.
+	t.Run("LargeWrite", func(t *testing.T) {
+		reader, writer := BufferedPipe(4)
+
+		go func() {
+			_, _ = writer.Write([]byte("a larger payload"))
+			_ = writer.CloseWithError(errors.New("done"))
+		}()
+
+		data, err := io.ReadAll(reader)
+		assert.EqualError(t, err, "done", "Expected the close error after the data")
+		assert.Equal(t, "a larger payload", string(data), "Unexpected data")
+	})
+
+	// WriteDeadline ensures a writer blocked on a full buffer gives up at the deadline.
+	t.Run("WriteDeadline", func(t *testing.T) {
+		_, writer := BufferedPipe(2)
+		assert.NoError(t, writer.SetWriteDeadline(time.Now().Add(20*time.Millisecond)), "Expected no error")
+
+		n, err := writer.Write([]byte("abc"))
+		assert.ErrorIs(t, err, os.ErrDeadlineExceeded, "Expected the deadline error")
+		assert.Equal(t, 2, n, "Expected the buffered part to be reported")
+	})
+
+	// ReadDeadline ensures a blocked reader gives up at a deadline set while it waits.
+	t.Run("ReadDeadline", func(t *testing.T) {
+		reader, _ := BufferedPipe(0)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = reader.SetReadDeadline(time.Now())
+		}()
+
+		_, err := reader.Read(make([]byte, 4))
+		assert.ErrorIs(t, err, os.ErrDeadlineExceeded, "Expected the deadline error")
+	})
+
+	// ReaderClosed ensures writers fail once the reader is gone.
+	t.Run("ReaderClosed", func(t *testing.T) {
+		reader, writer := BufferedPipe(2)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = reader.Close()
+		}()
+
+		_, err := writer.Write([]byte("abcdef"))
+		assert.ErrorIs(t, err, io.ErrClosedPipe, "Expected the closed pipe error")
+	})
+}
@@ -0,0 +1,171 @@
+package iox
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// ErrLimitExceeded is returned by a reader created with LimitReaderWithErr when the underlying reader
+// holds more data than the limit.
+var ErrLimitExceeded = errors.New("iox: read limit exceeded")
+
+// ConcatReaders returns a reader that reads the given readers one after another, like io.MultiReader.
+// Closing it closes every reader implementing io.Closer, including those that have not been read yet,
+// and returns all errors of closing joined together.
+func ConcatReaders(readers ...io.Reader) io.ReadCloser {
+	return &concatReader{reader: io.MultiReader(readers...), readers: readers}
+}
+
+// concatReader is the reader returned by ConcatReaders.
+type concatReader struct {
+	// reader reads the readers in sequence.
+	reader io.Reader
+	// readers are closed by Close.
+	readers []io.Reader
+}
+
+// Read reads from the current reader, moving to the next one at its end.
+func (r *concatReader) Read(p []byte) (int, error) {
+	return r.reader.Read(p)
+}
+
+// Close closes all readers implementing io.Closer.
+func (r *concatReader) Close() error {
+	var errs []error
+	for _, reader := range r.readers {
+		if closer, ok := reader.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// LimitReaderWithErr returns a reader reading at most n bytes from r. Unlike io.LimitReader, which ends
+// silently at the limit, the reader fails with ErrLimitExceeded when r holds more than n bytes, so
+// truncated input is not mistaken for complete input. Reaching the end of r at exactly n bytes is not an error.
+func LimitReaderWithErr(r io.Reader, n int64) io.Reader {
+	return &limitReader{reader: r, remaining: n}
+}
+
+// limitReader is the reader returned by LimitReaderWithErr.
+type limitReader struct {
+	// reader is the wrapped reader.
+	reader io.Reader
+	// remaining is the number of bytes that may still be read.
+	remaining int64
+}
+
+// Read reads up to the remaining number of bytes, and probes for more data once the limit is reached.
+func (r *limitReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if r.remaining <= 0 {
+		// Tell the end of the data apart from data beyond the limit.
+		var probe [1]byte
+		for {
+			n, err := r.reader.Read(probe[:])
+			if n > 0 {
+				return 0, ErrLimitExceeded
+			}
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+
+	return n, err
+}
+
+// CountingReader counts the bytes read through it. The count may be read concurrently with reading.
+type CountingReader struct {
+	// reader is the wrapped reader.
+	reader io.Reader
+	// count is the number of bytes read so far.
+	count atomic.Int64
+}
+
+// NewCountingReader creates a CountingReader reading from r.
+func NewCountingReader(r io.Reader) *CountingReader {
+	return &CountingReader{reader: r}
+}
+
+// Read reads from the wrapped reader and counts the bytes read.
+func (r *CountingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count.Add(int64(n))
+
+	return n, err
+}
+
+// Count returns the number of bytes read so far.
+func (r *CountingReader) Count() int64 {
+	return r.count.Load()
+}
+
+// CountingWriter counts the bytes written through it. The count may be read concurrently with writing.
+type CountingWriter struct {
+	// writer is the wrapped writer.
+	writer io.Writer
+	// count is the number of bytes written so far.
+	count atomic.Int64
+}
+
+// NewCountingWriter creates a CountingWriter writing to w.
+func NewCountingWriter(w io.Writer) *CountingWriter {
+	return &CountingWriter{writer: w}
+}
+
+// Write writes to the wrapped writer and counts the bytes written.
+func (w *CountingWriter) Write(p []byte) (int, error) {
+	n, err := w.writer.Write(p)
+	w.count.Add(int64(n))
+
+	return n, err
+}
+
+// Count returns the number of bytes written so far.
+func (w *CountingWriter) Count() int64 {
+	return w.count.Load()
+}
+
+// TeeReadCloser returns a reader that writes to w everything it reads from rc, like io.TeeReader.
+// Closing it closes rc and, if it implements io.Closer, w, so both ends of a copied response body
+// are released together.
+func TeeReadCloser(rc io.ReadCloser, w io.Writer) io.ReadCloser {
+	return &teeReadCloser{reader: io.TeeReader(rc, w), source: rc, writer: w}
+}
+
+// teeReadCloser is the reader returned by TeeReadCloser.
+type teeReadCloser struct {
+	// reader copies the data to the writer while reading.
+	reader io.Reader
+	// source is the wrapped reader.
+	source io.Closer
+	// writer receives a copy of the data.
+	writer io.Writer
+}
+
+// Read reads from the source and writes the data to the writer.
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	return t.reader.Read(p)
+}
+
+// Close closes the source and the writer.
+func (t *teeReadCloser) Close() error {
+	err := t.source.Close()
+	if closer, ok := t.writer.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
@@ -0,0 +1,113 @@
+package iox
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// closeRecorder is a reader and writer recording whether it was closed.
+type closeRecorder struct {
+	io.Reader
+	bytes.Buffer
+	closed bool
+	err    error
+}
+
+// Read reads from the embedded reader.
+func (c *closeRecorder) Read(p []byte) (int, error) {
+	return c.Reader.Read(p)
+}
+
+// Close records the call.
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.err
+}
+
+// TestConcatReaders verifies reading in sequence and closing all readers.
+func TestConcatReaders(t *testing.T) {
+	t.Parallel()
+
+	failure := errors.New("close failed")
+	first := &closeRecorder{Reader: strings.NewReader("ab")}
+	second := &closeRecorder{Reader: strings.NewReader("cd"), err: failure}
+
+	reader := ConcatReaders(first, strings.NewReader("-"), second)
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "ab-cd", string(data), "Unexpected data")
+
+	assert.ErrorIs(t, reader.Close(), failure, "Expected the close error")
+	assert.True(t, first.closed, "Expected the first reader to be closed")
+	assert.True(t, second.closed, "Expected the second reader to be closed")
+}
+
+// TestLimitReaderWithErr verifies that data beyond the limit is reported as an error.
+func TestLimitReaderWithErr(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for data below, at and above the limit.
+	cases := []struct {
+		name     string
+		input    string
+		limit    int64
+		expected string
+		err      error
+	}{
+		{name: "Below limit", input: "abc", limit: 5, expected: "abc"},
+		{name: "At limit", input: "abcde", limit: 5, expected: "abcde"},
+		{name: "Above limit", input: "abcdef", limit: 5, expected: "abcde", err: ErrLimitExceeded},
+		{name: "Zero limit", input: "a", limit: 0, expected: "", err: ErrLimitExceeded},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := io.ReadAll(LimitReaderWithErr(strings.NewReader(tt.input), tt.limit))
+
+			assert.Equal(t, tt.expected, string(data), "Test case %s failed", tt.name)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err, "Test case %s failed", tt.name)
+			} else {
+				assert.NoError(t, err, "Test case %s failed", tt.name)
+			}
+		})
+	}
+}
+
+// TestCounting verifies that the counting reader and writer count the transferred bytes.
+func TestCounting(t *testing.T) {
+	t.Parallel()
+
+	reader := NewCountingReader(strings.NewReader("counted data"))
+	var out bytes.Buffer
+	writer := NewCountingWriter(&out)
+
+	n, err := io.Copy(writer, reader)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, int64(12), n, "Unexpected copied size")
+	assert.Equal(t, int64(12), reader.Count(), "Unexpected read count")
+	assert.Equal(t, int64(12), writer.Count(), "Unexpected write count")
+}
+
+// TestTeeReadCloser verifies that the data is copied and both ends are closed.
+func TestTeeReadCloser(t *testing.T) {
+	t.Parallel()
+
+	source := &closeRecorder{Reader: strings.NewReader("body")}
+	copied := &closeRecorder{}
+
+	reader := TeeReadCloser(source, copied)
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "body", string(data), "Unexpected data")
+	assert.Equal(t, "body", copied.String(), "Expected the data to be copied")
+
+	assert.NoError(t, reader.Close(), "Expected no error on close")
+	assert.True(t, source.closed, "Expected the source to be closed")
+	assert.True(t, copied.closed, "Expected the writer to be closed")
+}
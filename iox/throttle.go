@@ -0,0 +1,141 @@
+package iox
+
+import (
+	"context"
+	"io"
+
+	"golang.org/x/time/rate"
+)
+
+// BandwidthLimiter is a token bucket measured in bytes. A single limiter can be shared by any number
+// of ThrottledReader and ThrottledWriter values, capping their combined throughput, which allows limits
+// per transfer (one limiter per stream) or per client (one limiter shared by all of its streams).
+// BandwidthLimiter is safe for concurrent use by multiple goroutines.
+type BandwidthLimiter struct {
+	// limiter is the underlying token bucket, where one token is one byte.
+	limiter *rate.Limiter
+}
+
+// NewBandwidthLimiter creates a limiter allowing bytesPerSecond bytes per second on average.
+// The burst is the largest amount of bytes transferred at once; a non-positive burst defaults to
+// bytesPerSecond, so that up to one second of traffic can be sent without waiting.
+// A non-positive bytesPerSecond is raised to one byte per second.
+func NewBandwidthLimiter(bytesPerSecond, burst int) *BandwidthLimiter {
+	// A zero rate would block forever, so enforce the smallest meaningful rate.
+	if bytesPerSecond < 1 {
+		bytesPerSecond = 1
+	}
+	if burst < 1 {
+		burst = bytesPerSecond
+	}
+
+	return &BandwidthLimiter{limiter: rate.NewLimiter(rate.Limit(bytesPerSecond), burst)}
+}
+
+// SetLimit changes the allowed bytes per second at runtime, affecting all readers and writers using the limiter.
+func (l *BandwidthLimiter) SetLimit(bytesPerSecond int) {
+	if bytesPerSecond < 1 {
+		bytesPerSecond = 1
+	}
+	l.limiter.SetLimit(rate.Limit(bytesPerSecond))
+}
+
+// Burst returns the largest amount of bytes transferred at once.
+func (l *BandwidthLimiter) Burst() int {
+	return l.limiter.Burst()
+}
+
+// WaitN blocks until n bytes may be transferred or the context is done. Requests larger than the burst
+// are split into several waits, so any n is accepted.
+func (l *BandwidthLimiter) WaitN(ctx context.Context, n int) error {
+	for n > 0 {
+		chunk := min(n, l.limiter.Burst())
+		if err := l.limiter.WaitN(ctx, chunk); err != nil {
+			return err
+		}
+		n -= chunk
+	}
+
+	return nil
+}
+
+// ThrottledReader wraps an io.Reader and limits the rate at which data can be read from it.
+type ThrottledReader struct {
+	// ctx aborts waiting for bandwidth when done.
+	ctx context.Context
+	// reader is the wrapped reader.
+	reader io.Reader
+	// limiter controls the read rate.
+	limiter *BandwidthLimiter
+}
+
+// NewThrottledReader creates a ThrottledReader reading from r at the rate allowed by limiter.
+// Reads return the context error once ctx is done.
+func NewThrottledReader(ctx context.Context, r io.Reader, limiter *BandwidthLimiter) *ThrottledReader {
+	return &ThrottledReader{ctx: ctx, reader: r, limiter: limiter}
+}
+
+// Read reads up to one burst of data from the wrapped reader and then waits until the limiter
+// allows the bytes that were read.
+func (r *ThrottledReader) Read(p []byte) (int, error) {
+	// Stop early if the context is already done.
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	// Never read more than one burst at once, so reads stay smooth and the wait is bounded.
+	if burst := r.limiter.Burst(); len(p) > burst {
+		p = p[:burst]
+	}
+
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		// Account for the bytes that were actually read.
+		if waitErr := r.limiter.WaitN(r.ctx, n); waitErr != nil {
+			return n, waitErr
+		}
+	}
+
+	return n, err
+}
+
+// ThrottledWriter wraps an io.Writer and limits the rate at which data can be written to it.
+type ThrottledWriter struct {
+	// ctx aborts waiting for bandwidth when done.
+	ctx context.Context
+	// writer is the wrapped writer.
+	writer io.Writer
+	// limiter controls the write rate.
+	limiter *BandwidthLimiter
+}
+
+// NewThrottledWriter creates a ThrottledWriter writing to w at the rate allowed by limiter.
+// Writes return the context error once ctx is done.
+func NewThrottledWriter(ctx context.Context, w io.Writer, limiter *BandwidthLimiter) *ThrottledWriter {
+	return &ThrottledWriter{ctx: ctx, writer: w, limiter: limiter}
+}
+
+// Write writes p to the wrapped writer in chunks of at most one burst, waiting for the limiter before each chunk.
+// It returns the number of bytes written before an error occurred.
+func (w *ThrottledWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		chunk := p[:min(len(p), w.limiter.Burst())]
+
+		// Wait for the bandwidth before writing the chunk.
+		if err := w.limiter.WaitN(w.ctx, len(chunk)); err != nil {
+			return written, err
+		}
+
+		n, err := w.writer.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		p = p[n:]
+	}
+
+	return written, nil
+}
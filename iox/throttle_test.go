@@ -0,0 +1,123 @@
+package iox
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBandwidthLimiter verifies the configuration of the BandwidthLimiter.
+func TestBandwidthLimiter(t *testing.T) {
+	t.Parallel()
+
+	// Defaults ensures invalid values fall back to sensible defaults.
+	t.Run("Defaults", func(t *testing.T) {
+		assert.Equal(t, 1000, NewBandwidthLimiter(1000, 0).Burst(), "Expected the burst to default to the rate")
+		assert.Equal(t, 1, NewBandwidthLimiter(0, 0).Burst(), "Expected the smallest rate to be enforced")
+		assert.Equal(t, 64, NewBandwidthLimiter(1000, 64).Burst(), "Expected an explicit burst to be kept")
+	})
+
+	// WaitNLargerThanBurst ensures requests above the burst are split instead of failing.
+	t.Run("WaitNLargerThanBurst", func(t *testing.T) {
+		limiter := NewBandwidthLimiter(1_000_000, 10)
+
+		assert.NoError(t, limiter.WaitN(context.Background(), 100), "Expected the request to be split into bursts")
+	})
+
+	// WaitNCancelled ensures waiting stops when the context is done.
+	t.Run("WaitNCancelled", func(t *testing.T) {
+		limiter := NewBandwidthLimiter(1, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.Error(t, limiter.WaitN(ctx, 5), "Expected the context error")
+	})
+}
+
+// TestThrottledReader verifies that reads are limited to the configured rate.
+func TestThrottledReader(t *testing.T) {
+	t.Parallel()
+
+	// ReadsAllData ensures the reader passes all data through unchanged.
+	t.Run("ReadsAllData", func(t *testing.T) {
+		limiter := NewBandwidthLimiter(1_000_000, 0)
+		reader := NewThrottledReader(context.Background(), strings.NewReader("throttled data"), limiter)
+
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err, "Expected no error while reading")
+		assert.Equal(t, "throttled data", string(data), "Expected the original data")
+	})
+
+	// LimitsRate ensures reading beyond the burst takes the expected time.
+	t.Run("LimitsRate", func(t *testing.T) {
+		// 1000 bytes per second with a burst of 100 bytes: 300 bytes take at least 200ms.
+		limiter := NewBandwidthLimiter(1000, 100)
+		reader := NewThrottledReader(context.Background(), bytes.NewReader(make([]byte, 300)), limiter)
+
+		start := time.Now()
+		data, err := io.ReadAll(reader)
+
+		assert.NoError(t, err, "Expected no error while reading")
+		assert.Len(t, data, 300, "Expected all data to be read")
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond, "Expected the read to be throttled")
+	})
+
+	// Cancelled ensures reads fail once the context is done.
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		reader := NewThrottledReader(ctx, strings.NewReader("data"), NewBandwidthLimiter(10, 0))
+
+		_, err := reader.Read(make([]byte, 4))
+		assert.ErrorIs(t, err, context.Canceled, "Expected the context error")
+	})
+}
+
+// TestThrottledWriter verifies that writes are limited to the configured rate.
+func TestThrottledWriter(t *testing.T) {
+	t.Parallel()
+
+	// LimitsRate ensures writing beyond the burst takes the expected time and all data arrives.
+	t.Run("LimitsRate", func(t *testing.T) {
+		limiter := NewBandwidthLimiter(1000, 100)
+		var buf bytes.Buffer
+		writer := NewThrottledWriter(context.Background(), &buf, limiter)
+
+		start := time.Now()
+		n, err := writer.Write(make([]byte, 300))
+
+		assert.NoError(t, err, "Expected no error while writing")
+		assert.Equal(t, 300, n, "Expected all bytes to be written")
+		assert.Equal(t, 300, buf.Len(), "Expected all bytes to reach the destination")
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond, "Expected the write to be throttled")
+	})
+
+	// SharedLimiter ensures writers sharing a limiter share its bandwidth.
+	t.Run("SharedLimiter", func(t *testing.T) {
+		limiter := NewBandwidthLimiter(1000, 100)
+		first := NewThrottledWriter(context.Background(), io.Discard, limiter)
+		second := NewThrottledWriter(context.Background(), io.Discard, limiter)
+
+		start := time.Now()
+		_, _ = first.Write(make([]byte, 150))
+		_, _ = second.Write(make([]byte, 150))
+
+		assert.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond, "Expected the writers to share the bandwidth")
+	})
+
+	// Cancelled ensures writes stop with the context error.
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		writer := NewThrottledWriter(ctx, io.Discard, NewBandwidthLimiter(10, 10))
+
+		n, err := writer.Write(make([]byte, 20))
+		assert.Error(t, err, "Expected the context error")
+		assert.Zero(t, n, "Expected nothing to be written")
+	})
+}
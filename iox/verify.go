@@ -0,0 +1,119 @@
+package iox
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	"github.com/SyntaxErrorLineNULL/common/crypto"
+)
+
+// ErrChecksumMismatch is matched by the errors of a VerifyingReader whose data does not have the expected digest.
+var ErrChecksumMismatch = errors.New("iox: checksum mismatch")
+
+// ChecksumError describes data whose digest differs from the expected one.
+type ChecksumError struct {
+	// Algorithm is the name of the hash algorithm.
+	Algorithm string
+	// Expected and Actual are the expected and the computed digests.
+	Expected, Actual []byte
+}
+
+// Error implements error.
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("iox: %s checksum mismatch: expected %s, got %s", e.Algorithm, hex.EncodeToString(e.Expected), hex.EncodeToString(e.Actual))
+}
+
+// Is reports whether target is ErrChecksumMismatch.
+func (e *ChecksumError) Is(target error) bool {
+	return target == ErrChecksumMismatch
+}
+
+// VerifyingReader hashes the data read through it and checks the digest once the data ends, so a download
+// can be streamed straight into its destination and still be verified without reading it a second time.
+type VerifyingReader struct {
+	// reader is the wrapped reader.
+	reader io.Reader
+	// algorithm computes the digest.
+	algorithm crypto.Hasher
+	// hash accumulates the digest of the data read so far.
+	hash hash.Hash
+	// expected is the expected digest.
+	expected []byte
+	// verified holds the result of the verification once the end of the data was reached.
+	verified error
+	// done reports whether the end of the data was reached.
+	done bool
+}
+
+// NewVerifyingReader creates a VerifyingReader reading from r and expecting the digest expectedSum of the
+// algorithm, such as crypto.SHA256. Checksums published as hexadecimal strings are decoded with hex.DecodeString.
+func NewVerifyingReader(r io.Reader, expectedSum []byte, algorithm crypto.Hasher) *VerifyingReader {
+	return &VerifyingReader{reader: r, algorithm: algorithm, hash: algorithm.New(), expected: expectedSum}
+}
+
+// Read reads from the wrapped reader. At the end of the data, it returns io.EOF if the digest matches and
+// a *ChecksumError otherwise, so consumers that stop at EOF never accept corrupted data silently.
+func (v *VerifyingReader) Read(p []byte) (int, error) {
+	if v.done {
+		return 0, v.result()
+	}
+
+	n, err := v.reader.Read(p)
+	// Writing to a hash never returns an error.
+	_, _ = v.hash.Write(p[:n])
+
+	if errors.Is(err, io.EOF) {
+		v.finish()
+		return n, v.result()
+	}
+
+	return n, err
+}
+
+// Close reads and verifies the data not consumed yet, such as padding a decoder ignores, and closes the
+// wrapped reader if it implements io.Closer. It returns a *ChecksumError if the digest does not match.
+func (v *VerifyingReader) Close() error {
+	var err error
+	if !v.done {
+		if _, drainErr := io.Copy(io.Discard, v); drainErr != nil {
+			err = drainErr
+		}
+	}
+	if err == nil {
+		err = v.verified
+	}
+
+	if closer, ok := v.reader.(io.Closer); ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
+
+// Verified reports whether all data was read and its digest matched.
+func (v *VerifyingReader) Verified() bool {
+	return v.done && v.verified == nil
+}
+
+// finish compares the digest once the end of the data was reached.
+func (v *VerifyingReader) finish() {
+	v.done = true
+
+	actual := v.hash.Sum(nil)
+	if subtle.ConstantTimeCompare(actual, v.expected) != 1 {
+		v.verified = &ChecksumError{Algorithm: v.algorithm.Name(), Expected: v.expected, Actual: actual}
+	}
+}
+
+// result returns the error reported at the end of the data.
+func (v *VerifyingReader) result() error {
+	if v.verified != nil {
+		return v.verified
+	}
+
+	return io.EOF
+}
@@ -0,0 +1,53 @@
+package iox
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/SyntaxErrorLineNULL/common/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestVerifyingReader verifies that mismatching data is reported at the end of the data and on close.
+func TestVerifyingReader(t *testing.T) {
+	t.Parallel()
+
+	const payload = "release archive"
+	sum := crypto.SHA256.Sum([]byte(payload))
+
+	// Match ensures matching data is read completely without an error.
+	t.Run("Match", func(t *testing.T) {
+		reader := NewVerifyingReader(strings.NewReader(payload), sum, crypto.SHA256)
+
+		data, err := io.ReadAll(reader)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, payload, string(data), "Unexpected data")
+		assert.True(t, reader.Verified(), "Expected the data to be verified")
+		assert.NoError(t, reader.Close(), "Expected no error on close")
+	})
+
+	// Mismatch ensures corrupted data fails at the end of the data.
+	t.Run("Mismatch", func(t *testing.T) {
+		reader := NewVerifyingReader(strings.NewReader(payload+"!"), sum, crypto.SHA256)
+
+		_, err := io.ReadAll(reader)
+		assert.ErrorIs(t, err, ErrChecksumMismatch, "Expected a checksum mismatch")
+		assert.Contains(t, err.Error(), "sha256", "Expected the algorithm in the error")
+		assert.False(t, reader.Verified(), "Expected the data not to be verified")
+		assert.ErrorIs(t, reader.Close(), ErrChecksumMismatch, "Expected close to report the mismatch")
+	})
+
+	// CloseDrains ensures Close verifies data that was not read by the consumer.
+	t.Run("CloseDrains", func(t *testing.T) {
+		reader := NewVerifyingReader(strings.NewReader(payload), sum, crypto.SHA256)
+
+		_, err := reader.Read(make([]byte, 4))
+		assert.NoError(t, err, "Expected no error")
+		assert.NoError(t, reader.Close(), "Expected the remaining data to be verified")
+		assert.True(t, reader.Verified(), "Expected the data to be verified")
+
+		corrupted := NewVerifyingReader(strings.NewReader("other"), sum, crypto.SHA256)
+		assert.ErrorIs(t, corrupted.Close(), ErrChecksumMismatch, "Expected close to detect the mismatch")
+	})
+}
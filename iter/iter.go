@@ -0,0 +1,79 @@
+package iter
+
+import (
+	"github.com/SyntaxErrorLineNULL/common/prob"
+)
+
+// Distinct returns a sequence yielding the elements of seq, skipping elements equal to one yielded before.
+// Sequences are push iterators with the same shape as iter.Seq: they call yield for every element and stop
+// early when yield returns false. Every distinct element is remembered, so the memory grows with the number
+// of distinct elements; use DistinctBloom for very large streams.
+func Distinct[T comparable](seq func(yield func(T) bool)) func(yield func(T) bool) {
+	return DistinctBy(seq, func(v T) T { return v })
+}
+
+// DistinctBy returns a sequence yielding the elements of seq, skipping elements whose key, as computed
+// by key, equals the key of an element yielded before. The first element with each key is kept.
+func DistinctBy[T any, K comparable](seq func(yield func(T) bool), key func(T) K) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		// The set is created per iteration, so the sequence can be iterated more than once.
+		seen := make(map[K]struct{})
+
+		seq(func(v T) bool {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				return true
+			}
+			seen[k] = struct{}{}
+
+			return yield(v)
+		})
+	}
+}
+
+// DistinctBloom returns a sequence like DistinctBy that remembers the keys in a Bloom filter sized for
+// expected distinct keys, so the memory stays fixed regardless of the length of the stream.
+// In exchange, a distinct element is skipped with the probability falsePositiveRate, which makes it suitable
+// for deduplicating logs or events, but not data that must not lose elements. It fails if the rate is not
+// in the open range (0, 1).
+func DistinctBloom[T any](seq func(yield func(T) bool), key func(T) string, expected int, falsePositiveRate float64) (func(yield func(T) bool), error) {
+	// Validate the parameters up front rather than on the first iteration.
+	if _, err := prob.NewBloom(expected, falsePositiveRate); err != nil {
+		return nil, err
+	}
+
+	return func(yield func(T) bool) {
+		// The parameters were validated above, so creating the filter cannot fail.
+		seen, _ := prob.NewBloom(expected, falsePositiveRate)
+
+		seq(func(v T) bool {
+			if seen.TestAndAdd([]byte(key(v))) {
+				return true
+			}
+
+			return yield(v)
+		})
+	}, nil
+}
+
+// FromSlice returns a sequence yielding the elements of s in order.
+func FromSlice[T any](s []T) func(yield func(T) bool) {
+	return func(yield func(T) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns the elements of seq as a slice, or nil if seq yields nothing.
+func Collect[T any](seq func(yield func(T) bool)) []T {
+	var out []T
+	seq(func(v T) bool {
+		out = append(out, v)
+		return true
+	})
+
+	return out
+}
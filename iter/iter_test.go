@@ -0,0 +1,68 @@
+package iter
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDistinct verifies that duplicates are skipped and the first occurrence is kept in order.
+func TestDistinct(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for empty, unique and repeated input.
+	cases := []struct {
+		name     string
+		input    []int
+		expected []int
+	}{
+		{name: "Empty", input: nil, expected: nil},
+		{name: "Unique", input: []int{3, 1, 2}, expected: []int{3, 1, 2}},
+		{name: "Repeated", input: []int{1, 2, 1, 3, 2, 1}, expected: []int{1, 2, 3}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Collect(Distinct(FromSlice(tt.input))), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestDistinctBy verifies deduplication by key, early stopping and repeated iteration.
+func TestDistinctBy(t *testing.T) {
+	t.Parallel()
+
+	seq := DistinctBy(FromSlice([]string{"Go", "go", "Rust", "GO", "rust", "Zig"}), strings.ToLower)
+	assert.Equal(t, []string{"Go", "Rust", "Zig"}, Collect(seq), "Expected the first element of every key")
+	assert.Equal(t, []string{"Go", "Rust", "Zig"}, Collect(seq), "Expected the sequence to be reusable")
+
+	var first []string
+	seq(func(v string) bool {
+		first = append(first, v)
+		return len(first) < 2
+	})
+	assert.Equal(t, []string{"Go", "Rust"}, first, "Expected iteration to stop when yield returns false")
+}
+
+// TestDistinctBloom verifies deduplication with a Bloom filter.
+func TestDistinctBloom(t *testing.T) {
+	t.Parallel()
+
+	input := make([]int, 0, 2000)
+	for i := range 1000 {
+		input = append(input, i, i)
+	}
+
+	seq, err := DistinctBloom(FromSlice(input), strconv.Itoa, 1000, 0.001)
+	assert.NoError(t, err, "Expected no error")
+
+	out := Collect(seq)
+	// A few distinct elements may be lost to false positives, but never duplicates kept.
+	assert.InDelta(t, 1000, len(out), 10, "Unexpected number of distinct elements")
+	assert.Equal(t, len(out), len(Collect(Distinct(FromSlice(out)))), "Expected no duplicates")
+
+	_, err = DistinctBloom(FromSlice(input), strconv.Itoa, 1000, 1.5)
+	assert.Error(t, err, "Expected an error for an invalid rate")
+}
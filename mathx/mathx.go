@@ -0,0 +1,59 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrOverflow is returned by the safe conversions when a value does not fit into the target type.
+var ErrOverflow = errors.New("mathx: integer overflow")
+
+// Clamp limits v to the range [lo, hi]. If lo is greater than hi, hi is returned.
+func Clamp[T constraints.Ordered](v, lo, hi T) T {
+	return min(max(v, lo), hi)
+}
+
+// Abs returns the absolute value of a signed integer. The minimum value of the type, such as
+// math.MinInt64, has no positive counterpart and is returned unchanged, as two's complement negation does.
+func Abs[T constraints.Signed](v T) T {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
+
+// RoundTo rounds f to the given number of decimal places, with halves rounded away from zero,
+// for example RoundTo(3.14159, 2) is 3.14. A negative number of decimals rounds to tens, hundreds and so on.
+// As most decimal fractions have no exact binary representation, a value that looks like a half, such as
+// 2.675, may be stored slightly below it and round down. Values too large to be scaled, infinities and NaN
+// are returned unchanged.
+func RoundTo(f float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	scaled := f * scale
+	if scale == 0 || math.IsInf(scale, 0) || math.IsInf(scaled, 0) || math.IsNaN(scaled) {
+		return f
+	}
+
+	return math.Round(scaled) / scale
+}
+
+// SafeIntFromInt64 converts v to int, returning ErrOverflow if it does not fit, as on 32-bit platforms.
+func SafeIntFromInt64(v int64) (int, error) {
+	if v < math.MinInt || v > math.MaxInt {
+		return 0, ErrOverflow
+	}
+
+	return int(v), nil
+}
+
+// SafeIntFromUint64 converts v to int, returning ErrOverflow if it exceeds math.MaxInt.
+func SafeIntFromUint64(v uint64) (int, error) {
+	if v > math.MaxInt {
+		return 0, ErrOverflow
+	}
+
+	return int(v), nil
+}
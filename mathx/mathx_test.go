@@ -0,0 +1,77 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestClamp verifies limiting values to a range.
+func TestClamp(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, Clamp(5, 0, 10), "Expected a value inside the range to stay unchanged")
+	assert.Equal(t, 0, Clamp(-3, 0, 10), "Expected the lower bound")
+	assert.Equal(t, 10, Clamp(42, 0, 10), "Expected the upper bound")
+	assert.Equal(t, 0.5, Clamp(0.7, 0.0, 0.5), "Expected floats to be supported")
+	assert.Equal(t, "m", Clamp("z", "a", "m"), "Expected strings to be supported")
+}
+
+// TestAbs verifies the absolute value of signed integers.
+func TestAbs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, Abs(-5), "Unexpected absolute value")
+	assert.Equal(t, 5, Abs(5), "Unexpected absolute value")
+	assert.Equal(t, int8(0), Abs(int8(0)), "Unexpected absolute value")
+	assert.Equal(t, int8(math.MinInt8), Abs(int8(math.MinInt8)), "Expected the minimum value to be returned unchanged")
+}
+
+// TestRoundTo verifies rounding to decimal places.
+func TestRoundTo(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		value    float64
+		decimals int
+		expected float64
+	}{
+		{name: "Two decimals", value: 3.14159, decimals: 2, expected: 3.14},
+		{name: "Half away from zero", value: 0.125, decimals: 2, expected: 0.13},
+		{name: "Negative value", value: -0.125, decimals: 2, expected: -0.13},
+		{name: "Zero decimals", value: 2.5, decimals: 0, expected: 3},
+		{name: "Negative decimals", value: 1234, decimals: -2, expected: 1200},
+		{name: "Huge scale", value: 1e300, decimals: 100, expected: 1e300},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RoundTo(tt.value, tt.decimals), "Test case %s failed", tt.name)
+		})
+	}
+
+	assert.True(t, math.IsNaN(RoundTo(math.NaN(), 2)), "Expected NaN to be returned unchanged")
+}
+
+// TestSafeIntConversions verifies conversions reporting overflow.
+func TestSafeIntConversions(t *testing.T) {
+	t.Parallel()
+
+	v, err := SafeIntFromInt64(-42)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, -42, v, "Unexpected value")
+
+	v, err = SafeIntFromUint64(42)
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 42, v, "Unexpected value")
+
+	_, err = SafeIntFromUint64(math.MaxUint64)
+	assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow")
+
+	if math.MaxInt == math.MaxInt32 {
+		_, err = SafeIntFromInt64(math.MaxInt64)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow on 32-bit platforms")
+	}
+}
@@ -0,0 +1,104 @@
+package mathx
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	// ErrEmpty is returned by the statistics that are undefined for an empty slice.
+	ErrEmpty = errors.New("mathx: empty input")
+	// ErrInvalidPercentile is returned by Percentile for a percentile outside of [0, 100].
+	ErrInvalidPercentile = errors.New("mathx: percentile out of range")
+)
+
+// Number is the set of integer and floating point types the statistics accept.
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// Sum returns the sum of the values as a float64, or zero for an empty slice. The values are accumulated
+// in floating point with compensated (Kahan-Neumaier) summation, so sums of large integers do not wrap
+// around and long series of floats do not accumulate rounding errors.
+func Sum[T Number](values []T) float64 {
+	var sum, compensation float64
+
+	for _, v := range values {
+		x := float64(v)
+		t := sum + x
+
+		// Recover the low-order bits lost when adding the smaller of the two operands.
+		if math.Abs(sum) >= math.Abs(x) {
+			compensation += (sum - t) + x
+		} else {
+			compensation += (x - t) + sum
+		}
+		sum = t
+	}
+
+	return sum + compensation
+}
+
+// Mean returns the arithmetic mean of the values, or ErrEmpty for an empty slice.
+func Mean[T Number](values []T) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+
+	return Sum(values) / float64(len(values)), nil
+}
+
+// Median returns the middle value of the values, or the mean of the two middle values for an even count.
+// The input is not modified. It returns ErrEmpty for an empty slice.
+func Median[T Number](values []T) (float64, error) {
+	return Percentile(values, 50)
+}
+
+// StdDev returns the population standard deviation of the values, or ErrEmpty for an empty slice.
+// Use it to describe the spread of a complete set of measurements, such as all latencies of a benchmark.
+func StdDev[T Number](values []T) (float64, error) {
+	mean, err := Mean(values)
+	if err != nil {
+		return 0, err
+	}
+
+	// Sum the squared deviations from the mean, which is more precise than the sum of squares formula.
+	deviations := make([]float64, len(values))
+	for i, v := range values {
+		d := float64(v) - mean
+		deviations[i] = d * d
+	}
+
+	return math.Sqrt(Sum(deviations) / float64(len(values))), nil
+}
+
+// Percentile returns the p-th percentile of the values, for p between 0 and 100, interpolating linearly
+// between the two closest values, so Percentile(values, 50) is the median and Percentile(values, 99)
+// the p99 latency. The input is not modified. It returns ErrEmpty for an empty slice and
+// ErrInvalidPercentile for p outside of [0, 100].
+func Percentile[T Number](values []T, p float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, ErrEmpty
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ErrInvalidPercentile
+	}
+
+	// Sort a copy, so the caller's order is kept.
+	sorted := make([]float64, len(values))
+	for i, v := range values {
+		sorted[i] = float64(v)
+	}
+	sort.Float64s(sorted)
+
+	// Locate the percentile between two ranks and interpolate between their values.
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+
+	return sorted[lower] + fraction*(sorted[upper]-sorted[lower]), nil
+}
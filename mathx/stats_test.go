@@ -0,0 +1,77 @@
+package mathx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSum verifies that Sum neither overflows nor loses precision.
+func TestSum(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0.0, Sum[int](nil), "Expected zero for an empty slice")
+	assert.Equal(t, 10.0, Sum([]int{1, 2, 3, 4}), "Unexpected integer sum")
+
+	// Overflow ensures sums beyond the range of the element type do not wrap around.
+	t.Run("Overflow", func(t *testing.T) {
+		assert.Equal(t, 510.0, Sum([]int8{127, 127, 127, 127, 2}), "Expected no wrap around")
+		assert.InEpsilon(t, 2*float64(math.MaxInt64), Sum([]int64{math.MaxInt64, math.MaxInt64}), 1e-12, "Expected no wrap around")
+	})
+
+	// Precision ensures small values are not lost next to large ones.
+	t.Run("Precision", func(t *testing.T) {
+		assert.Equal(t, 2.0, Sum([]float64{1e100, 1, -1e100, 1}), "Expected compensated summation")
+	})
+}
+
+// TestStatistics verifies Mean, Median, StdDev and Percentile.
+func TestStatistics(t *testing.T) {
+	t.Parallel()
+
+	latencies := []int{12, 5, 7, 20, 6, 10}
+
+	cases := []struct {
+		name     string
+		fn       func() (float64, error)
+		expected float64
+	}{
+		{name: "Mean", fn: func() (float64, error) { return Mean(latencies) }, expected: 10},
+		{name: "Median even", fn: func() (float64, error) { return Median(latencies) }, expected: 8.5},
+		{name: "Median odd", fn: func() (float64, error) { return Median([]float64{3, 1, 2}) }, expected: 2},
+		{name: "StdDev", fn: func() (float64, error) { return StdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}) }, expected: 2},
+		{name: "Percentile minimum", fn: func() (float64, error) { return Percentile(latencies, 0) }, expected: 5},
+		{name: "Percentile maximum", fn: func() (float64, error) { return Percentile(latencies, 100) }, expected: 20},
+		{name: "Percentile interpolated", fn: func() (float64, error) { return Percentile(latencies, 90) }, expected: 16},
+		{name: "Single value", fn: func() (float64, error) { return Percentile([]int{42}, 99) }, expected: 42},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn()
+
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.InDelta(t, tt.expected, result, 1e-9, "Test case %s failed", tt.name)
+		})
+	}
+
+	// InputUnchanged ensures the input keeps its order.
+	t.Run("InputUnchanged", func(t *testing.T) {
+		_, _ = Median(latencies)
+
+		assert.Equal(t, []int{12, 5, 7, 20, 6, 10}, latencies, "Expected the input slice to stay unchanged")
+	})
+
+	// Errors ensures undefined statistics are reported.
+	t.Run("Errors", func(t *testing.T) {
+		_, err := Mean[int](nil)
+		assert.ErrorIs(t, err, ErrEmpty, "Expected an error for Mean")
+		_, err = Median[int](nil)
+		assert.ErrorIs(t, err, ErrEmpty, "Expected an error for Median")
+		_, err = StdDev[int](nil)
+		assert.ErrorIs(t, err, ErrEmpty, "Expected an error for StdDev")
+		_, err = Percentile(latencies, 101)
+		assert.ErrorIs(t, err, ErrInvalidPercentile, "Expected an error for Percentile")
+	})
+}
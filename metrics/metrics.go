@@ -0,0 +1,179 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// EWMA is an exponentially weighted moving average of a stream of observations.
+// Every new observation moves the average towards itself by a fraction equal to alpha,
+// so recent values dominate the result while older values decay exponentially.
+// EWMA is safe for concurrent use by multiple goroutines.
+type EWMA struct {
+	// mu guards the fields below against concurrent updates.
+	mu sync.Mutex
+	// alpha is the smoothing factor in the range (0, 1].
+	alpha float64
+	// value holds the current average.
+	value float64
+	// initialized reports whether at least one observation has been added.
+	initialized bool
+}
+
+// NewEWMA creates a new EWMA with the given smoothing factor.
+// The alpha value is clamped to the range (0, 1]; a non-positive alpha falls back to 1,
+// which makes the average simply follow the latest observation.
+func NewEWMA(alpha float64) *EWMA {
+	// Guard against a smoothing factor outside the valid range.
+	// A value above 1 would overshoot, and a non-positive value would freeze the average.
+	if alpha <= 0 || alpha > 1 {
+		alpha = 1
+	}
+
+	return &EWMA{alpha: alpha}
+}
+
+// Add records a new observation and updates the moving average.
+// The first observation initializes the average directly so the estimate
+// does not start biased towards zero.
+func (e *EWMA) Add(value float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Seed the average with the first observation.
+	if !e.initialized {
+		e.value = value
+		e.initialized = true
+		return
+	}
+
+	// Move the average towards the new observation by alpha.
+	e.value += e.alpha * (value - e.value)
+}
+
+// Value returns the current moving average, or zero if nothing has been added yet.
+func (e *EWMA) Value() float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.value
+}
+
+// Reset discards the accumulated average so the next observation seeds it again.
+func (e *EWMA) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.value = 0
+	e.initialized = false
+}
+
+// rateBuckets is the number of buckets a RateEstimator divides its window into.
+const rateBuckets = 20
+
+// rateBucket counts the events recorded during one slice of time.
+type rateBucket struct {
+	// slot numbers the slice of time, counted in bucket widths since the Unix epoch.
+	slot int64
+	// count is the number of events recorded during the slice.
+	count int64
+}
+
+// RateEstimator measures the rate of events per second over a sliding time window.
+// The window is divided into a fixed ring of buckets, a twentieth of the window each, so recording and
+// querying take constant time and memory regardless of the number of events. Buckets leave the window
+// as a whole, so the window slides in steps of one bucket.
+// RateEstimator is safe for concurrent use by multiple goroutines.
+type RateEstimator struct {
+	// mu guards the fields below against concurrent updates.
+	mu sync.Mutex
+	// window is the length of the sliding window used for the estimate.
+	window time.Duration
+	// width is the length of the slice of time counted by every bucket.
+	width time.Duration
+	// buckets is the ring of buckets, indexed by slot modulo its length.
+	buckets [rateBuckets]rateBucket
+	// now returns the current time and can be replaced in tests.
+	now func() time.Time
+}
+
+// NewRateEstimator creates a new RateEstimator using the given sliding window.
+// A non-positive window falls back to one second.
+func NewRateEstimator(window time.Duration) *RateEstimator {
+	// A zero or negative window cannot hold any events, so use a sensible default.
+	if window <= 0 {
+		window = time.Second
+	}
+
+	return &RateEstimator{window: window, width: max(window/rateBuckets, 1), now: time.Now}
+}
+
+// Incr records a single event.
+func (r *RateEstimator) Incr() {
+	r.Add(1)
+}
+
+// Add records n events happening at the current moment.
+// Non-positive values are ignored.
+func (r *RateEstimator) Add(n int64) {
+	// Nothing to record for empty or negative batches.
+	if n <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	slot := r.slot(r.now())
+	bucket := &r.buckets[slot%rateBuckets]
+	// A bucket still holding an older slice of time is reused for the current one.
+	if bucket.slot != slot {
+		*bucket = rateBucket{slot: slot}
+	}
+	bucket.count += n
+}
+
+// Count returns the number of events recorded within the current window.
+func (r *RateEstimator) Count() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.total(r.now())
+}
+
+// Rate returns the estimated number of events per second over the current window.
+func (r *RateEstimator) Rate() float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Divide the number of events in the window by its length in seconds.
+	return float64(r.total(r.now())) / r.window.Seconds()
+}
+
+// Reset discards all recorded events.
+func (r *RateEstimator) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.buckets = [rateBuckets]rateBucket{}
+}
+
+// slot returns the number of the bucket covering the moment t.
+func (r *RateEstimator) slot(t time.Time) int64 {
+	return t.UnixNano() / int64(r.width)
+}
+
+// total sums the events of the buckets within the window ending at now.
+// The caller must hold the mutex.
+func (r *RateEstimator) total(now time.Time) int64 {
+	current := r.slot(now)
+
+	var total int64
+	for _, bucket := range r.buckets {
+		if bucket.slot > current-rateBuckets && bucket.slot <= current {
+			total += bucket.count
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEWMA verifies that the EWMA correctly seeds, smooths and resets its average.
+func TestEWMA(t *testing.T) {
+	t.Parallel()
+
+	// FirstValueSeedsAverage ensures the first observation is taken as-is,
+	// so the average does not start biased towards zero.
+	t.Run("FirstValueSeedsAverage", func(t *testing.T) {
+		ewma := NewEWMA(0.5)
+		ewma.Add(10)
+
+		assert.Equal(t, 10.0, ewma.Value(), "Expected the first observation to seed the average")
+	})
+
+	// Smoothing ensures each observation moves the average by alpha towards itself.
+	t.Run("Smoothing", func(t *testing.T) {
+		ewma := NewEWMA(0.5)
+		ewma.Add(10)
+		ewma.Add(20)
+		ewma.Add(20)
+
+		// 10 -> 15 -> 17.5
+		assert.InDelta(t, 17.5, ewma.Value(), 1e-9, "Expected the average to move halfway on each observation")
+	})
+
+	// InvalidAlpha ensures an out-of-range alpha falls back to following the latest value.
+	t.Run("InvalidAlpha", func(t *testing.T) {
+		ewma := NewEWMA(-1)
+		ewma.Add(1)
+		ewma.Add(42)
+
+		assert.Equal(t, 42.0, ewma.Value(), "Expected the average to follow the latest observation")
+	})
+
+	// Reset ensures the average is cleared and reseeded by the next observation.
+	t.Run("Reset", func(t *testing.T) {
+		ewma := NewEWMA(0.1)
+		ewma.Add(100)
+		ewma.Reset()
+
+		assert.Zero(t, ewma.Value(), "Expected zero after reset")
+
+		ewma.Add(5)
+		assert.Equal(t, 5.0, ewma.Value(), "Expected the next observation to reseed the average")
+	})
+
+	// Concurrent ensures the EWMA can be updated from several goroutines at once.
+	t.Run("Concurrent", func(t *testing.T) {
+		ewma := NewEWMA(0.2)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ewma.Add(7)
+			}()
+		}
+		wg.Wait()
+
+		assert.InDelta(t, 7.0, ewma.Value(), 1e-9, "Expected a constant stream to produce a constant average")
+	})
+}
+
+// TestRateEstimator verifies that the RateEstimator counts events within its sliding window.
+func TestRateEstimator(t *testing.T) {
+	t.Parallel()
+
+	// newEstimator creates an estimator driven by a manually advanced clock.
+	newEstimator := func(window time.Duration) (*RateEstimator, *time.Time) {
+		current := time.Unix(1_700_000_000, 0)
+		estimator := NewRateEstimator(window)
+		estimator.now = func() time.Time { return current }
+		return estimator, &current
+	}
+
+	// RateWithinWindow ensures all recorded events are included while inside the window.
+	t.Run("RateWithinWindow", func(t *testing.T) {
+		estimator, _ := newEstimator(2 * time.Second)
+		estimator.Add(10)
+		estimator.Incr()
+		estimator.Incr()
+
+		assert.Equal(t, int64(12), estimator.Count(), "Expected all events to be counted")
+		assert.InDelta(t, 6.0, estimator.Rate(), 1e-9, "Expected 12 events over 2 seconds")
+	})
+
+	// Eviction ensures events older than the window are no longer counted.
+	t.Run("Eviction", func(t *testing.T) {
+		estimator, current := newEstimator(time.Second)
+		estimator.Add(5)
+
+		*current = current.Add(500 * time.Millisecond)
+		estimator.Add(3)
+		assert.Equal(t, int64(8), estimator.Count(), "Expected both batches inside the window")
+
+		*current = current.Add(600 * time.Millisecond)
+		assert.Equal(t, int64(3), estimator.Count(), "Expected the first batch to be evicted")
+
+		*current = current.Add(time.Second)
+		assert.Zero(t, estimator.Rate(), "Expected no events left in the window")
+	})
+
+	// SteadyStream ensures a long stream of events is counted over the window only.
+	t.Run("SteadyStream", func(t *testing.T) {
+		estimator, current := newEstimator(time.Second)
+		for range 10_000 {
+			estimator.Incr()
+			*current = current.Add(time.Millisecond)
+		}
+
+		// The window slides by whole buckets, a twentieth of the window.
+		assert.InDelta(t, 1000, estimator.Count(), 50, "Expected the events of the last second")
+		assert.InDelta(t, 1000.0, estimator.Rate(), 50, "Expected about 1000 events per second")
+	})
+
+	// IgnoresNonPositive ensures empty or negative batches are not recorded.
+	t.Run("IgnoresNonPositive", func(t *testing.T) {
+		estimator, _ := newEstimator(time.Second)
+		estimator.Add(0)
+		estimator.Add(-3)
+
+		assert.Zero(t, estimator.Count(), "Expected no events to be recorded")
+	})
+
+	// DefaultWindow ensures an invalid window falls back to one second.
+	t.Run("DefaultWindow", func(t *testing.T) {
+		estimator := NewRateEstimator(0)
+
+		assert.Equal(t, time.Second, estimator.window, "Expected the window to default to one second")
+	})
+
+	// Reset ensures all recorded events are discarded.
+	t.Run("Reset", func(t *testing.T) {
+		estimator, _ := newEstimator(time.Second)
+		estimator.Add(4)
+		estimator.Reset()
+
+		assert.Zero(t, estimator.Count(), "Expected no events after reset")
+	})
+}
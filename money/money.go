@@ -0,0 +1,358 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownCurrency is returned for a currency code missing from the currency table.
+	ErrUnknownCurrency = errors.New("money: unknown currency")
+	// ErrCurrencyMismatch is returned when amounts in different currencies are combined.
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	// ErrOverflow is returned when a result does not fit into the range of an amount.
+	ErrOverflow = errors.New("money: amount overflow")
+	// ErrInvalidAmount is returned by Parse for malformed amounts, including amounts with more decimal
+	// places than the currency has.
+	ErrInvalidAmount = errors.New("money: invalid amount")
+)
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	// Code is the three-letter code, such as "USD".
+	Code string
+	// Digits is the number of decimal places of the minor unit, such as 2 for cents.
+	Digits int
+}
+
+// currencies holds the known currencies by code.
+var currencies = map[string]Currency{
+	"AUD": {Code: "AUD", Digits: 2},
+	"BHD": {Code: "BHD", Digits: 3},
+	"BRL": {Code: "BRL", Digits: 2},
+	"CAD": {Code: "CAD", Digits: 2},
+	"CHF": {Code: "CHF", Digits: 2},
+	"CNY": {Code: "CNY", Digits: 2},
+	"CZK": {Code: "CZK", Digits: 2},
+	"DKK": {Code: "DKK", Digits: 2},
+	"EUR": {Code: "EUR", Digits: 2},
+	"GBP": {Code: "GBP", Digits: 2},
+	"HKD": {Code: "HKD", Digits: 2},
+	"INR": {Code: "INR", Digits: 2},
+	"JPY": {Code: "JPY", Digits: 0},
+	"KRW": {Code: "KRW", Digits: 0},
+	"KWD": {Code: "KWD", Digits: 3},
+	"MXN": {Code: "MXN", Digits: 2},
+	"NOK": {Code: "NOK", Digits: 2},
+	"NZD": {Code: "NZD", Digits: 2},
+	"PLN": {Code: "PLN", Digits: 2},
+	"RUB": {Code: "RUB", Digits: 2},
+	"SEK": {Code: "SEK", Digits: 2},
+	"SGD": {Code: "SGD", Digits: 2},
+	"TRY": {Code: "TRY", Digits: 2},
+	"UAH": {Code: "UAH", Digits: 2},
+	"USD": {Code: "USD", Digits: 2},
+	"ZAR": {Code: "ZAR", Digits: 2},
+}
+
+// LookupCurrency returns the currency with the code, which is matched case-insensitively.
+func LookupCurrency(code string) (Currency, error) {
+	currency, ok := currencies[strings.ToUpper(code)]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+
+	return currency, nil
+}
+
+// Money is an amount of a currency, stored as an integer number of minor units such as cents, so
+// arithmetic is exact and never suffers from the rounding errors of floating point. Operations return
+// new values without allocating and report overflows and currency mismatches as errors.
+// The zero value has no currency and can only be combined with itself.
+type Money struct {
+	// amount is the number of minor units.
+	amount int64
+	// currency is the currency of the amount.
+	currency Currency
+}
+
+// New returns an amount of minor units of the currency, for example New(1234, "USD") for $12.34.
+func New(minor int64, code string) (Money, error) {
+	currency, err := LookupCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return Money{amount: minor, currency: currency}, nil
+}
+
+// Parse parses a decimal amount such as "-12.5" of the currency. The amount may have at most as many
+// decimal places as the currency; rounding is never applied implicitly. Thousands separators and
+// exponents are not accepted.
+func Parse(amount, code string) (Money, error) {
+	currency, err := LookupCurrency(code)
+	if err != nil {
+		return Money{}, err
+	}
+
+	// Split off the sign and the fraction.
+	s := amount
+	negative := strings.HasPrefix(s, "-")
+	if negative || strings.HasPrefix(s, "+") {
+		s = s[1:]
+	}
+	whole, fraction, hasFraction := strings.Cut(s, ".")
+	if whole == "" || (hasFraction && fraction == "") || len(fraction) > currency.Digits {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+	}
+
+	// Combine the digits into minor units, padding the fraction to the decimal places of the currency.
+	digits := whole + fraction + strings.Repeat("0", currency.Digits-len(fraction))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, amount)
+		}
+	}
+	if negative {
+		digits = "-" + digits
+	}
+
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrOverflow, amount)
+	}
+
+	return Money{amount: minor, currency: currency}, nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants in code and tests.
+func MustParse(amount, code string) Money {
+	m, err := Parse(amount, code)
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// Minor returns the amount in minor units.
+func (m Money) Minor() int64 {
+	return m.amount
+}
+
+// Currency returns the currency of the amount.
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of the amount.
+func (m Money) Sign() int {
+	switch {
+	case m.amount < 0:
+		return -1
+	case m.amount > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Add returns m + other. Both amounts must be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.currency != other.currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+
+	sum := m.amount + other.amount
+	// Adding two numbers of the same sign must not change it.
+	if (other.amount > 0 && sum < m.amount) || (other.amount < 0 && sum > m.amount) {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{amount: sum, currency: m.currency}, nil
+}
+
+// Sub returns m - other. Both amounts must be in the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+
+	return m.Add(other.Neg())
+}
+
+// Mul returns m multiplied by an integer factor, such as a quantity.
+func (m Money) Mul(factor int64) (Money, error) {
+	if m.amount == 0 || factor == 0 {
+		return Money{currency: m.currency}, nil
+	}
+
+	product := m.amount * factor
+	if product/factor != m.amount || (m.amount == -1 && factor == math.MinInt64) || (factor == -1 && m.amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// Neg returns -m. The negation of the smallest amount wraps around to itself.
+func (m Money) Neg() Money {
+	return Money{amount: -m.amount, currency: m.currency}
+}
+
+// Cmp compares m and other, returning -1, 0 or +1. Both amounts must be in the same currency.
+func (m Money) Cmp(other Money) (int, error) {
+	if m.currency != other.currency {
+		return 0, ErrCurrencyMismatch
+	}
+
+	switch {
+	case m.amount < other.amount:
+		return -1, nil
+	case m.amount > other.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Allocate splits m into parts proportional to the ratios without losing a minor unit, for example
+// to split a bill. The remainder left by rounding down is distributed one minor unit at a time to the
+// first parts, so the parts always add up to m. The ratios must not be negative and must not all be zero.
+// ErrOverflow is returned if the sum of the ratios does not fit into an int64.
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := int64(0)
+	for _, ratio := range ratios {
+		if ratio < 0 {
+			return nil, fmt.Errorf("money: negative ratio %d", ratio)
+		}
+		if int64(ratio) > math.MaxInt64-total {
+			return nil, fmt.Errorf("%w: sum of ratios", ErrOverflow)
+		}
+		total += int64(ratio)
+	}
+	if total == 0 {
+		return nil, errors.New("money: ratios must not all be zero")
+	}
+
+	// The share of a ratio is amount*ratio/total, computed as the whole multiples of the total plus the share
+	// of the rest. The first term never exceeds the amount, as the ratio never exceeds the total.
+	quotient, rest := m.amount/total, m.amount%total
+	sign := int64(1)
+	if rest < 0 {
+		sign, rest = -1, -rest
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, ratio := range ratios {
+		// The product of the rest and the ratio may exceed 64 bits, so multiply into 128 bits.
+		// The quotient is less than the ratio, and the high bits are less than the total, as Div64 requires.
+		hi, lo := bits.Mul64(uint64(rest), uint64(ratio))
+		restShare, _ := bits.Div64(hi, lo, uint64(total))
+
+		share := quotient*int64(ratio) + sign*int64(restShare)
+		parts[i] = Money{amount: share, currency: m.currency}
+		remainder -= share
+	}
+
+	// Hand out the remainder unit by unit, in the direction of its sign.
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+		parts[i].amount += unit
+		remainder -= unit
+	}
+
+	return parts, nil
+}
+
+// String formats the amount with the decimal places of its currency followed by the currency code,
+// for example "-12.50 USD".
+func (m Money) String() string {
+	return m.Amount() + " " + m.currency.Code
+}
+
+// Amount formats the amount with the decimal places of its currency, without the currency,
+// for example "-12.50". It is the inverse of Parse.
+func (m Money) Amount() string {
+	// Work on the magnitude as unsigned, which also covers the smallest amount.
+	magnitude := uint64(m.amount)
+	sign := ""
+	if m.amount < 0 {
+		magnitude = -magnitude
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(magnitude, 10)
+	if m.currency.Digits == 0 {
+		return sign + digits
+	}
+
+	// Pad small amounts, so one cent becomes "0.01".
+	if len(digits) <= m.currency.Digits {
+		digits = strings.Repeat("0", m.currency.Digits-len(digits)+1) + digits
+	}
+	point := len(digits) - m.currency.Digits
+
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// jsonMoney is the JSON representation of Money. The amount is a string, so no JSON decoder
+// reads it as a float.
+type jsonMoney struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON implements json.Marshaler, encoding the amount as {"amount":"12.34","currency":"USD"}.
+// The zero Money, which has no currency, is encoded as null.
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m == (Money{}) {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(jsonMoney{Amount: m.Amount(), Currency: m.currency.Code})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting the output of MarshalJSON. Like the standard
+// types, null leaves m unchanged. A zero amount without a currency decodes to the zero Money.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+
+	var raw jsonMoney
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if raw.Currency == "" && (raw.Amount == "" || strings.Trim(raw.Amount, "0.") == "") {
+		*m = Money{}
+		return nil
+	}
+
+	parsed, err := Parse(raw.Amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+
+	return nil
+}
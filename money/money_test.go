@@ -0,0 +1,226 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParse verifies parsing decimal amounts into minor units.
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		amount   string
+		code     string
+		expected int64
+		err      error
+	}{
+		{name: "Whole", amount: "12", code: "USD", expected: 1200},
+		{name: "Fraction", amount: "12.34", code: "USD", expected: 1234},
+		{name: "Short fraction", amount: "0.5", code: "EUR", expected: 50},
+		{name: "Negative", amount: "-0.01", code: "USD", expected: -1},
+		{name: "Explicit plus", amount: "+3", code: "USD", expected: 300},
+		{name: "No minor unit", amount: "1500", code: "JPY", expected: 1500},
+		{name: "Three digits", amount: "1.234", code: "kwd", expected: 1234},
+		{name: "Too many decimals", amount: "1.234", code: "USD", err: ErrInvalidAmount},
+		{name: "Decimals without minor unit", amount: "1.5", code: "JPY", err: ErrInvalidAmount},
+		{name: "Empty", amount: "", code: "USD", err: ErrInvalidAmount},
+		{name: "Trailing point", amount: "1.", code: "USD", err: ErrInvalidAmount},
+		{name: "Double sign", amount: "-+1", code: "USD", err: ErrInvalidAmount},
+		{name: "Float syntax", amount: "1e3", code: "USD", err: ErrInvalidAmount},
+		{name: "Overflow", amount: "999999999999999999", code: "USD", err: ErrOverflow},
+		{name: "Unknown currency", amount: "1", code: "XYZ", err: ErrUnknownCurrency},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := Parse(tt.amount, tt.code)
+
+			assert.ErrorIs(t, err, tt.err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, m.Minor(), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestFormat verifies formatting with the decimal places of the currency.
+func TestFormat(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		minor    int64
+		code     string
+		expected string
+	}{
+		{name: "Cents", minor: 1234, code: "USD", expected: "12.34 USD"},
+		{name: "Single cent", minor: 1, code: "USD", expected: "0.01 USD"},
+		{name: "Negative", minor: -1250, code: "EUR", expected: "-12.50 EUR"},
+		{name: "Zero", minor: 0, code: "GBP", expected: "0.00 GBP"},
+		{name: "No minor unit", minor: 1500, code: "JPY", expected: "1500 JPY"},
+		{name: "Smallest amount", minor: math.MinInt64, code: "USD", expected: "-92233720368547758.08 USD"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.minor, tt.code)
+
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, m.String(), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestArithmetic verifies exact arithmetic with overflow and currency checks.
+func TestArithmetic(t *testing.T) {
+	t.Parallel()
+
+	price := MustParse("0.10", "USD")
+
+	// Exact ensures the classic floating point error does not occur.
+	t.Run("Exact", func(t *testing.T) {
+		sum, err := price.Add(MustParse("0.20", "USD"))
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, MustParse("0.30", "USD"), sum, "Expected an exact sum")
+	})
+
+	// SubAndMul ensures subtraction and multiplication by quantities.
+	t.Run("SubAndMul", func(t *testing.T) {
+		total, err := price.Mul(3)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "0.30 USD", total.String(), "Unexpected product")
+
+		change, err := price.Sub(total)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "-0.20 USD", change.String(), "Unexpected difference")
+		assert.Equal(t, -1, change.Sign(), "Unexpected sign")
+	})
+
+	// Mismatch ensures currencies are not mixed.
+	t.Run("Mismatch", func(t *testing.T) {
+		_, err := price.Add(MustParse("0.10", "EUR"))
+		assert.ErrorIs(t, err, ErrCurrencyMismatch, "Expected a currency mismatch")
+
+		_, err = price.Cmp(MustParse("0.10", "EUR"))
+		assert.ErrorIs(t, err, ErrCurrencyMismatch, "Expected a currency mismatch")
+	})
+
+	// Overflow ensures results out of range are reported.
+	t.Run("Overflow", func(t *testing.T) {
+		largest, _ := New(math.MaxInt64, "USD")
+		smallest, _ := New(math.MinInt64, "USD")
+
+		_, err := largest.Add(price)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow of Add")
+		_, err = smallest.Sub(price)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow of Sub")
+		_, err = largest.Mul(2)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow of Mul")
+		_, err = smallest.Mul(-1)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflow of Mul")
+	})
+
+	// Cmp ensures amounts are ordered.
+	t.Run("Cmp", func(t *testing.T) {
+		result, err := price.Cmp(MustParse("0.20", "USD"))
+
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, -1, result, "Unexpected comparison")
+	})
+}
+
+// TestAllocate verifies splitting amounts without losing minor units.
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		amount   string
+		ratios   []int
+		expected []string
+	}{
+		{name: "Even split", amount: "100.00", ratios: []int{1, 1, 1}, expected: []string{"33.34", "33.33", "33.33"}},
+		{name: "Weighted", amount: "0.05", ratios: []int{3, 7}, expected: []string{"0.02", "0.03"}},
+		{name: "Negative", amount: "-1.00", ratios: []int{1, 1, 1}, expected: []string{"-0.34", "-0.33", "-0.33"}},
+		{name: "Zero ratio", amount: "0.03", ratios: []int{0, 1, 1}, expected: []string{"0.00", "0.02", "0.01"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			parts, err := MustParse(tt.amount, "USD").Allocate(tt.ratios...)
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+
+			amounts := make([]string, len(parts))
+			for i, part := range parts {
+				amounts[i] = part.Amount()
+			}
+			assert.Equal(t, tt.expected, amounts, "Test case %s failed", tt.name)
+		})
+	}
+
+	// InvalidRatios ensures unusable ratios are rejected.
+	t.Run("InvalidRatios", func(t *testing.T) {
+		_, err := MustParse("1", "USD").Allocate(0, 0)
+		assert.Error(t, err, "Expected an error for zero ratios")
+
+		_, err = MustParse("1", "USD").Allocate(1, -1)
+		assert.Error(t, err, "Expected an error for a negative ratio")
+	})
+
+	// LargeRatios ensures ratios whose products exceed 64 bits are split exactly, and overflowing sums are rejected.
+	t.Run("LargeRatios", func(t *testing.T) {
+		amount, err := New(math.MaxInt64, "USD")
+		assert.NoError(t, err, "Expected no error")
+		parts, err := amount.Allocate(math.MaxInt64/2, math.MaxInt64/2)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2}, []int64{parts[0].Minor(), parts[1].Minor()}, "Unexpected parts")
+
+		negative, err := New(-1000, "USD")
+		assert.NoError(t, err, "Expected no error")
+		parts, err = negative.Allocate(math.MaxInt64-1, 1)
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, int64(-1000), parts[0].Minor()+parts[1].Minor(), "Expected the parts to add up")
+
+		_, err = amount.Allocate(math.MaxInt64, 1)
+		assert.ErrorIs(t, err, ErrOverflow, "Expected an overflowing sum of ratios to be rejected")
+	})
+}
+
+// TestJSON verifies that amounts survive a JSON round trip as strings.
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	type order struct {
+		Total Money `json:"total"`
+	}
+
+	data, err := json.Marshal(order{Total: MustParse("19.99", "EUR")})
+	assert.NoError(t, err, "Expected no error")
+	assert.JSONEq(t, `{"total":{"amount":"19.99","currency":"EUR"}}`, string(data), "Unexpected JSON")
+
+	var decoded order
+	assert.NoError(t, json.Unmarshal(data, &decoded), "Expected no error")
+	assert.Equal(t, MustParse("19.99", "EUR"), decoded.Total, "Unexpected decoded amount")
+
+	err = json.Unmarshal([]byte(`{"total":{"amount":"1.999","currency":"EUR"}}`), &decoded)
+	assert.ErrorIs(t, err, ErrInvalidAmount, "Expected invalid amounts to be rejected")
+
+	// Zero ensures an unset amount survives a round trip.
+	t.Run("Zero", func(t *testing.T) {
+		data, err := json.Marshal(order{})
+		assert.NoError(t, err, "Expected no error")
+		assert.JSONEq(t, `{"total":null}`, string(data), "Expected the zero value to be null")
+
+		decoded := order{Total: MustParse("1", "EUR")}
+		assert.NoError(t, json.Unmarshal(data, &decoded), "Expected no error")
+		assert.Equal(t, MustParse("1", "EUR"), decoded.Total, "Expected null to leave the value unchanged")
+
+		decoded = order{}
+		assert.NoError(t, json.Unmarshal([]byte(`{"total":{"amount":"0","currency":""}}`), &decoded), "Expected no error")
+		assert.Equal(t, Money{}, decoded.Total, "Expected a zero amount without currency to decode to the zero value")
+	})
+}
@@ -0,0 +1,184 @@
+package netx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HostIPEnv overrides the address returned by PrimaryOutboundIP, for example with the pod IP
+	// injected by Kubernetes when the detected address is not the one peers should use.
+	HostIPEnv = "HOST_IP"
+	// HostFQDNEnv overrides the name returned by FQDN.
+	HostFQDNEnv = "HOST_FQDN"
+)
+
+// fqdnLookupTimeout limits the DNS lookups of FQDN.
+const fqdnLookupTimeout = 2 * time.Second
+
+// hostInfoTTL is how long LocalIPs, PrimaryOutboundIP and FQDN reuse a successful result, so address
+// changes, for example by DHCP, are picked up while frequent calls stay cheap.
+const hostInfoTTL = time.Minute
+
+// hostInfo caches the successful result of compute for hostInfoTTL. Failures are not cached, so a call
+// made before the network is up does not fail for the lifetime of the process.
+type hostInfo[T any] struct {
+	// compute determines the value.
+	compute func() (T, error)
+	// mu guards the fields below.
+	mu sync.Mutex
+	// value is the cached result, valid until expires.
+	value T
+	// expires is the time the cached value becomes stale; zero if nothing is cached.
+	expires time.Time
+}
+
+// get returns the cached value, computing it again when it is missing or stale.
+func (h *hostInfo[T]) get() (T, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if time.Now().Before(h.expires) {
+		return h.value, nil
+	}
+
+	value, err := h.compute()
+	if err != nil {
+		return value, err
+	}
+	h.value, h.expires = value, time.Now().Add(hostInfoTTL)
+
+	return value, nil
+}
+
+// Caches of the host information.
+var (
+	localIPs   = &hostInfo[[]netip.Addr]{compute: listLocalIPs}
+	outboundIP = &hostInfo[netip.Addr]{compute: primaryOutboundIP}
+	hostFQDN   = &hostInfo[string]{compute: fqdn}
+)
+
+// LocalIPs returns the unicast addresses of the network interfaces that are up, excluding loopback,
+// in the order the system reports them. A successful result is cached for a minute.
+func LocalIPs() ([]netip.Addr, error) {
+	ips, err := localIPs.get()
+	// Hand out a copy, so callers cannot modify the cache.
+	return append([]netip.Addr(nil), ips...), err
+}
+
+// listLocalIPs collects the addresses of the interfaces.
+func listLocalIPs() ([]netip.Addr, error) {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	var ips []netip.Addr
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range addrs {
+			prefix, err := netip.ParsePrefix(addr.String())
+			if err != nil {
+				continue
+			}
+			if ip := prefix.Addr().Unmap(); ip.IsGlobalUnicast() || ip.IsPrivate() {
+				ips = append(ips, ip)
+			}
+		}
+	}
+
+	return ips, nil
+}
+
+// PrimaryOutboundIP returns the local address used for connections to the internet, which identifies
+// the instance to its peers. It asks the routing table by preparing a UDP socket towards a documentation
+// address; no packet is sent. The HOST_IP environment variable takes precedence when set.
+// A successful result is cached for a minute.
+func PrimaryOutboundIP() (netip.Addr, error) {
+	return outboundIP.get()
+}
+
+// primaryOutboundIP determines the outbound address without caching.
+func primaryOutboundIP() (netip.Addr, error) {
+	if value := os.Getenv(HostIPEnv); value != "" {
+		ip, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("netx: invalid %s: %w", HostIPEnv, err)
+		}
+		return ip, nil
+	}
+
+	// Connecting a UDP socket only selects the route and the local address.
+	conn, err := net.Dial("udp", "192.0.2.1:80")
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer conn.Close()
+
+	addr, ok := conn.LocalAddr().(*net.UDPAddr)
+	if !ok {
+		return netip.Addr{}, errors.New("netx: unexpected local address type")
+	}
+	ip, _ := netip.AddrFromSlice(addr.IP)
+
+	return ip.Unmap(), nil
+}
+
+// FQDN returns the fully qualified domain name of the host, such as "worker-1.eu.example.com".
+// If the hostname has no domain, the name is looked up through the addresses it resolves to; when that
+// fails, the plain hostname is returned. The HOST_FQDN environment variable takes precedence when set.
+// A successful result is cached for a minute.
+func FQDN() (string, error) {
+	return hostFQDN.get()
+}
+
+// fqdn determines the name without caching.
+func fqdn() (string, error) {
+	if value := os.Getenv(HostFQDNEnv); value != "" {
+		return value, nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(hostname, ".") {
+		return hostname, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), fqdnLookupTimeout)
+	defer cancel()
+
+	// Resolve the hostname and look for a qualified name pointing back at one of its addresses.
+	addrs, err := net.DefaultResolver.LookupHost(ctx, hostname)
+	if err != nil {
+		return hostname, nil
+	}
+	for _, addr := range addrs {
+		names, err := net.DefaultResolver.LookupAddr(ctx, addr)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if name = strings.TrimSuffix(name, "."); strings.Contains(name, ".") {
+				return name, nil
+			}
+		}
+	}
+
+	return hostname, nil
+}
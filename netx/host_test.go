@@ -0,0 +1,109 @@
+package netx
+
+import (
+	"errors"
+	"net/netip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestLocalIPs verifies that loopback addresses are excluded.
+func TestLocalIPs(t *testing.T) {
+	ips, err := LocalIPs()
+	assert.NoError(t, err, "Expected no error")
+
+	for _, ip := range ips {
+		assert.False(t, ip.IsLoopback(), "Expected no loopback address, got %s", ip)
+	}
+}
+
+// TestPrimaryOutboundIP verifies the detection and the override of the outbound address.
+func TestPrimaryOutboundIP(t *testing.T) {
+	// Override ensures the environment variable takes precedence.
+	t.Run("Override", func(t *testing.T) {
+		t.Setenv(HostIPEnv, "10.1.2.3")
+
+		ip, err := primaryOutboundIP()
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, netip.MustParseAddr("10.1.2.3"), ip, "Expected the overridden address")
+	})
+
+	// InvalidOverride ensures malformed overrides are reported.
+	t.Run("InvalidOverride", func(t *testing.T) {
+		t.Setenv(HostIPEnv, "not-an-ip")
+
+		_, err := primaryOutboundIP()
+		assert.Error(t, err, "Expected an error")
+	})
+
+	// Detected ensures a detected address is a valid non-loopback address.
+	t.Run("Detected", func(t *testing.T) {
+		t.Setenv(HostIPEnv, "")
+
+		ip, err := primaryOutboundIP()
+		if err != nil {
+			t.Skipf("No route to the internet: %v", err)
+		}
+		assert.True(t, ip.IsValid(), "Expected a valid address")
+		assert.False(t, ip.IsLoopback(), "Expected no loopback address")
+	})
+}
+
+// TestFQDN verifies the detection and the override of the host name.
+func TestFQDN(t *testing.T) {
+	// Override ensures the environment variable takes precedence.
+	t.Run("Override", func(t *testing.T) {
+		t.Setenv(HostFQDNEnv, "worker-1.example.com")
+
+		name, err := fqdn()
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "worker-1.example.com", name, "Expected the overridden name")
+	})
+
+	// Detected ensures a name is found, at least the hostname.
+	t.Run("Detected", func(t *testing.T) {
+		t.Setenv(HostFQDNEnv, "")
+
+		if _, err := os.Hostname(); err != nil {
+			t.Skipf("No hostname: %v", err)
+		}
+
+		name, err := fqdn()
+		assert.NoError(t, err, "Expected no error")
+		assert.NotEmpty(t, name, "Expected a name")
+	})
+}
+
+// TestHostInfo verifies that successful results are cached for a while and failures are not cached.
+func TestHostInfo(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	fail := true
+	info := &hostInfo[int]{compute: func() (int, error) {
+		calls++
+		if fail {
+			return 0, errors.New("network is down")
+		}
+		return calls, nil
+	}}
+
+	_, err := info.get()
+	assert.Error(t, err, "Expected the first failure")
+
+	fail = false
+	value, err := info.get()
+	assert.NoError(t, err, "Expected the failure not to be cached")
+	assert.Equal(t, 2, value, "Unexpected value")
+
+	value, _ = info.get()
+	assert.Equal(t, 2, value, "Expected the cached value")
+
+	// Let the cached value expire.
+	info.expires = time.Now().Add(-time.Second)
+	value, _ = info.get()
+	assert.Equal(t, 3, value, "Expected the stale value to be computed again")
+}
@@ -0,0 +1,155 @@
+package netx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common"
+)
+
+// portPollInterval is the delay between connection attempts of WaitForPort.
+const portPollInterval = 50 * time.Millisecond
+
+// ErrInvalidPort is returned by ParseHostPort for ports that are not numbers between 1 and 65535.
+var ErrInvalidPort = errors.New("netx: invalid port")
+
+// WaitForPort waits until a TCP connection to addr, such as "localhost:8080", can be established,
+// for example for a server spawned by a test. It returns nil as soon as a connection succeeds and the
+// error of ctx, joined with the last connection error, when ctx is done first.
+func WaitForPort(ctx context.Context, addr string) error {
+	var dialer net.Dialer
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			return conn.Close()
+		}
+
+		if sleepErr := common.SleepCtx(ctx, portPollInterval); sleepErr != nil {
+			return errors.Join(sleepErr, err)
+		}
+	}
+}
+
+// GetFreePort returns a TCP port on the loopback interface that is free at the time of the call,
+// for starting a server in a test. Another process may take the port before it is used, so servers
+// that can listen on port zero and report their address should prefer that.
+func GetFreePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
+
+// nonPublicPrefixes lists the ranges that are not reachable on the public internet, beyond those
+// recognized by the methods of netip.Addr, from the IANA special-purpose address registries.
+var nonPublicPrefixes = []netip.Prefix{
+	// Shared address space used for carrier-grade NAT.
+	netip.MustParsePrefix("100.64.0.0/10"),
+	// The "this network" range.
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// IETF protocol assignments.
+	netip.MustParsePrefix("192.0.0.0/24"),
+	// Benchmarking networks.
+	netip.MustParsePrefix("198.18.0.0/15"),
+	// Reserved for future use, including the limited broadcast address 255.255.255.255.
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// Prefixes of IPv6 addresses embedding an IPv4 address, which a gateway may translate to that address.
+var (
+	// nat64Prefix is the well-known NAT64 prefix, with the IPv4 address in the last four bytes.
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	// sixToFourPrefix is the 6to4 prefix, with the IPv4 address in the bytes following the prefix.
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// IsPrivateIP reports whether ip is an address that does not belong to the public internet:
+// private ranges (RFC 1918 and IPv6 unique local addresses), loopback, link-local, unspecified,
+// carrier-grade NAT, "this network", IETF protocol assignment, benchmarking and reserved addresses.
+// IPv4-mapped IPv6 addresses are checked as IPv4, and so are the IPv4 addresses embedded in NAT64
+// and 6to4 addresses, which can reach internal hosts such as 169.254.169.254 through a gateway.
+// Guards against server-side request forgery use it to refuse connections to internal services;
+// the address must be checked after DNS resolution, at the time of connecting.
+func IsPrivateIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() {
+		return false
+	}
+
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() {
+		return true
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+
+	// Check the IPv4 address a translating gateway would connect to.
+	raw := ip.As16()
+	switch {
+	case nat64Prefix.Contains(ip):
+		return IsPrivateIP(netip.AddrFrom4([4]byte(raw[12:16])))
+	case sixToFourPrefix.Contains(ip):
+		return IsPrivateIP(netip.AddrFrom4([4]byte(raw[2:6])))
+	}
+
+	return false
+}
+
+// CIDRContains reports whether the address ip lies within the network cidr, such as "10.0.0.0/8".
+// IPv4-mapped IPv6 addresses match IPv4 networks. An error is returned if either argument is malformed.
+func CIDRContains(cidr, ip string) (bool, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return false, err
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false, err
+	}
+
+	return prefix.Contains(addr.Unmap()), nil
+}
+
+// ParseHostPort splits an address such as "example.com:8080" into host and port, using defaultPort when
+// the address has no port. IPv6 addresses may be given with or without brackets, as in "[::1]:8080",
+// "[::1]" or "::1"; the returned host has no brackets. An error is returned for an empty host or an
+// invalid port.
+func ParseHostPort(addr string, defaultPort int) (string, int, error) {
+	host, portText, err := net.SplitHostPort(addr)
+	if err != nil {
+		// Without a port, the whole address is the host; a bare IPv6 address has too many colons for SplitHostPort.
+		host, portText = strings.TrimSuffix(strings.TrimPrefix(addr, "["), "]"), ""
+		if strings.Contains(host, ":") {
+			if _, parseErr := netip.ParseAddr(host); parseErr != nil {
+				return "", 0, err
+			}
+		}
+	}
+	if host == "" {
+		return "", 0, fmt.Errorf("netx: missing host in %q", addr)
+	}
+
+	if portText == "" {
+		portText = strconv.Itoa(defaultPort)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidPort, portText)
+	}
+
+	return host, port, nil
+}
@@ -0,0 +1,163 @@
+package netx
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWaitForPort verifies waiting for a server to accept connections.
+func TestWaitForPort(t *testing.T) {
+	t.Parallel()
+
+	// Ready ensures WaitForPort returns once the server listens.
+	t.Run("Ready", func(t *testing.T) {
+		port, err := GetFreePort()
+		assert.NoError(t, err, "Expected a free port")
+		addr := "127.0.0.1:" + strconv.Itoa(port)
+
+		// Start listening only after WaitForPort has begun polling. The kernel completes connections
+		// to a listening socket, so no Accept is needed.
+		listeners := make(chan net.Listener, 1)
+		go func() {
+			defer close(listeners)
+			time.Sleep(100 * time.Millisecond)
+			if listener, err := net.Listen("tcp", addr); err == nil {
+				listeners <- listener
+			}
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		assert.NoError(t, WaitForPort(ctx, addr), "Expected the port to become ready")
+		if listener, ok := <-listeners; ok {
+			listener.Close()
+		}
+	})
+
+	// Timeout ensures WaitForPort gives up when ctx is done.
+	t.Run("Timeout", func(t *testing.T) {
+		port, err := GetFreePort()
+		assert.NoError(t, err, "Expected a free port")
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err = WaitForPort(ctx, "127.0.0.1:"+strconv.Itoa(port))
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the deadline to be exceeded")
+	})
+}
+
+// TestIsPrivateIP verifies the classification of addresses.
+func TestIsPrivateIP(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		ip       string
+		expected bool
+	}{
+		{ip: "10.1.2.3", expected: true},
+		{ip: "172.16.0.1", expected: true},
+		{ip: "192.168.1.1", expected: true},
+		{ip: "127.0.0.1", expected: true},
+		{ip: "169.254.169.254", expected: true},
+		{ip: "100.64.0.1", expected: true},
+		{ip: "0.0.0.0", expected: true},
+		{ip: "::1", expected: true},
+		{ip: "fd00::1", expected: true},
+		{ip: "fe80::1", expected: true},
+		{ip: "::ffff:10.0.0.1", expected: true},
+		{ip: "192.0.0.8", expected: true},
+		{ip: "198.18.0.1", expected: true},
+		{ip: "198.19.255.254", expected: true},
+		{ip: "240.0.0.1", expected: true},
+		{ip: "255.255.255.255", expected: true},
+		{ip: "64:ff9b::a9fe:a9fe", expected: true},
+		{ip: "64:ff9b::10.0.0.1", expected: true},
+		{ip: "64:ff9b::8.8.8.8", expected: false},
+		{ip: "2002:a9fe:a9fe::1", expected: true},
+		{ip: "2002:c0a8:0101::1", expected: true},
+		{ip: "2002:0808:0808::1", expected: false},
+		{ip: "8.8.8.8", expected: false},
+		{ip: "198.20.0.1", expected: false},
+		{ip: "172.32.0.1", expected: false},
+		{ip: "2001:4860:4860::8888", expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.ip, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsPrivateIP(netip.MustParseAddr(tt.ip)), "Test case %s failed", tt.ip)
+		})
+	}
+
+	assert.False(t, IsPrivateIP(netip.Addr{}), "Expected the zero address not to be private")
+}
+
+// TestCIDRContains verifies network membership checks.
+func TestCIDRContains(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		cidr     string
+		ip       string
+		expected bool
+		err      bool
+	}{
+		{name: "Inside", cidr: "10.0.0.0/8", ip: "10.20.30.40", expected: true},
+		{name: "Outside", cidr: "10.0.0.0/8", ip: "11.0.0.1", expected: false},
+		{name: "IPv6", cidr: "2001:db8::/32", ip: "2001:db8::1", expected: true},
+		{name: "Mapped IPv4", cidr: "192.168.0.0/16", ip: "::ffff:192.168.1.1", expected: true},
+		{name: "Invalid CIDR", cidr: "10.0.0.0/33", ip: "10.0.0.1", err: true},
+		{name: "Invalid IP", cidr: "10.0.0.0/8", ip: "ten", err: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			contains, err := CIDRContains(tt.cidr, tt.ip)
+
+			assert.Equal(t, tt.err, err != nil, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, contains, "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestParseHostPort verifies splitting addresses with default ports.
+func TestParseHostPort(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		addr string
+		host string
+		port int
+		err  bool
+	}{
+		{name: "Host and port", addr: "example.com:8080", host: "example.com", port: 8080},
+		{name: "Host only", addr: "example.com", host: "example.com", port: 443},
+		{name: "IPv4 only", addr: "10.0.0.1", host: "10.0.0.1", port: 443},
+		{name: "Bracketed IPv6 and port", addr: "[::1]:80", host: "::1", port: 80},
+		{name: "Bracketed IPv6", addr: "[::1]", host: "::1", port: 443},
+		{name: "Bare IPv6", addr: "2001:db8::1", host: "2001:db8::1", port: 443},
+		{name: "Empty host", addr: ":80", err: true},
+		{name: "Invalid port", addr: "example.com:http", err: true},
+		{name: "Port out of range", addr: "example.com:70000", err: true},
+		{name: "Too many colons", addr: "a:b:c", err: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port, err := ParseHostPort(tt.addr, 443)
+
+			assert.Equal(t, tt.err, err != nil, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.host, host, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.port, port, "Test case %s failed", tt.name)
+		})
+	}
+}
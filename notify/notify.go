@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Message is a notification sent by a Notifier.
+type Message struct {
+	// Title is a short summary, rendered in bold where the target supports it. It may be empty.
+	Title string `json:"title,omitempty"`
+	// Text is the body of the notification.
+	Text string `json:"text"`
+}
+
+// Notifier sends notifications to a single target, such as a chat or a webhook endpoint.
+type Notifier interface {
+	// Notify sends the message and returns an error if the target did not accept it.
+	Notify(ctx context.Context, msg Message) error
+}
+
+// StatusError is returned when the target responds with a non-2xx status code.
+type StatusError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Body holds the beginning of the response body, which usually explains the failure.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("notify: unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// maxErrorBody limits how much of an error response body is kept in a StatusError.
+const maxErrorBody = 512
+
+// Webhook is a Notifier posting the message as JSON to a generic webhook endpoint.
+type Webhook struct {
+	// URL is the endpoint the message is posted to.
+	URL string
+	// Header holds additional request headers, for example an authorization token.
+	Header http.Header
+	// Client sends the requests. When nil, http.DefaultClient is used, so retries and rate limits
+	// are configured by passing a client with the corresponding transport.
+	Client *http.Client
+}
+
+// Notify posts the message as a JSON object with "title" and "text" fields.
+func (w *Webhook) Notify(ctx context.Context, msg Message) error {
+	return postJSON(ctx, w.Client, w.URL, w.Header, msg)
+}
+
+// Slack is a Notifier posting messages to a Slack incoming webhook.
+type Slack struct {
+	// WebhookURL is the incoming webhook URL created in the Slack app settings.
+	WebhookURL string
+	// Client sends the requests. When nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Notify posts the message to the Slack channel of the webhook, with the title in bold.
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	text := msg.Text
+	if msg.Title != "" {
+		text = "*" + msg.Title + "*\n" + msg.Text
+	}
+
+	return postJSON(ctx, s.Client, s.WebhookURL, nil, map[string]string{"text": text})
+}
+
+// TelegramAPI is the base URL of the Telegram Bot API.
+const TelegramAPI = "https://api.telegram.org"
+
+// Telegram is a Notifier sending messages to a Telegram chat through a bot.
+type Telegram struct {
+	// Token is the bot token issued by BotFather.
+	Token string
+	// ChatID identifies the chat, either numerically or as "@channelname".
+	ChatID string
+	// BaseURL overrides TelegramAPI, for example for a local Bot API server.
+	BaseURL string
+	// Client sends the requests. When nil, http.DefaultClient is used.
+	Client *http.Client
+}
+
+// Notify sends the message to the chat, with the title in bold.
+func (t *Telegram) Notify(ctx context.Context, msg Message) error {
+	baseURL := t.BaseURL
+	if baseURL == "" {
+		baseURL = TelegramAPI
+	}
+
+	// Send plain text unless there is a title, which needs HTML markup and therefore escaping.
+	payload := map[string]string{"chat_id": t.ChatID, "text": msg.Text}
+	if msg.Title != "" {
+		payload["text"] = "<b>" + escapeHTML(msg.Title) + "</b>\n" + escapeHTML(msg.Text)
+		payload["parse_mode"] = "HTML"
+	}
+
+	return postJSON(ctx, t.Client, strings.TrimSuffix(baseURL, "/")+"/bot"+t.Token+"/sendMessage", nil, payload)
+}
+
+// Multi is a Notifier sending every message to all of its notifiers.
+// A failure of one notifier does not prevent sending to the others; the errors are joined.
+type Multi []Notifier
+
+// Notify sends the message to all notifiers.
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	errs := make([]error, 0, len(m))
+	for _, notifier := range m {
+		errs = append(errs, notifier.Notify(ctx, msg))
+	}
+
+	return errors.Join(errs...)
+}
+
+// postJSON posts payload encoded as JSON to target and checks the response status.
+func postJSON(ctx context.Context, client *http.Client, target string, header http.Header, payload any) error {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("notify: encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("notify: %w", redactURL(err))
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("notify: %w", redactURL(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		// Keep the beginning of the body, as targets describe the failure there.
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// redactURL removes the path and query from the URL of a *url.Error. The URLs of Slack webhooks and the
+// Telegram Bot API carry the credentials, which must not end up in logs when a request fails.
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	redacted := "<redacted>"
+	if parsed, parseErr := url.Parse(urlErr.URL); parseErr == nil && parsed.Host != "" {
+		redacted = parsed.Scheme + "://" + parsed.Host
+	}
+
+	return &url.Error{Op: urlErr.Op, URL: redacted, Err: urlErr.Err}
+}
+
+// htmlEscaper escapes the characters Telegram requires to be escaped in HTML messages.
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// escapeHTML escapes text for a Telegram message in HTML parse mode.
+func escapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is an HTTP handler recording the path and JSON body of the last request.
+type recorder struct {
+	status int
+	path   string
+	header http.Header
+	body   map[string]string
+}
+
+// ServeHTTP records the request and responds with the configured status.
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.path = req.URL.Path
+	r.header = req.Header
+	_ = json.NewDecoder(req.Body).Decode(&r.body)
+
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+		_, _ = w.Write([]byte("invalid payload\n"))
+	}
+}
+
+// newServer starts a test server with a recorder that is closed when the test completes.
+func newServer(t *testing.T, status int) (*httptest.Server, *recorder) {
+	rec := &recorder{status: status}
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+
+	return server, rec
+}
+
+// TestWebhook verifies that the message is posted as JSON with the configured headers.
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+
+	server, rec := newServer(t, 0)
+	notifier := &Webhook{URL: server.URL + "/hook", Header: http.Header{"Authorization": {"Bearer token"}}}
+
+	err := notifier.Notify(context.Background(), Message{Title: "Deploy", Text: "done"})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "/hook", rec.path, "Unexpected path")
+	assert.Equal(t, "Bearer token", rec.header.Get("Authorization"), "Expected the custom header")
+	assert.Equal(t, "application/json", rec.header.Get("Content-Type"), "Expected a JSON request")
+	assert.Equal(t, map[string]string{"title": "Deploy", "text": "done"}, rec.body, "Unexpected payload")
+}
+
+// TestSlack verifies the Slack payload format.
+func TestSlack(t *testing.T) {
+	t.Parallel()
+
+	server, rec := newServer(t, 0)
+	notifier := &Slack{WebhookURL: server.URL}
+
+	err := notifier.Notify(context.Background(), Message{Title: "Deploy", Text: "done"})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, map[string]string{"text": "*Deploy*\ndone"}, rec.body, "Unexpected payload")
+}
+
+// TestTelegram verifies the Bot API path and the escaping of HTML messages.
+func TestTelegram(t *testing.T) {
+	t.Parallel()
+
+	// WithTitle ensures titled messages are sent as escaped HTML.
+	t.Run("WithTitle", func(t *testing.T) {
+		server, rec := newServer(t, 0)
+		notifier := &Telegram{Token: "123:abc", ChatID: "42", BaseURL: server.URL}
+
+		err := notifier.Notify(context.Background(), Message{Title: "Build <main>", Text: "a & b"})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "/bot123:abc/sendMessage", rec.path, "Unexpected path")
+		assert.Equal(t, map[string]string{
+			"chat_id":    "42",
+			"text":       "<b>Build &lt;main&gt;</b>\na &amp; b",
+			"parse_mode": "HTML",
+		}, rec.body, "Unexpected payload")
+	})
+
+	// PlainText ensures messages without a title are sent as is.
+	t.Run("PlainText", func(t *testing.T) {
+		server, rec := newServer(t, 0)
+		notifier := &Telegram{Token: "123:abc", ChatID: "42", BaseURL: server.URL}
+
+		err := notifier.Notify(context.Background(), Message{Text: "a < b"})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, map[string]string{"chat_id": "42", "text": "a < b"}, rec.body, "Unexpected payload")
+	})
+}
+
+// TestStatusError verifies that non-2xx responses are reported with their status and body.
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newServer(t, http.StatusBadRequest)
+
+	err := (&Webhook{URL: server.URL}).Notify(context.Background(), Message{Text: "done"})
+
+	var statusErr *StatusError
+	assert.True(t, errors.As(err, &statusErr), "Expected a StatusError")
+	assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode, "Unexpected status")
+	assert.Equal(t, "invalid payload", statusErr.Body, "Expected the response body")
+}
+
+// TestCredentialsRedacted verifies that failed requests do not report the secret parts of the URL.
+func TestCredentialsRedacted(t *testing.T) {
+	t.Parallel()
+
+	// A closed server makes the request fail after the URL has been parsed.
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	cases := []struct {
+		name     string
+		notifier Notifier
+		secret   string
+	}{
+		{name: "Telegram token", notifier: &Telegram{Token: "123:secret-token", ChatID: "42", BaseURL: server.URL}, secret: "secret-token"},
+		{name: "Slack webhook", notifier: &Slack{WebhookURL: server.URL + "/services/T0/B0/secret-hook"}, secret: "secret-hook"},
+		{name: "Invalid URL", notifier: &Slack{WebhookURL: "https://hooks.example.com/secret-hook\x7f"}, secret: "secret-hook"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.notifier.Notify(context.Background(), Message{Text: "done"})
+
+			assert.Error(t, err, "Expected an error for case: %s", tt.name)
+			assert.NotContains(t, err.Error(), tt.secret, "Expected the secret to be redacted for case: %s", tt.name)
+		})
+	}
+}
+
+// TestMulti verifies that all notifiers are called and their errors joined.
+func TestMulti(t *testing.T) {
+	t.Parallel()
+
+	okServer, okRec := newServer(t, 0)
+	failServer, _ := newServer(t, http.StatusInternalServerError)
+
+	notifier := Multi{&Webhook{URL: failServer.URL}, &Webhook{URL: okServer.URL}}
+
+	err := notifier.Notify(context.Background(), Message{Text: "done"})
+	assert.Error(t, err, "Expected the failing notifier to be reported")
+	assert.Equal(t, "done", okRec.body["text"], "Expected the other notifier to be called")
+}
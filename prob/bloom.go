@@ -0,0 +1,129 @@
+package prob
+
+import (
+	"errors"
+	"math"
+)
+
+// Bloom is a space-efficient probabilistic set. It answers "definitely not added" or "probably added":
+// false negatives never happen, while false positives occur at a rate bounded by the configured probability
+// as long as no more than the expected number of elements is added.
+// Bloom is not safe for concurrent use.
+type Bloom struct {
+	// bits is the bit array backing the filter.
+	bits []uint64
+	// size is the number of bits in the filter.
+	size uint64
+	// hashes is the number of bit positions set for every element.
+	hashes uint64
+}
+
+// NewBloom creates a Bloom filter sized for the expected number of elements and the desired
+// false positive rate, which must be in the open range (0, 1). The optimal number of bits and hash
+// functions is derived from these values. An expected count lower than one is raised to one.
+func NewBloom(expected int, falsePositiveRate float64) (*Bloom, error) {
+	// The false positive rate must be a probability strictly between 0 and 1.
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, errors.New("false positive rate must be between 0 and 1")
+	}
+
+	if expected < 1 {
+		expected = 1
+	}
+
+	// m = -n*ln(p) / ln(2)^2 bits and k = m/n * ln(2) hash functions minimize the false positive rate.
+	n := float64(expected)
+	size := uint64(math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := uint64(math.Max(1, math.Round(float64(size)/n*math.Ln2)))
+
+	return &Bloom{
+		bits:   make([]uint64, (size+63)/64),
+		size:   size,
+		hashes: hashes,
+	}, nil
+}
+
+// Add inserts the element into the filter.
+func (b *Bloom) Add(data []byte) {
+	h1, h2 := b.baseHashes(data)
+
+	// Derive the bit positions with double hashing: position_i = h1 + i*h2.
+	for i := uint64(0); i < b.hashes; i++ {
+		position := (h1 + i*h2) % b.size
+		b.bits[position/64] |= 1 << (position % 64)
+	}
+}
+
+// AddString inserts the string into the filter.
+func (b *Bloom) AddString(data string) {
+	b.Add([]byte(data))
+}
+
+// Test reports whether the element may have been added. A false result is definitive,
+// while a true result is correct with the probability configured at creation.
+func (b *Bloom) Test(data []byte) bool {
+	h1, h2 := b.baseHashes(data)
+
+	// The element is absent as soon as one of its bits is not set.
+	for i := uint64(0); i < b.hashes; i++ {
+		position := (h1 + i*h2) % b.size
+		if b.bits[position/64]&(1<<(position%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TestString reports whether the string may have been added.
+func (b *Bloom) TestString(data string) bool {
+	return b.Test([]byte(data))
+}
+
+// TestAndAdd reports whether the element may have been added before and adds it in the same pass,
+// which is the typical "seen before?" deduplication check.
+func (b *Bloom) TestAndAdd(data []byte) bool {
+	present := b.Test(data)
+	b.Add(data)
+
+	return present
+}
+
+// Merge adds all elements of the other filter to this one. Both filters must have been created
+// with the same parameters, otherwise an error is returned.
+func (b *Bloom) Merge(other *Bloom) error {
+	if b.size != other.size || b.hashes != other.hashes {
+		return errors.New("bloom filters have different parameters")
+	}
+
+	for i := range b.bits {
+		b.bits[i] |= other.bits[i]
+	}
+
+	return nil
+}
+
+// Reset clears the filter.
+func (b *Bloom) Reset() {
+	for i := range b.bits {
+		b.bits[i] = 0
+	}
+}
+
+// Size returns the number of bits in the filter.
+func (b *Bloom) Size() uint64 {
+	return b.size
+}
+
+// Hashes returns the number of bit positions set for every element.
+func (b *Bloom) Hashes() uint64 {
+	return b.hashes
+}
+
+// baseHashes returns the two hashes used for double hashing. The second hash is forced to be odd
+// so that it never degenerates to zero, which would map every position to the same bit.
+func (b *Bloom) baseHashes(data []byte) (h1, h2 uint64) {
+	h := hash64(data)
+
+	return h, mix64(h) | 1
+}
@@ -0,0 +1,86 @@
+package prob
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBloom verifies the guarantees of the Bloom filter.
+func TestBloom(t *testing.T) {
+	t.Parallel()
+
+	// InvalidRate ensures false positive rates outside (0, 1) are rejected.
+	t.Run("InvalidRate", func(t *testing.T) {
+		for _, rate := range []float64{0, 1, -0.5, 2} {
+			_, err := NewBloom(100, rate)
+			assert.Error(t, err, "Expected an error for rate %v", rate)
+		}
+	})
+
+	// Sizing ensures the filter derives sensible parameters.
+	t.Run("Sizing", func(t *testing.T) {
+		bloom, err := NewBloom(1000, 0.01)
+		assert.NoError(t, err, "Expected no error for valid parameters")
+
+		// About 9.6 bits and 7 hashes per element are optimal for a 1% false positive rate.
+		assert.Equal(t, uint64(9586), bloom.Size(), "Unexpected number of bits")
+		assert.Equal(t, uint64(7), bloom.Hashes(), "Unexpected number of hashes")
+	})
+
+	// NoFalseNegatives ensures every added element is reported as present.
+	t.Run("NoFalseNegatives", func(t *testing.T) {
+		bloom, _ := NewBloom(1000, 0.01)
+		for i := 0; i < 1000; i++ {
+			bloom.AddString(strconv.Itoa(i))
+		}
+
+		for i := 0; i < 1000; i++ {
+			assert.True(t, bloom.TestString(strconv.Itoa(i)), "Expected element %d to be present", i)
+		}
+	})
+
+	// FalsePositiveRate ensures the observed false positive rate stays close to the configured one.
+	t.Run("FalsePositiveRate", func(t *testing.T) {
+		bloom, _ := NewBloom(10000, 0.01)
+		for i := 0; i < 10000; i++ {
+			bloom.AddString("in-" + strconv.Itoa(i))
+		}
+
+		falsePositives := 0
+		for i := 0; i < 10000; i++ {
+			if bloom.TestString("out-" + strconv.Itoa(i)) {
+				falsePositives++
+			}
+		}
+
+		assert.Less(t, float64(falsePositives)/10000, 0.02, "Expected the false positive rate to stay near 1%")
+	})
+
+	// TestAndAdd ensures the first call reports absence and the second reports presence.
+	t.Run("TestAndAdd", func(t *testing.T) {
+		bloom, _ := NewBloom(100, 0.01)
+
+		assert.False(t, bloom.TestAndAdd([]byte("job-1")), "Expected the first check to report absence")
+		assert.True(t, bloom.TestAndAdd([]byte("job-1")), "Expected the second check to report presence")
+	})
+
+	// MergeAndReset ensures merging combines the elements and reset clears them.
+	t.Run("MergeAndReset", func(t *testing.T) {
+		first, _ := NewBloom(100, 0.01)
+		second, _ := NewBloom(100, 0.01)
+		first.AddString("a")
+		second.AddString("b")
+
+		assert.NoError(t, first.Merge(second), "Expected filters with the same parameters to merge")
+		assert.True(t, first.TestString("a"), "Expected a to be present after merge")
+		assert.True(t, first.TestString("b"), "Expected b to be present after merge")
+
+		other, _ := NewBloom(1000, 0.01)
+		assert.Error(t, first.Merge(other), "Expected filters with different parameters to be rejected")
+
+		first.Reset()
+		assert.False(t, first.TestString("a"), "Expected the filter to be empty after reset")
+	})
+}
@@ -0,0 +1,26 @@
+package prob
+
+import "hash/fnv"
+
+// hash64 returns a well-distributed 64-bit hash of data.
+// FNV-1a is fast but its high bits are poorly mixed for short inputs, so the result is passed
+// through the MurmurHash3 finalizer, which probabilistic structures rely on for uniform bit patterns.
+// The hash is deterministic across processes, so structures built in different places can be merged.
+func hash64(data []byte) uint64 {
+	h := fnv.New64a()
+	// Writing to an FNV hash never returns an error.
+	_, _ = h.Write(data)
+
+	return mix64(h.Sum64())
+}
+
+// mix64 applies the MurmurHash3 64-bit finalizer to spread the entropy of x over all bits.
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+
+	return x
+}
@@ -0,0 +1,118 @@
+package prob
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinPrecision is the lowest precision accepted by NewHyperLogLog.
+	MinPrecision = 4
+	// MaxPrecision is the highest precision accepted by NewHyperLogLog.
+	MaxPrecision = 18
+)
+
+// HyperLogLog estimates the number of distinct elements added to it using a fixed amount of memory.
+// With precision p it uses 2^p one-byte registers and has a standard error of about 1.04/sqrt(2^p),
+// e.g. around 0.8% for the precision 14 (16 KiB). HyperLogLog is not safe for concurrent use.
+type HyperLogLog struct {
+	// precision is the number of hash bits used to select a register.
+	precision uint8
+	// registers holds the maximum observed rank for every register.
+	registers []uint8
+}
+
+// NewHyperLogLog creates a HyperLogLog with the given precision, which must be between
+// MinPrecision and MaxPrecision inclusive.
+func NewHyperLogLog(precision uint8) (*HyperLogLog, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, errors.New("hyperloglog precision is out of range")
+	}
+
+	return &HyperLogLog{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Add records the element.
+func (h *HyperLogLog) Add(data []byte) {
+	hash := hash64(data)
+
+	// The top p bits select the register, the remaining bits provide the rank.
+	index := hash >> (64 - h.precision)
+	rest := hash<<h.precision | 1<<(h.precision-1)
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+
+	// Registers only keep the maximum rank seen.
+	if rank > h.registers[index] {
+		h.registers[index] = rank
+	}
+}
+
+// AddString records the string.
+func (h *HyperLogLog) AddString(data string) {
+	h.Add([]byte(data))
+}
+
+// Count returns the estimated number of distinct elements added so far.
+func (h *HyperLogLog) Count() uint64 {
+	m := float64(len(h.registers))
+
+	// Compute the harmonic mean of 2^register and count the empty registers.
+	sum := 0.0
+	zeros := 0
+	for _, register := range h.registers {
+		sum += 1 / float64(uint64(1)<<register)
+		if register == 0 {
+			zeros++
+		}
+	}
+
+	estimate := hllAlpha(m) * m * m / sum
+
+	// For small cardinalities the raw estimate is biased, so fall back to linear counting.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(estimate + 0.5)
+}
+
+// Merge combines the other HyperLogLog into this one, so that the result estimates the cardinality
+// of the union of both inputs. Both must have the same precision, otherwise an error is returned.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	if h.precision != other.precision {
+		return errors.New("hyperloglogs have different precisions")
+	}
+
+	for i, register := range other.registers {
+		if register > h.registers[i] {
+			h.registers[i] = register
+		}
+	}
+
+	return nil
+}
+
+// Reset clears all registers.
+func (h *HyperLogLog) Reset() {
+	for i := range h.registers {
+		h.registers[i] = 0
+	}
+}
+
+// hllAlpha returns the bias correction constant for m registers.
+func hllAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
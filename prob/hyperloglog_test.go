@@ -0,0 +1,61 @@
+package prob
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHyperLogLog verifies the cardinality estimates of the HyperLogLog.
+func TestHyperLogLog(t *testing.T) {
+	t.Parallel()
+
+	// InvalidPrecision ensures precisions outside the supported range are rejected.
+	t.Run("InvalidPrecision", func(t *testing.T) {
+		_, err := NewHyperLogLog(MinPrecision - 1)
+		assert.Error(t, err, "Expected an error below the minimum precision")
+
+		_, err = NewHyperLogLog(MaxPrecision + 1)
+		assert.Error(t, err, "Expected an error above the maximum precision")
+	})
+
+	// Empty ensures an empty estimator reports zero.
+	t.Run("Empty", func(t *testing.T) {
+		hll, _ := NewHyperLogLog(14)
+		assert.Zero(t, hll.Count(), "Expected zero distinct elements")
+	})
+
+	// Estimates ensures the estimate stays within a few standard errors for several cardinalities.
+	t.Run("Estimates", func(t *testing.T) {
+		for _, cardinality := range []int{10, 1000, 100000} {
+			hll, _ := NewHyperLogLog(14)
+			for i := 0; i < cardinality; i++ {
+				hll.AddString("element-" + strconv.Itoa(i))
+				// Duplicates must not change the estimate.
+				hll.AddString("element-" + strconv.Itoa(i))
+			}
+
+			assert.InEpsilon(t, cardinality, hll.Count(), 0.03, "Unexpected estimate for cardinality %d", cardinality)
+		}
+	})
+
+	// Merge ensures merging estimates the cardinality of the union.
+	t.Run("Merge", func(t *testing.T) {
+		first, _ := NewHyperLogLog(12)
+		second, _ := NewHyperLogLog(12)
+		for i := 0; i < 6000; i++ {
+			first.AddString(strconv.Itoa(i))
+			second.AddString(strconv.Itoa(i + 4000))
+		}
+
+		assert.NoError(t, first.Merge(second), "Expected estimators with the same precision to merge")
+		assert.InEpsilon(t, 10000, first.Count(), 0.05, "Expected the estimate of the union")
+
+		other, _ := NewHyperLogLog(10)
+		assert.Error(t, first.Merge(other), "Expected estimators with different precisions to be rejected")
+
+		first.Reset()
+		assert.Zero(t, first.Count(), "Expected zero after reset")
+	})
+}
@@ -0,0 +1,42 @@
+package progress
+
+import "io"
+
+// Writer is an io.Writer reporting the number of bytes written through it to a Bar.
+type Writer struct {
+	w   io.Writer
+	bar *Bar
+}
+
+// NewWriter wraps w so that every write adds the number of written bytes to bar.
+// It is typically the destination of io.Copy when downloading or extracting files.
+func NewWriter(w io.Writer, bar *Bar) *Writer {
+	return &Writer{w: w, bar: bar}
+}
+
+// Write writes p to the underlying writer and reports the written bytes.
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.bar.Add(int64(n))
+
+	return n, err
+}
+
+// Reader is an io.Reader reporting the number of bytes read through it to a Bar.
+type Reader struct {
+	r   io.Reader
+	bar *Bar
+}
+
+// NewReader wraps r so that every read adds the number of read bytes to bar.
+func NewReader(r io.Reader, bar *Bar) *Reader {
+	return &Reader{r: r, bar: bar}
+}
+
+// Read reads from the underlying reader into p and reports the read bytes.
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.bar.Add(int64(n))
+
+	return n, err
+}
@@ -0,0 +1,185 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	commonstrings "github.com/SyntaxErrorLineNULL/common/strings"
+	"github.com/SyntaxErrorLineNULL/common/term"
+)
+
+// renderInterval limits how often a bar is redrawn, so frequent small updates do not flood the terminal.
+const renderInterval = 100 * time.Millisecond
+
+// BarOptions configures a Bar.
+type BarOptions struct {
+	// Label is shown before the bar. It is shortened when it does not fit into the line.
+	Label string
+	// Width is the width of the whole line. When zero, the width of the terminal is used.
+	Width int
+	// Force renders the bar even if the output is not a terminal, for example into a log file.
+	Force bool
+}
+
+// Bar renders a progress bar on a single terminal line, redrawn in place as progress is reported.
+// When the output is not a terminal, the bar renders nothing, so programs can report progress
+// unconditionally without garbling redirected output. Bar is safe for concurrent use.
+type Bar struct {
+	// out receives the rendered bar.
+	out io.Writer
+	// total is the amount of work, or zero or less when unknown.
+	total int64
+	// opts holds the configuration of the bar.
+	opts BarOptions
+	// enabled reports whether the bar renders anything.
+	enabled bool
+	// mu guards the fields below.
+	mu sync.Mutex
+	// current is the amount of work done.
+	current int64
+	// lastRender is the time the bar was last drawn.
+	lastRender time.Time
+	// finished reports whether Finish has been called.
+	finished bool
+}
+
+// NewBar creates a Bar tracking total units of work, rendered to out.
+// A total of zero or less renders the amount done without a bar, for work of unknown size.
+func NewBar(out io.Writer, total int64, opts BarOptions) *Bar {
+	if opts.Width <= 0 {
+		opts.Width = term.Width(out)
+	}
+
+	return &Bar{out: out, total: total, opts: opts, enabled: opts.Force || term.IsTerminal(out)}
+}
+
+// Add reports n more units of work done.
+func (b *Bar) Add(n int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current += n
+	b.render(false)
+}
+
+// Set reports the total amount of work done so far.
+func (b *Bar) Set(current int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.current = current
+	b.render(false)
+}
+
+// Current returns the amount of work done so far.
+func (b *Bar) Current() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.current
+}
+
+// Finish draws the final state of the bar and moves the cursor to the next line.
+// Later updates are ignored.
+func (b *Bar) Finish() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.finished {
+		return
+	}
+	b.render(true)
+	b.finished = true
+
+	if b.enabled {
+		_, _ = io.WriteString(b.out, "\n")
+	}
+}
+
+// render draws the bar, at most once per renderInterval unless forced. The caller must hold the mutex.
+func (b *Bar) render(force bool) {
+	if !b.enabled || b.finished {
+		return
+	}
+
+	now := time.Now()
+	if !force && now.Sub(b.lastRender) < renderInterval {
+		return
+	}
+	b.lastRender = now
+
+	// Rendering is best effort; a broken terminal must not fail the work being tracked.
+	_, _ = io.WriteString(b.out, "\r"+b.line())
+}
+
+// line formats the bar to fill the configured width.
+func (b *Bar) line() string {
+	// Without a known total, only the amount of work done can be shown.
+	if b.total <= 0 {
+		return fitLabel(b.opts.Label, b.opts.Width-12) + " " + FormatBytes(b.current)
+	}
+
+	current := min(max(b.current, 0), b.total)
+	ratio := float64(current) / float64(b.total)
+	stats := fmt.Sprintf(" %3d%% %s/%s", int(ratio*100), FormatBytes(current), FormatBytes(b.total))
+
+	// Give the label at most a third of the line, the rest goes to the bar.
+	label := fitLabel(b.opts.Label, b.opts.Width/3)
+	if label != "" {
+		label += " "
+	}
+
+	barWidth := b.opts.Width - len([]rune(label)) - len(stats) - 2
+	if barWidth < 5 {
+		return label + strings.TrimSpace(stats)
+	}
+
+	filled := int(ratio * float64(barWidth))
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+
+	return label + "[" + bar + "]" + stats
+}
+
+// fitLabel shortens the label to about width characters at a word boundary, marking the cut with an ellipsis.
+func fitLabel(label string, width int) string {
+	if width <= 1 || label == "" {
+		return ""
+	}
+	if len([]rune(label)) <= width {
+		return label
+	}
+
+	// Keep the words fitting into the width, leaving room for the ellipsis.
+	segments := commonstrings.SplitStringWithWidthConstraints(label, width-1, 0)
+
+	// Cut words that are longer than the width on their own.
+	first := []rune(segments[0])
+	if len(first) > width-1 {
+		first = first[:width-1]
+	}
+
+	return string(first) + "…"
+}
+
+// FormatBytes formats a byte count with a binary unit, for example "1.5 MiB".
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return strconv.FormatInt(n, 10) + " B"
+	}
+
+	div, exp := int64(unit), 0
+	for value := n / unit; value >= unit; value /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
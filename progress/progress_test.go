@@ -0,0 +1,137 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, written by the spinner goroutine.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends p to the buffer.
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+// String returns the contents of the buffer.
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+// TestBar verifies the rendering of progress bars.
+func TestBar(t *testing.T) {
+	t.Parallel()
+
+	// NotTerminal ensures nothing is rendered when the output is not a terminal.
+	t.Run("NotTerminal", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := NewBar(&out, 100, BarOptions{Label: "download"})
+		bar.Add(50)
+		bar.Finish()
+
+		assert.Empty(t, out.String(), "Expected no output")
+		assert.Equal(t, int64(50), bar.Current(), "Expected the progress to be tracked")
+	})
+
+	// Finish ensures the final state fills the configured width.
+	t.Run("Finish", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := NewBar(&out, 2048, BarOptions{Label: "download", Width: 50, Force: true})
+		bar.Set(1024)
+		bar.Finish()
+		bar.Add(1)
+
+		lines := strings.Split(out.String(), "\r")
+		last := lines[len(lines)-1]
+		assert.Equal(t, "download [=========>        ]  50% 1.0 KiB/2.0 KiB\n", last, "Unexpected final state")
+		assert.Len(t, strings.TrimSuffix(last, "\n"), 50, "Expected the line to fill the width")
+	})
+
+	// UnknownTotal ensures work of unknown size is rendered as an amount.
+	t.Run("UnknownTotal", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := NewBar(&out, 0, BarOptions{Label: "extract", Width: 40, Force: true})
+		bar.Add(3 << 20)
+		bar.Finish()
+
+		assert.True(t, strings.HasSuffix(out.String(), "\rextract 3.0 MiB\n"), "Unexpected output %q", out.String())
+	})
+
+	// LongLabel ensures a label longer than a third of the line is shortened at a word boundary.
+	t.Run("LongLabel", func(t *testing.T) {
+		var out bytes.Buffer
+		bar := NewBar(&out, 10, BarOptions{Label: "downloading archive from the mirror", Width: 45, Force: true})
+		bar.Finish()
+
+		assert.True(t, strings.HasPrefix(out.String(), "\rdownloading…"), "Expected a shortened label, got %q", out.String())
+	})
+}
+
+// TestWriterAndReader verifies that the wrappers report transferred bytes.
+func TestWriterAndReader(t *testing.T) {
+	t.Parallel()
+
+	bar := NewBar(io.Discard, 10, BarOptions{})
+
+	var dst bytes.Buffer
+	n, err := io.Copy(NewWriter(&dst, bar), NewReader(strings.NewReader("0123456789"), bar))
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, int64(10), n, "Unexpected copied bytes")
+	assert.Equal(t, "0123456789", dst.String(), "Expected the data to be copied")
+	assert.Equal(t, int64(20), bar.Current(), "Expected both the reads and the writes to be reported")
+}
+
+// TestSpinner verifies that the spinner renders frames until it is stopped.
+func TestSpinner(t *testing.T) {
+	t.Parallel()
+
+	// NotTerminal ensures nothing is rendered when the output is not a terminal.
+	t.Run("NotTerminal", func(t *testing.T) {
+		var out syncBuffer
+		spinner := NewSpinner(&out, SpinnerOptions{Label: "waiting"})
+		spinner.Start()
+		spinner.Stop()
+
+		assert.Empty(t, out.String(), "Expected no output")
+	})
+
+	// Frames ensures frames are rendered and the line is cleared on Stop.
+	t.Run("Frames", func(t *testing.T) {
+		var out syncBuffer
+		spinner := NewSpinner(&out, SpinnerOptions{Label: "waiting", Interval: 5 * time.Millisecond, Force: true})
+		spinner.Start()
+		spinner.Start()
+		time.Sleep(30 * time.Millisecond)
+		spinner.Stop()
+		spinner.Stop()
+
+		output := out.String()
+		assert.Contains(t, output, "\r| waiting", "Expected the first frame")
+		assert.Contains(t, output, "\r/ waiting", "Expected the second frame")
+		assert.True(t, strings.HasSuffix(output, "\r         \r"), "Expected the line to be cleared")
+	})
+}
+
+// TestFormatBytes verifies the formatting of byte counts.
+func TestFormatBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "512 B", FormatBytes(512), "Unexpected bytes")
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536), "Unexpected kibibytes")
+	assert.Equal(t, "2.0 GiB", FormatBytes(2<<30), "Unexpected gibibytes")
+}
@@ -0,0 +1,101 @@
+package progress
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SyntaxErrorLineNULL/common/term"
+)
+
+// spinnerFrames are the characters cycled through by a Spinner.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// SpinnerOptions configures a Spinner.
+type SpinnerOptions struct {
+	// Label is shown after the spinner. It is shortened when it does not fit into the line.
+	Label string
+	// Interval is the time between frames. When zero, 100ms is used.
+	Interval time.Duration
+	// Width is the width of the whole line. When zero, the width of the terminal is used.
+	Width int
+	// Force renders the spinner even if the output is not a terminal.
+	Force bool
+}
+
+// Spinner shows that work of unknown duration is in progress. Like Bar, it renders nothing
+// when the output is not a terminal.
+type Spinner struct {
+	// out receives the rendered frames.
+	out io.Writer
+	// opts holds the configuration of the spinner.
+	opts SpinnerOptions
+	// mu guards the fields below.
+	mu sync.Mutex
+	// stop is closed to stop the running spinner, nil when it is not running.
+	stop chan struct{}
+	// done is closed once the rendering goroutine has exited.
+	done chan struct{}
+}
+
+// NewSpinner creates a Spinner rendered to out. Call Start to show it.
+func NewSpinner(out io.Writer, opts SpinnerOptions) *Spinner {
+	if opts.Interval <= 0 {
+		opts.Interval = 100 * time.Millisecond
+	}
+	if opts.Width <= 0 {
+		opts.Width = term.Width(out)
+	}
+
+	return &Spinner{out: out, opts: opts}
+}
+
+// Start starts rendering the spinner in a background goroutine. Starting a running spinner does nothing.
+func (s *Spinner) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop != nil || (!s.opts.Force && !term.IsTerminal(s.out)) {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run(s.stop, s.done)
+}
+
+// Stop stops the spinner and clears its line. Stopping a spinner that is not running does nothing.
+func (s *Spinner) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stop == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+	s.stop, s.done = nil, nil
+}
+
+// run renders a frame every interval until stop is closed.
+func (s *Spinner) run(stop, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	label := fitLabel(s.opts.Label, s.opts.Width-2)
+	for frame := 0; ; frame++ {
+		_, _ = io.WriteString(s.out, "\r"+spinnerFrames[frame%len(spinnerFrames)]+" "+label)
+
+		select {
+		case <-stop:
+			// Overwrite the spinner with spaces, so the next output starts on a clean line.
+			_, _ = io.WriteString(s.out, "\r"+strings.Repeat(" ", len([]rune(label))+2)+"\r")
+			return
+		case <-ticker.C:
+		}
+	}
+}
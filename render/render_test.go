@@ -0,0 +1,86 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTable verifies column sizing, alignment, truncation and styling of tables.
+func TestTable(t *testing.T) {
+	t.Parallel()
+
+	// Aligned ensures columns fit their widest cell and short rows are padded.
+	t.Run("Aligned", func(t *testing.T) {
+		out := Table(
+			[]string{"NAME", "STATUS", "TIME"},
+			[][]string{{"build", "ok", "1.2s"}, {"integration-tests", "failed"}},
+			TableOptions{AlignRight: []int{2}},
+		)
+
+		expected := "" +
+			"NAME               STATUS  TIME\n" +
+			"build              ok      1.2s\n" +
+			"integration-tests  failed\n"
+		assert.Equal(t, expected, out, "Unexpected table")
+	})
+
+	// Truncate ensures wide cells are cut with an ellipsis.
+	t.Run("Truncate", func(t *testing.T) {
+		out := Table([]string{"ID", "MESSAGE"}, [][]string{{"1", "connection refused by peer"}}, TableOptions{MaxColumnWidth: 10, Gap: 1})
+
+		expected := "" +
+			"ID MESSAGE\n" +
+			"1  connectio…\n"
+		assert.Equal(t, expected, out, "Unexpected table")
+	})
+
+	// ANSI ensures escape sequences do not count towards the column width.
+	t.Run("ANSI", func(t *testing.T) {
+		bold := func(s string) string { return "\x1b[1m" + s + "\x1b[0m" }
+		out := Table([]string{"A", "B"}, [][]string{{"\x1b[31mred\x1b[0m", "x"}}, TableOptions{HeaderStyle: bold})
+
+		expected := "" +
+			"\x1b[1mA  \x1b[0m  \x1b[1mB\x1b[0m\n" +
+			"\x1b[31mred\x1b[0m  x\n"
+		assert.Equal(t, expected, out, "Unexpected table")
+	})
+
+	// NoHeaders ensures the column count is taken from the rows.
+	t.Run("NoHeaders", func(t *testing.T) {
+		assert.Equal(t, "a    bb\nccc\n", Table(nil, [][]string{{"a", "bb"}, {"ccc"}}, TableOptions{}), "Unexpected table")
+		assert.Empty(t, Table(nil, nil, TableOptions{}), "Expected no output for an empty table")
+	})
+}
+
+// TestVisibleWidth verifies that escape sequences and multi-byte characters are measured correctly.
+func TestVisibleWidth(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 5, VisibleWidth("hello"), "Unexpected width of plain text")
+	assert.Equal(t, 3, VisibleWidth("\x1b[1;31mred\x1b[0m"), "Expected escape sequences to be ignored")
+	assert.Equal(t, 4, VisibleWidth("größ"), "Expected characters rather than bytes")
+}
+
+// TestTree verifies the rendering of nested nodes.
+func TestTree(t *testing.T) {
+	t.Parallel()
+
+	root := &Node{Label: "project", Children: []*Node{
+		{Label: "cmd", Children: []*Node{{Label: "main.go"}, {Label: "flags.go"}}},
+		{Label: "internal", Children: []*Node{{Label: "store", Children: []*Node{{Label: "store.go"}}}}},
+		{Label: "go.mod\nignored"},
+	}}
+
+	expected := "" +
+		"project\n" +
+		"├── cmd\n" +
+		"│   ├── main.go\n" +
+		"│   └── flags.go\n" +
+		"├── internal\n" +
+		"│   └── store\n" +
+		"│       └── store.go\n" +
+		"└── go.mod\n"
+	assert.Equal(t, expected, Tree(root), "Unexpected tree")
+	assert.Empty(t, Tree(nil), "Expected no output for a nil tree")
+}
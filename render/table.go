@@ -0,0 +1,133 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SyntaxErrorLineNULL/common/term"
+)
+
+// TableOptions configures Table.
+type TableOptions struct {
+	// MaxColumnWidth truncates cells wider than this many characters, marking the cut with an ellipsis.
+	// Zero means no limit.
+	MaxColumnWidth int
+	// Gap is the number of spaces between columns. When zero, two spaces are used.
+	Gap int
+	// HeaderStyle, if set, decorates every header cell after it has been padded, for example with
+	// ANSI bold or color codes. Escape sequences in cells are ignored when measuring widths.
+	HeaderStyle func(string) string
+	// AlignRight lists the indexes of the columns aligned to the right, such as numeric columns.
+	AlignRight []int
+}
+
+// Table renders headers and rows as text with aligned columns, one line per row, each ending with a newline.
+// Column widths fit the widest cell of each column. Rows with fewer cells than headers are padded with
+// empty cells; extra cells are ignored. Without headers, the number of columns is taken from the widest row.
+func Table(headers []string, rows [][]string, opts TableOptions) string {
+	gap := opts.Gap
+	if gap <= 0 {
+		gap = 2
+	}
+
+	columns := len(headers)
+	if columns == 0 {
+		for _, row := range rows {
+			columns = max(columns, len(row))
+		}
+	}
+	if columns == 0 {
+		return ""
+	}
+
+	// Normalize the cells first, so widths are measured on the truncated content.
+	cells := func(row []string) []string {
+		normalized := make([]string, columns)
+		for i := range normalized {
+			if i < len(row) {
+				normalized[i] = truncate(row[i], opts.MaxColumnWidth)
+			}
+		}
+		return normalized
+	}
+
+	var header []string
+	if len(headers) > 0 {
+		header = cells(headers)
+	}
+	body := make([][]string, len(rows))
+	for i, row := range rows {
+		body[i] = cells(row)
+	}
+
+	// Size every column to its widest cell.
+	widths := make([]int, columns)
+	for _, row := range append([][]string{header}, body...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], VisibleWidth(cell))
+		}
+	}
+
+	rightAligned := make([]bool, columns)
+	for _, i := range opts.AlignRight {
+		if i >= 0 && i < columns {
+			rightAligned[i] = true
+		}
+	}
+
+	var out strings.Builder
+	writeRow := func(row []string, style func(string) string) {
+		var line strings.Builder
+		for i, cell := range row {
+			if i > 0 {
+				line.WriteString(strings.Repeat(" ", gap))
+			}
+
+			padded := pad(cell, widths[i], rightAligned[i])
+			if style != nil {
+				padded = style(padded)
+			}
+			line.WriteString(padded)
+		}
+		// Trailing spaces of the last column only make copying the output harder.
+		out.WriteString(strings.TrimRight(line.String(), " "))
+		out.WriteString("\n")
+	}
+
+	if header != nil {
+		writeRow(header, opts.HeaderStyle)
+	}
+	for _, row := range body {
+		writeRow(row, nil)
+	}
+
+	return out.String()
+}
+
+// VisibleWidth returns the number of characters s occupies on a terminal,
+// ignoring ANSI escape sequences such as color codes.
+func VisibleWidth(s string) int {
+	return utf8.RuneCountInString(term.Strip(s))
+}
+
+// pad pads s with spaces to width visible characters, on the left when alignRight is set.
+func pad(s string, width int, alignRight bool) string {
+	padding := strings.Repeat(" ", max(width-VisibleWidth(s), 0))
+	if alignRight {
+		return padding + s
+	}
+
+	return s + padding
+}
+
+// truncate shortens s to at most width visible characters, ending it with an ellipsis when cut.
+// Cells containing escape sequences are stripped of them before cutting, so no sequence is left unterminated.
+func truncate(s string, width int) string {
+	if width <= 0 || VisibleWidth(s) <= width {
+		return s
+	}
+
+	runes := []rune(term.Strip(s))
+
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,49 @@
+package render
+
+import "strings"
+
+// Node is an element of a tree rendered by Tree.
+type Node struct {
+	// Label is the text shown for the node. Only the first line of a multi-line label is rendered.
+	Label string
+	// Children are rendered below the node, in order.
+	Children []*Node
+}
+
+// Tree renders the tree rooted at root with box-drawing connectors, one line per node:
+//
+//	project
+//	├── cmd
+//	│   └── main.go
+//	└── go.mod
+func Tree(root *Node) string {
+	if root == nil {
+		return ""
+	}
+
+	var out strings.Builder
+	out.WriteString(firstLine(root.Label) + "\n")
+	writeChildren(&out, root.Children, "")
+
+	return out.String()
+}
+
+// writeChildren writes the children of a node, each line starting with the given prefix.
+func writeChildren(out *strings.Builder, children []*Node, prefix string) {
+	for i, child := range children {
+		// The last child closes the branch, so its subtree is not continued by a vertical line.
+		connector, continuation := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, continuation = "└── ", "    "
+		}
+
+		out.WriteString(prefix + connector + firstLine(child.Label) + "\n")
+		writeChildren(out, child.Children, prefix+continuation)
+	}
+}
+
+// firstLine returns the first line of s, so a label cannot break the layout of the tree.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,94 @@
+package runtimex
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"time"
+)
+
+// DefaultEmitInterval is the interval used by Emit when none is given.
+const DefaultEmitInterval = 10 * time.Second
+
+// Stats is a snapshot of the health of the running process.
+type Stats struct {
+	// Time is the moment the snapshot was taken.
+	Time time.Time
+	// Goroutines is the number of goroutines that currently exist.
+	Goroutines int
+	// HeapAlloc is the number of bytes of allocated heap objects, including unreachable ones not yet freed.
+	HeapAlloc uint64
+	// HeapInuse is the number of bytes in heap spans that hold at least one object.
+	HeapInuse uint64
+	// HeapSys is the number of bytes of heap memory obtained from the operating system.
+	HeapSys uint64
+	// HeapObjects is the number of allocated heap objects.
+	HeapObjects uint64
+	// NumGC is the number of completed garbage collection cycles.
+	NumGC uint32
+	// PauseTotal is the cumulative time the program was stopped by garbage collections.
+	PauseTotal time.Duration
+	// LastPause is the stop-the-world pause of the most recent garbage collection, zero before the first one.
+	LastPause time.Duration
+	// OpenFDs is the number of open file descriptors, or -1 where they cannot be counted, such as outside Linux.
+	OpenFDs int
+}
+
+// Snapshot collects the current runtime statistics. It briefly stops the world to read the memory
+// statistics, so it is meant to be called every few seconds rather than on every request.
+func Snapshot() Stats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	stats := Stats{
+		Time:        time.Now(),
+		Goroutines:  runtime.NumGoroutine(),
+		HeapAlloc:   mem.HeapAlloc,
+		HeapInuse:   mem.HeapInuse,
+		HeapSys:     mem.HeapSys,
+		HeapObjects: mem.HeapObjects,
+		NumGC:       mem.NumGC,
+		PauseTotal:  time.Duration(mem.PauseTotalNs),
+		OpenFDs:     openFDs(),
+	}
+
+	// PauseNs is a circular buffer holding the most recent pause at index (NumGC+255)%256.
+	if mem.NumGC > 0 {
+		stats.LastPause = time.Duration(mem.PauseNs[(mem.NumGC+255)%uint32(len(mem.PauseNs))])
+	}
+
+	return stats
+}
+
+// Emit passes a snapshot to observe right away and then every interval, until ctx is done, to feed
+// the runtime statistics of a long-running program into logs or metrics. It blocks, so it is usually
+// started in its own goroutine. A non-positive interval uses DefaultEmitInterval.
+func Emit(ctx context.Context, interval time.Duration, observe func(Stats)) {
+	if interval <= 0 {
+		interval = DefaultEmitInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		observe(Snapshot())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// openFDs counts the entries of /proc/self/fd, or returns -1 when the directory is not available.
+func openFDs() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+
+	// The listing includes the descriptor opened to read the directory itself.
+	return len(entries) - 1
+}
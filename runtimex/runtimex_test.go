@@ -0,0 +1,71 @@
+package runtimex
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshot verifies that the snapshot reflects the state of the process.
+func TestSnapshot(t *testing.T) {
+	// Values ensures the statistics are populated after a garbage collection.
+	t.Run("Values", func(t *testing.T) {
+		runtime.GC()
+
+		stats := Snapshot()
+
+		assert.Positive(t, stats.Goroutines, "Expected at least one goroutine")
+		assert.Positive(t, stats.HeapAlloc, "Expected allocated heap memory")
+		assert.GreaterOrEqual(t, stats.HeapSys, stats.HeapInuse, "Expected the heap in use to fit the heap obtained")
+		assert.Positive(t, stats.NumGC, "Expected a completed garbage collection")
+		assert.GreaterOrEqual(t, stats.PauseTotal, stats.LastPause, "Expected the total pause to include the last one")
+		assert.WithinDuration(t, time.Now(), stats.Time, time.Second, "Unexpected snapshot time")
+	})
+
+	// OpenFDs ensures opening a file is reflected in the descriptor count where /proc is available.
+	t.Run("OpenFDs", func(t *testing.T) {
+		before := Snapshot().OpenFDs
+		if before < 0 {
+			t.Skip("file descriptors cannot be counted on this platform")
+		}
+
+		file, err := os.Open(os.Args[0])
+		require.NoError(t, err, "Failed to open a file")
+		defer file.Close()
+
+		assert.Equal(t, before+1, Snapshot().OpenFDs, "Expected the opened file to be counted")
+	})
+}
+
+// TestEmit verifies that snapshots are emitted immediately and periodically until the context is done.
+func TestEmit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	snapshots := make(chan Stats)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Emit(ctx, 10*time.Millisecond, func(stats Stats) {
+			select {
+			case snapshots <- stats:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	first := <-snapshots
+	second := <-snapshots
+	assert.True(t, second.Time.After(first.Time), "Expected snapshots to be taken periodically")
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit did not return after the context was canceled")
+	}
+}
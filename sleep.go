@@ -0,0 +1,52 @@
+package common
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+// SleepCtx pauses the current goroutine for the duration d or until ctx is done, whichever happens first.
+// It returns nil after a full sleep and the context error if the sleep was interrupted, which lets loops
+// stop promptly on shutdown instead of finishing a bare time.Sleep. A non-positive duration only checks ctx.
+func SleepCtx(ctx context.Context, d time.Duration) error {
+	// Do not start sleeping if the context is already done.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// JitteredSleep sleeps like SleepCtx for base randomly adjusted by up to jitterFrac of it in either direction.
+// For example, a base of one second with a jitterFrac of 0.2 sleeps between 800ms and 1.2s. Jitter spreads out
+// retries and polling of many clients that would otherwise run in lockstep. The fraction is clamped to [0, 1].
+func JitteredSleep(ctx context.Context, base time.Duration, jitterFrac float64) error {
+	return SleepCtx(ctx, Jitter(base, jitterFrac))
+}
+
+// Jitter returns base randomly adjusted by up to jitterFrac of it in either direction.
+// The fraction is clamped to [0, 1], so the result is never negative.
+func Jitter(base time.Duration, jitterFrac float64) time.Duration {
+	jitterFrac = min(max(jitterFrac, 0), 1)
+	if base <= 0 || jitterFrac == 0 {
+		return base
+	}
+
+	// Pick a uniformly distributed offset in [-jitterFrac, +jitterFrac) of base.
+	offset := (rand.Float64()*2 - 1) * jitterFrac * float64(base)
+
+	return base + time.Duration(offset)
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSleepCtx verifies that SleepCtx sleeps for the full duration unless the context is done.
+func TestSleepCtx(t *testing.T) {
+	t.Parallel()
+
+	// FullSleep ensures SleepCtx waits for the whole duration and returns nil.
+	t.Run("FullSleep", func(t *testing.T) {
+		start := time.Now()
+
+		assert.NoError(t, SleepCtx(context.Background(), 20*time.Millisecond), "Expected no error")
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "Expected the full duration to pass")
+	})
+
+	// Cancelled ensures SleepCtx returns early with the context error.
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+
+		assert.ErrorIs(t, SleepCtx(ctx, time.Hour), context.DeadlineExceeded, "Expected the context error")
+		assert.Less(t, time.Since(start), time.Second, "Expected SleepCtx to return early")
+	})
+
+	// AlreadyDone ensures a done context is reported even for a zero duration.
+	t.Run("AlreadyDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		assert.ErrorIs(t, SleepCtx(ctx, 0), context.Canceled, "Expected the context error")
+	})
+}
+
+// TestJitter verifies that Jitter keeps the result within the requested range.
+func TestJitter(t *testing.T) {
+	t.Parallel()
+
+	base := time.Second
+
+	for i := 0; i < 1000; i++ {
+		d := Jitter(base, 0.2)
+		assert.GreaterOrEqual(t, d, 800*time.Millisecond, "Expected the jitter to stay within the fraction")
+		assert.LessOrEqual(t, d, 1200*time.Millisecond, "Expected the jitter to stay within the fraction")
+	}
+
+	assert.Equal(t, base, Jitter(base, 0), "Expected no jitter for a zero fraction")
+	assert.Equal(t, base, Jitter(base, -1), "Expected a negative fraction to be treated as zero")
+	assert.GreaterOrEqual(t, Jitter(base, 5), time.Duration(0), "Expected a clamped fraction to keep the result non-negative")
+}
+
+// TestJitteredSleep verifies that JitteredSleep returns early on cancellation.
+func TestJitteredSleep(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.ErrorIs(t, JitteredSleep(ctx, time.Hour, 0.5), context.Canceled, "Expected the context error")
+	assert.NoError(t, JitteredSleep(context.Background(), 10*time.Millisecond, 0.5), "Expected no error")
+}
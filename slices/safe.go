@@ -0,0 +1,70 @@
+package slices
+
+import "sync"
+
+// SafeSlice is a slice guarded by a mutex, for collecting results from many goroutines.
+// The zero value is an empty slice ready to use. A SafeSlice must not be copied after first use.
+type SafeSlice[T any] struct {
+	// mu guards elements.
+	mu sync.RWMutex
+	// elements holds the appended elements in order.
+	elements []T
+}
+
+// Append adds the values to the end of the slice.
+func (s *SafeSlice[T]) Append(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.elements = append(s.elements, values...)
+}
+
+// Get returns the element at index and true, or the zero value and false if the index is out of range.
+func (s *SafeSlice[T]) Get(index int) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Report an index outside of the slice instead of panicking.
+	if index < 0 || index >= len(s.elements) {
+		var zero T
+		return zero, false
+	}
+
+	return s.elements[index], true
+}
+
+// Len returns the number of elements.
+func (s *SafeSlice[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.elements)
+}
+
+// Snapshot returns a copy of the elements, which the caller may use and modify freely.
+// It returns nil if the slice is empty.
+func (s *SafeSlice[T]) Snapshot() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Copy the elements, so later appends do not race with the caller.
+	if len(s.elements) == 0 {
+		return nil
+	}
+	snapshot := make([]T, len(s.elements))
+	copy(snapshot, s.elements)
+
+	return snapshot
+}
+
+// Range calls fn for every element in order until fn returns false. It iterates over a snapshot taken
+// when Range is called, so fn may safely call other methods of the slice, and elements appended
+// meanwhile are not visited.
+func (s *SafeSlice[T]) Range(fn func(index int, value T) bool) {
+	// Iterate without holding the lock, so fn cannot deadlock by appending.
+	for i, v := range s.Snapshot() {
+		if !fn(i, v) {
+			return
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package slices
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSafeSlice verifies the mutex-protected slice wrapper.
+func TestSafeSlice(t *testing.T) {
+	t.Parallel()
+
+	// ConcurrentAppend ensures no appends are lost across goroutines.
+	t.Run("ConcurrentAppend", func(t *testing.T) {
+		var results SafeSlice[int]
+		var wg sync.WaitGroup
+
+		for worker := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 100 {
+					results.Append(worker*100 + i)
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 800, results.Len(), "Expected every append to be kept")
+		assert.ElementsMatch(t, Generate(800, func(i int) int { return i }), results.Snapshot(), "Unexpected elements")
+	})
+
+	// Get ensures indexes are bounds checked.
+	t.Run("Get", func(t *testing.T) {
+		var s SafeSlice[string]
+		s.Append("a", "b")
+
+		v, ok := s.Get(1)
+		assert.True(t, ok, "Expected the element to exist")
+		assert.Equal(t, "b", v, "Unexpected element")
+
+		_, ok = s.Get(2)
+		assert.False(t, ok, "Expected an index past the end to be reported")
+		_, ok = s.Get(-1)
+		assert.False(t, ok, "Expected a negative index to be reported")
+	})
+
+	// Snapshot ensures the copy is independent of the slice.
+	t.Run("Snapshot", func(t *testing.T) {
+		var s SafeSlice[int]
+		assert.Nil(t, s.Snapshot(), "Expected nil for an empty slice")
+
+		s.Append(1, 2)
+		snapshot := s.Snapshot()
+		snapshot[0] = 100
+		s.Append(3)
+
+		assert.Equal(t, []int{100, 2}, snapshot, "Expected the snapshot to keep its length")
+		assert.Equal(t, []int{1, 2, 3}, s.Snapshot(), "Expected the slice to stay unchanged")
+	})
+
+	// Range ensures iteration stops early and allows appends from the callback.
+	t.Run("Range", func(t *testing.T) {
+		var s SafeSlice[int]
+		s.Append(1, 2, 3)
+
+		var visited []int
+		s.Range(func(_ int, v int) bool {
+			visited = append(visited, v)
+			s.Append(v * 10)
+			return v < 2
+		})
+
+		assert.Equal(t, []int{1, 2}, visited, "Expected iteration to stop after false")
+		assert.Equal(t, []int{1, 2, 3, 10, 20}, s.Snapshot(), "Expected appends from the callback")
+	})
+}
@@ -1,8 +1,11 @@
 package slices
 
 import (
-	"golang.org/x/exp/constraints"
+	"errors"
+	"math/rand/v2"
 	"sort"
+
+	"golang.org/x/exp/constraints"
 )
 
 // Merge concatenates two slices into a single slice.
@@ -20,9 +23,91 @@ func Merge[T any](first, second []T) []T {
 	return list
 }
 
+// ErrIndexOutOfRange is returned by the index-based operations when an index or count does not fit the slice.
+var ErrIndexOutOfRange = errors.New("slices: index out of range")
+
+// Insert returns a new slice with the values inserted before the element at index, leaving the input
+// unchanged. An index equal to the length of the slice appends the values. ErrIndexOutOfRange is returned
+// for an index outside of [0, len(elements)].
+func Insert[T any](elements []T, index int, values ...T) ([]T, error) {
+	// Inserting is a splice that removes nothing.
+	return Splice(elements, index, 0, values...)
+}
+
+// RemoveAt returns a new slice without the element at index, leaving the input unchanged.
+// ErrIndexOutOfRange is returned for an index outside of [0, len(elements)).
+func RemoveAt[T any](elements []T, index int) ([]T, error) {
+	// Removing is a splice that deletes a single element and inserts nothing.
+	return Splice(elements, index, 1)
+}
+
+// Splice returns a new slice in which deleteCount elements starting at start are replaced by the values,
+// leaving the input unchanged. It generalizes Insert (deleteCount of zero) and RemoveAt (a single deletion
+// without values). ErrIndexOutOfRange is returned if start is outside of [0, len(elements)] or if
+// deleteCount is negative or reaches past the end of the slice. The result is never nil.
+func Splice[T any](elements []T, start, deleteCount int, values ...T) ([]T, error) {
+	// Validate the bounds instead of letting a slice expression panic.
+	if start < 0 || start > len(elements) || deleteCount < 0 || deleteCount > len(elements)-start {
+		return nil, ErrIndexOutOfRange
+	}
+
+	// Allocate the result once with its final length.
+	result := make([]T, 0, len(elements)-deleteCount+len(values))
+
+	// Keep the head, add the values, then keep the tail after the deleted elements.
+	result = append(result, elements[:start]...)
+	result = append(result, values...)
+	result = append(result, elements[start+deleteCount:]...)
+
+	// Return the spliced copy.
+	return result, nil
+}
+
+// Repeat returns a new slice holding n copies of v. Copies are shallow, so for pointer or slice values
+// all elements refer to the same data. If n is not positive, nil is returned.
+func Repeat[T any](v T, n int) []T {
+	// A non-positive count yields no elements.
+	if n <= 0 {
+		return nil
+	}
+
+	// Allocate the result once and set every element.
+	result := make([]T, n)
+	Fill(result, v)
+
+	// Return the filled slice.
+	return result
+}
+
+// Generate returns a new slice of n elements, where the element at index i is fn(i).
+// The function is called once per index, in ascending order. If n is not positive, nil is returned.
+func Generate[T any](n int, fn func(i int) T) []T {
+	// A non-positive count yields no elements.
+	if n <= 0 {
+		return nil
+	}
+
+	// Allocate the result once and compute every element from its index.
+	result := make([]T, n)
+	for i := range result {
+		result[i] = fn(i)
+	}
+
+	// Return the generated slice.
+	return result
+}
+
+// Fill sets every element of dst to v in place, reusing a buffer without reallocating it.
+func Fill[T any](dst []T, v T) {
+	for i := range dst {
+		dst[i] = v
+	}
+}
+
 // Contains checks if the provided element is present in the slice.
 // It first sorts the slice and then performs a binary search to determine if the element exists.
 // Returns true if the element is found, otherwise false.
+// Sorting a copy costs O(n log n) on every call; for a slice that is already sorted, use BinarySearch.
 func Contains[T constraints.Ordered](elements []T, element T) bool {
 	// Check if the slice is nil. If it is, return false because there's nothing to search.
 	if elements == nil {
@@ -52,11 +137,79 @@ func Contains[T constraints.Ordered](elements []T, element T) bool {
 	return index < len(copiedElements) && copiedElements[index] == element
 }
 
+// BinarySearch searches a slice sorted in ascending order for the element in O(log n) time.
+// It returns the index of the first occurrence and true if the element is present, or the index at which
+// it would have to be inserted to keep the slice sorted and false otherwise. The slice must be sorted;
+// this is not checked, and an unsorted slice produces meaningless results.
+func BinarySearch[T constraints.Ordered](sorted []T, element T) (int, bool) {
+	// Find the first index whose element is not less than the searched one.
+	index := sort.Search(len(sorted), func(i int) bool {
+		return sorted[i] >= element
+	})
+
+	// The element is present if that index holds it.
+	return index, index < len(sorted) && sorted[index] == element
+}
+
+// ContainsFunc reports whether at least one element of the slice satisfies the predicate function.
+// Unlike Contains, it works for elements of any type, such as structs searched by one of their fields,
+// and stops at the first match without copying or sorting the slice.
+func ContainsFunc[T any](elements []T, fn func(T) bool) bool {
+	// Delegate to IndexFunc, which returns -1 when no element matches.
+	return IndexFunc(elements, fn) >= 0
+}
+
+// IndexFunc returns the index of the first element of the slice satisfying the predicate function,
+// or -1 if no element does. The elements are inspected in order and the search stops at the first match.
+func IndexFunc[T any](elements []T, fn func(T) bool) int {
+	// Inspect the elements in order and return the position of the first match.
+	for i, v := range elements {
+		if fn(v) {
+			return i
+		}
+	}
+
+	// No element satisfied the predicate.
+	return -1
+}
+
 // Exclude removes all instances of a specified value from the provided slice.
-// It creates a new slice containing only the elements that are not equal to the specified value.
-// This approach efficiently constructs the result slice by reusing the original slice's underlying array,
-// avoiding unnecessary memory allocations.
+// It filters the slice in place, exactly like ExcludeInPlace: the returned slice shares the underlying array
+// of the input, whose contents are overwritten. Callers that keep using the input slice must use ExcludeCopy.
 func Exclude[T comparable](elements []T, element T) []T {
+	// Keep the zero-allocation behavior existing callers rely on.
+	return ExcludeInPlace(elements, element)
+}
+
+// ExcludeCopy returns a new slice containing the elements of the provided slice that are not equal to the specified value.
+// The input slice is left untouched. A nil input yields nil; any other input yields a non-nil slice, which is empty
+// when every element was excluded.
+func ExcludeCopy[T comparable](elements []T, element T) []T {
+	// Preserve the distinction between a nil and an empty input.
+	if elements == nil {
+		return nil
+	}
+
+	// Allocate a separate array, so that the caller's slice is never modified.
+	result := make([]T, 0, len(elements))
+
+	// Copy every element that is not equal to the specified value, preserving the order.
+	for _, item := range elements {
+		if item != element {
+			result = append(result, item)
+		}
+	}
+
+	// Return the new slice with the specified value removed.
+	return result
+}
+
+// ExcludeInPlace removes all instances of a specified value from the provided slice without allocating.
+// It moves the elements that are not equal to the specified value to the front of the slice and returns that part.
+// This approach efficiently constructs the result slice by reusing the original slice's underlying array,
+// avoiding unnecessary memory allocations. The contents of the input slice are overwritten,
+// so it must not be used after the call.
+func ExcludeInPlace[T comparable](elements []T, element T) []T {
 	// Initialize the result slice with the same underlying array as the original slice.
 	// This avoids unnecessary allocations and keeps the capacity the same.
 	result := elements[:0]
@@ -95,10 +248,34 @@ func Map[A, B any](elements []A, fn func(A) B) []B {
 	return result
 }
 
+// filterSampleSize is the number of leading elements Filter and FilterMap inspect before reserving the
+// capacity of the result. The capacity is estimated from the share of matches among them, so a large input
+// with a few matches does not leave the caller holding a mostly empty array. Callers knowing the number of
+// matches can preallocate with FilterInto.
+const filterSampleSize = 128
+
+// reserveByRatio grows result, holding the matches among the first inspected elements, to the capacity
+// needed if the remaining elements up to total match at the same ratio.
+func reserveByRatio[T any](result []T, inspected, total int) []T {
+	// Round the estimate up, so that the ratio of a sample with matches never reserves nothing.
+	expected := len(result) + (len(result)*(total-inspected)+inspected-1)/inspected
+	if expected <= cap(result) {
+		return result
+	}
+
+	grown := make([]T, len(result), expected)
+	copy(grown, result)
+
+	return grown
+}
+
 // Filter filters a slice of elements based on a provided predicate function.
 // It iterates over each element in the input slice and applies the predicate function to it.
 // If the predicate returns true for an element, that element is included in the result.
 // The result is a new slice containing only the elements that satisfied the predicate function.
+// The result slice is allocated lazily on the first match. Once the first elements are inspected, the capacity
+// for the rest is reserved at the ratio of matches among them, and the slice grows as needed beyond it.
+// If no element matches, nil is returned.
 // This function is generic and works with any type of slice and predicate.
 func Filter[T any](elements []T, fn func(T) bool) []T {
 	var result []T
@@ -106,20 +283,220 @@ func Filter[T any](elements []T, fn func(T) bool) []T {
 	// Iterate over each element in the input slice.
 	// For each element, apply the predicate function.
 	// If the predicate returns true, append the element to the result slice.
-	for _, v := range elements {
+	for i, v := range elements {
+		// Once the sample is inspected, reserve room for the rest at the ratio of matches found in it.
+		if i == filterSampleSize && result != nil {
+			result = reserveByRatio(result, filterSampleSize, len(elements))
+		}
+
 		// Check if the current element satisfies the predicate function fn.
 		// The predicate function fn is applied to the element v to determine if it should be included in the result.
-		if fn(v) {
-			// If the element satisfies the condition, it is added to the result slice.
-			// The append function dynamically grows the result slice and appends the element v to it.
-			result = append(result, v)
+		if !fn(v) {
+			continue
+		}
+
+		// Allocate the result slice on the first match only, so that an input without matches still yields nil.
+		// The capacity never exceeds the number of elements left to inspect, including the current one.
+		if result == nil {
+			result = make([]T, 0, min(len(elements)-i, filterSampleSize))
 		}
+
+		// If the element satisfies the condition, it is added to the result slice.
+		// Append grows the slice geometrically once the reserved capacity is used up.
+		result = append(result, v)
 	}
 
 	// Return the resulting slice containing only the elements that satisfy the predicate.
 	return result
 }
 
+// FilterMap transforms and filters the slice in a single pass: fn returns the transformed element and
+// whether to keep it. It replaces a Filter followed by a Map without the intermediate slice.
+// Like Filter, the result is allocated on the first kept element and reserved at the ratio of kept elements
+// in the sample, and nil is returned if none is kept.
+func FilterMap[A, B any](elements []A, fn func(A) (B, bool)) []B {
+	var result []B
+
+	for i, v := range elements {
+		// Reserve room for the rest at the ratio of the sample, like Filter does.
+		if i == filterSampleSize && result != nil {
+			result = reserveByRatio(result, filterSampleSize, len(elements))
+		}
+
+		// Transform the element and skip it if fn rejects it.
+		mapped, ok := fn(v)
+		if !ok {
+			continue
+		}
+
+		// Allocate the result on the first kept element, with a small capacity like Filter does.
+		if result == nil {
+			result = make([]B, 0, min(len(elements)-i, filterSampleSize))
+		}
+
+		// Keep the transformed element.
+		result = append(result, mapped)
+	}
+
+	// Return the transformed elements that were kept.
+	return result
+}
+
+// FilterInto appends the elements of src that satisfy the predicate function to dst and returns the extended slice.
+// It performs no allocations as long as dst has enough spare capacity for the matching elements,
+// which makes it suitable for hot paths that reuse a buffer between calls.
+// Passing src[:0] as dst filters src in place, reusing its underlying array;
+// in that case the original contents of src are overwritten.
+func FilterInto[T any](dst, src []T, fn func(T) bool) []T {
+	// Iterate over each element in the source slice and apply the predicate function.
+	for _, v := range src {
+		// Append only the elements that satisfy the predicate.
+		// When dst aliases src, the write position never overtakes the read position,
+		// so elements are never overwritten before they are inspected.
+		if fn(v) {
+			dst = append(dst, v)
+		}
+	}
+
+	// Return the destination slice extended with the matching elements.
+	return dst
+}
+
+// Partition splits the slice in a single pass into the elements that satisfy the predicate and the rest,
+// both in their original order. The input is not modified. Either result is nil if it has no elements,
+// like the result of Filter.
+func Partition[T any](elements []T, fn func(T) bool) (matched, rest []T) {
+	// Send every element to one of the two results, evaluating the predicate once per element.
+	for _, v := range elements {
+		if fn(v) {
+			matched = append(matched, v)
+		} else {
+			rest = append(rest, v)
+		}
+	}
+
+	// Return both groups.
+	return matched, rest
+}
+
+// Count returns the number of elements equal to the given element.
+func Count[T comparable](elements []T, element T) int {
+	count := 0
+
+	// Count every occurrence of the element.
+	for _, v := range elements {
+		if v == element {
+			count++
+		}
+	}
+
+	// Return the number of occurrences.
+	return count
+}
+
+// CountBy returns the number of elements that satisfy the predicate, without building a filtered slice.
+func CountBy[T any](elements []T, fn func(T) bool) int {
+	count := 0
+
+	// Count every element the predicate accepts.
+	for _, v := range elements {
+		if fn(v) {
+			count++
+		}
+	}
+
+	// Return the number of matches.
+	return count
+}
+
+// Reduce aggregates the elements of a slice into a single value.
+// It starts with the initial value and applies fn to the accumulated value and each element in order,
+// passing the result on to the next call. The result of the last call is returned, or the initial value
+// if the slice is empty. Together with Map and Filter, it covers sums, lookups and concatenations without
+// writing the loop by hand, for example Reduce(orders, 0, func(sum int, o Order) int { return sum + o.Total }).
+func Reduce[T, R any](elements []T, initial R, fn func(R, T) R) R {
+	// Start from the initial value, which is also the result for an empty slice.
+	accumulator := initial
+
+	// Fold every element into the accumulated value, preserving the order of the slice.
+	for _, v := range elements {
+		accumulator = fn(accumulator, v)
+	}
+
+	// Return the aggregated value.
+	return accumulator
+}
+
+// Min returns the smallest element of the slice and true, or the zero value and false if the slice is empty.
+// Like the builtin min, a NaN element makes the result NaN.
+func Min[T constraints.Ordered](elements []T) (T, bool) {
+	// An empty slice has no smallest element.
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Start with the first element and keep the smaller one of every comparison.
+	result := elements[0]
+	for _, v := range elements[1:] {
+		result = min(result, v)
+	}
+
+	// Return the smallest element.
+	return result, true
+}
+
+// Max returns the greatest element of the slice and true, or the zero value and false if the slice is empty.
+// Like the builtin max, a NaN element makes the result NaN.
+func Max[T constraints.Ordered](elements []T) (T, bool) {
+	// An empty slice has no greatest element.
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Start with the first element and keep the greater one of every comparison.
+	result := elements[0]
+	for _, v := range elements[1:] {
+		result = max(result, v)
+	}
+
+	// Return the greatest element.
+	return result, true
+}
+
+// Sum returns the sum of the elements of the slice, or zero if the slice is empty.
+// Integer sums wrap around on overflow, as with the + operator.
+func Sum[T constraints.Integer | constraints.Float](elements []T) T {
+	// The sum of no elements is zero.
+	var result T
+
+	// Add up the elements in order.
+	for _, v := range elements {
+		result += v
+	}
+
+	// Return the total.
+	return result
+}
+
+// GroupBy groups the elements of a slice by the key computed for each of them by keyFn.
+// It returns a map from every key to the elements having that key, in the order they appear in the slice.
+// If the slice is empty, an empty non-nil map is returned, so the result can be used without a nil check.
+func GroupBy[T any, K comparable](elements []T, keyFn func(T) K) map[K][]T {
+	// Allocate the map for the result; the number of groups is not known in advance.
+	groups := make(map[K][]T)
+
+	// Append every element to the group of its key, which preserves the order within each group.
+	for _, v := range elements {
+		key := keyFn(v)
+		groups[key] = append(groups[key], v)
+	}
+
+	// Return the groups keyed by the computed keys.
+	return groups
+}
+
 // Unique removes duplicate elements from a slice of any comparable type.
 // It iterates over each element in the input slice and keeps track of the elements that have already been encountered.
 // If an element has not been encountered before, it is added to the result slice.
@@ -158,3 +535,733 @@ func Unique[T comparable](elements []T) []T {
 	// The order of the elements is preserved.
 	return result
 }
+
+// UniqueBy removes the elements whose key, computed by keyFn, has already been seen, such as structs
+// sharing an ID. The first element with every key is kept and the order of the slice is preserved.
+// If the slice is empty, nil is returned, like Unique.
+func UniqueBy[T any, K comparable](elements []T, keyFn func(T) K) []T {
+	// Declare the result, which stays nil for an empty slice.
+	var result []T
+	// Track the keys of the elements kept so far.
+	seen := make(map[K]struct{}, len(elements))
+
+	for _, elem := range elements {
+		// Skip the element if an earlier one had the same key.
+		key := keyFn(elem)
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		// Remember the key and keep the first element having it.
+		seen[key] = struct{}{}
+		result = append(result, elem)
+	}
+
+	// Return the deduplicated elements in their original order.
+	return result
+}
+
+// Union merges any number of slices into one without duplicates, keeping every element at the position
+// of its first occurrence. Unlike Unique(Merge(first, second)), it takes any number of slices and
+// builds the result in a single pass. If no elements are given, nil is returned, like Unique.
+func Union[T comparable](slices ...[]T) []T {
+	// Size the index for the worst case of all elements being distinct.
+	total := 0
+	for _, s := range slices {
+		total += len(s)
+	}
+
+	var result []T
+	seen := make(map[T]struct{}, total)
+	for _, s := range slices {
+		for _, elem := range s {
+			// Skip the element if it occurred earlier in this or a previous slice.
+			if _, ok := seen[elem]; ok {
+				continue
+			}
+
+			seen[elem] = struct{}{}
+			result = append(result, elem)
+		}
+	}
+
+	// Return the distinct elements in the order they were first seen.
+	return result
+}
+
+// Intersect returns the elements of the first slice that are also present in the second slice.
+// The result preserves the order of the first slice and contains every common element only once,
+// even if it occurs several times in either slice. If the slices have no element in common,
+// including when either of them is nil or empty, nil is returned.
+func Intersect[T comparable](first, second []T) []T {
+	// Nothing can be common when either slice is empty.
+	if len(first) == 0 || len(second) == 0 {
+		return nil
+	}
+
+	// Index the elements of the second slice for constant-time lookups.
+	present := make(map[T]struct{}, len(second))
+	for _, v := range second {
+		present[v] = struct{}{}
+	}
+
+	var result []T
+	for _, v := range first {
+		// Keep the element if the second slice contains it, and remove it from the index
+		// so that later duplicates in the first slice are skipped.
+		if _, ok := present[v]; ok {
+			result = append(result, v)
+			delete(present, v)
+		}
+	}
+
+	// Return the common elements in the order of the first slice.
+	return result
+}
+
+// Difference returns the elements of the first slice that are not present in the second slice.
+// The result preserves the order of the first slice and contains every remaining element only once,
+// even if it occurs several times in the first slice. A nil or empty second slice removes nothing,
+// so the result is the first slice without duplicates. If no element remains, nil is returned.
+func Difference[T comparable](first, second []T) []T {
+	// Index the elements of the second slice; they and the elements already kept are excluded.
+	excluded := make(map[T]struct{}, len(second))
+	for _, v := range second {
+		excluded[v] = struct{}{}
+	}
+
+	var result []T
+	for _, v := range first {
+		// Skip the elements of the second slice and the duplicates of elements already kept.
+		if _, ok := excluded[v]; ok {
+			continue
+		}
+		excluded[v] = struct{}{}
+		result = append(result, v)
+	}
+
+	// Return the remaining elements in the order of the first slice.
+	return result
+}
+
+// Equal reports whether two slices contain the same elements in the same order.
+// Two slices of different lengths are never equal. A nil slice and an empty slice
+// are considered equal, because both contain no elements.
+func Equal[T comparable](first, second []T) bool {
+	// Slices of different lengths cannot hold the same sequence of elements.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Compare the elements pairwise and stop at the first mismatch.
+	for i := range first {
+		if first[i] != second[i] {
+			return false
+		}
+	}
+
+	// All elements matched at their positions.
+	return true
+}
+
+// EqualFunc reports whether two slices contain equal elements in the same order, comparing them with eq.
+// It handles element types that are not comparable or need a custom notion of equality, such as
+// structs holding slices or floats compared with a tolerance. Like Equal, it treats a nil slice and
+// an empty slice as equal, and eq is not called for slices of different lengths.
+func EqualFunc[T any](first, second []T, eq func(T, T) bool) bool {
+	// Slices of different lengths cannot hold the same sequence of elements.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Compare the elements pairwise and stop at the first mismatch.
+	for i := range first {
+		if !eq(first[i], second[i]) {
+			return false
+		}
+	}
+
+	// All elements matched at their positions.
+	return true
+}
+
+// EqualUnordered reports whether two slices contain the same elements with the same multiplicity,
+// regardless of their order. It treats the slices as multisets, so []int{1, 1, 2} equals []int{2, 1, 1}
+// but not []int{1, 2, 2}. Neither input slice is modified, which makes the function a drop-in replacement
+// for sorting copies of both slices just to compare them.
+func EqualUnordered[T comparable](first, second []T) bool {
+	// Multisets of different sizes can never be equal.
+	if len(first) != len(second) {
+		return false
+	}
+
+	// Count the occurrences of every element in the first slice.
+	counts := make(map[T]int, len(first))
+	for _, v := range first {
+		counts[v]++
+	}
+
+	// Consume the counts using the elements of the second slice.
+	// An element that is missing or occurs more often than in the first slice makes the slices unequal.
+	for _, v := range second {
+		if counts[v] == 0 {
+			return false
+		}
+		counts[v]--
+	}
+
+	// Since both slices have the same length and every element of the second slice
+	// was matched, all counts are exhausted and the multisets are equal.
+	return true
+}
+
+// Compare compares two slices lexicographically, element by element.
+// It returns -1 if first is less than second, 1 if first is greater than second, and 0 if they are equal.
+// When one slice is a prefix of the other, the shorter slice is considered less.
+func Compare[T constraints.Ordered](first, second []T) int {
+	// Walk both slices up to the length of the shorter one and return on the first differing element.
+	for i := 0; i < len(first) && i < len(second); i++ {
+		switch {
+		case first[i] < second[i]:
+			return -1
+		case first[i] > second[i]:
+			return 1
+		}
+	}
+
+	// All common elements are equal, so the shorter slice comes first.
+	switch {
+	case len(first) < len(second):
+		return -1
+	case len(first) > len(second):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Pair holds two values that belong together, such as two adjacent elements of a slice.
+type Pair[A, B any] struct {
+	// First is the first value of the pair.
+	First A
+	// Second is the second value of the pair.
+	Second B
+}
+
+// Windows returns all overlapping windows of the given size over the slice, in order.
+// Each window is a sub-slice of the input sharing its underlying array, with its capacity
+// limited to the window size so that appending to a window never overwrites the input.
+// If size is not positive or exceeds the length of the slice, nil is returned.
+func Windows[T any](elements []T, size int) [][]T {
+	// A window must hold at least one element and cannot be larger than the slice itself.
+	if size <= 0 || size > len(elements) {
+		return nil
+	}
+
+	// There are exactly len(elements)-size+1 windows, so allocate the result once.
+	result := make([][]T, 0, len(elements)-size+1)
+
+	// Slide the window one element at a time.
+	for i := 0; i+size <= len(elements); i++ {
+		// Use a full slice expression to cap the window, protecting the rest of the input from appends.
+		result = append(result, elements[i:i+size:i+size])
+	}
+
+	// Return the collected windows.
+	return result
+}
+
+// Chunk splits the slice into consecutive batches of the given size, in order. The last batch holds
+// the remaining elements and may be smaller. Each batch is a sub-slice of the input sharing its underlying
+// array, with its capacity limited to the batch length so that appending to a batch never overwrites the input.
+// If size is not positive or the slice is empty, nil is returned, so a loop over the batches does nothing.
+func Chunk[T any](elements []T, size int) [][]T {
+	// A batch must hold at least one element, and an empty slice has no batches.
+	if size <= 0 || len(elements) == 0 {
+		return nil
+	}
+
+	// Allocate the result once; the division rounds up to include the smaller last batch.
+	result := make([][]T, 0, (len(elements)+size-1)/size)
+
+	// Cut the slice into batches, the last one ending at the end of the slice.
+	for i := 0; i < len(elements); i += size {
+		end := min(i+size, len(elements))
+		// Use a full slice expression to cap the batch, protecting the rest of the input from appends.
+		result = append(result, elements[i:end:end])
+	}
+
+	// Return the collected batches.
+	return result
+}
+
+// Reverse returns a copy of the slice with the elements in reverse order, leaving the input unchanged.
+// If the slice is nil, nil is returned.
+func Reverse[T any](elements []T) []T {
+	// Keep nil as nil, so callers can tell a missing slice from an empty one.
+	if elements == nil {
+		return nil
+	}
+
+	// Allocate the copy once, as its length is known.
+	result := make([]T, len(elements))
+
+	// Place every element at the mirrored index.
+	for i, v := range elements {
+		result[len(elements)-1-i] = v
+	}
+
+	// Return the reversed copy.
+	return result
+}
+
+// Shuffle returns a copy of the slice with the elements in random order, leaving the input unchanged.
+// The permutation is drawn from r, so tests can pass a generator with a fixed seed to get a deterministic
+// order; if r is nil, the global generator of math/rand/v2 is used. If the slice is nil, nil is returned.
+func Shuffle[T any](elements []T, r *rand.Rand) []T {
+	// Keep nil as nil, so callers can tell a missing slice from an empty one.
+	if elements == nil {
+		return nil
+	}
+
+	// Work on a copy, so the input keeps its order.
+	result := make([]T, len(elements))
+	copy(result, elements)
+
+	// Swap the elements in place with a Fisher-Yates shuffle, drawing from the given generator if any.
+	swap := func(i, j int) { result[i], result[j] = result[j], result[i] }
+	if r != nil {
+		r.Shuffle(len(result), swap)
+	} else {
+		rand.Shuffle(len(result), swap)
+	}
+
+	// Return the shuffled copy.
+	return result
+}
+
+// SortBy returns a copy of the slice sorted in ascending order of the key computed by keyFn,
+// leaving the input unchanged. The order of elements with equal keys is unspecified; use SortStableBy
+// to keep their original order. The key is computed on every comparison, so keyFn should be cheap.
+// If the slice is nil, nil is returned.
+func SortBy[T any, K constraints.Ordered](elements []T, keyFn func(T) K) []T {
+	// Keep nil as nil, so callers can tell a missing slice from an empty one.
+	if elements == nil {
+		return nil
+	}
+
+	// Sort a copy, so the input keeps its order.
+	result := make([]T, len(elements))
+	copy(result, elements)
+
+	// Order the copy by the keys of its elements.
+	sort.Slice(result, func(i, j int) bool { return keyFn(result[i]) < keyFn(result[j]) })
+
+	// Return the sorted copy.
+	return result
+}
+
+// SortStableBy returns a copy of the slice sorted in ascending order of the key computed by keyFn,
+// leaving the input unchanged. Elements with equal keys keep their original order.
+// If the slice is nil, nil is returned.
+func SortStableBy[T any, K constraints.Ordered](elements []T, keyFn func(T) K) []T {
+	// Keep nil as nil, so callers can tell a missing slice from an empty one.
+	if elements == nil {
+		return nil
+	}
+
+	// Sort a copy, so the input keeps its order.
+	result := make([]T, len(elements))
+	copy(result, elements)
+
+	// Order the copy by the keys of its elements, keeping equal keys in place.
+	sort.SliceStable(result, func(i, j int) bool { return keyFn(result[i]) < keyFn(result[j]) })
+
+	// Return the sorted copy.
+	return result
+}
+
+// CompactConsecutive returns a copy of the slice with runs of equal adjacent elements replaced by a single
+// element, leaving the input unchanged. For sorted data this removes all duplicates more cheaply than
+// Unique, as no set is needed; unsorted data keeps repeated values that are not adjacent.
+// If the slice is empty, nil is returned, like Unique.
+func CompactConsecutive[T comparable](elements []T) []T {
+	// An empty slice has nothing to compact.
+	if len(elements) == 0 {
+		return nil
+	}
+
+	// The first element always starts a run.
+	result := []T{elements[0]}
+
+	// Keep every element that differs from its predecessor.
+	for i := 1; i < len(elements); i++ {
+		if elements[i] != elements[i-1] {
+			result = append(result, elements[i])
+		}
+	}
+
+	// Return one element per run.
+	return result
+}
+
+// SplitWhen cuts the slice into consecutive segments, starting a new segment between two adjacent
+// elements whenever fn(prev, next) returns true. For example, log lines can be grouped into entries
+// with func(_, next string) bool { return isEntryStart(next) }. Every segment is a sub-slice of the input
+// sharing its underlying array, with its capacity limited to the segment length so that appending to
+// a segment never overwrites the input. If the slice is empty, nil is returned.
+func SplitWhen[T any](elements []T, fn func(prev, next T) bool) [][]T {
+	// An empty slice has no segments.
+	if len(elements) == 0 {
+		return nil
+	}
+
+	var result [][]T
+	// start is the index of the first element of the current segment.
+	start := 0
+
+	// Close the current segment at every boundary.
+	for i := 1; i < len(elements); i++ {
+		if fn(elements[i-1], elements[i]) {
+			result = append(result, elements[start:i:i])
+			start = i
+		}
+	}
+
+	// The last segment extends to the end of the slice.
+	result = append(result, elements[start:len(elements):len(elements)])
+
+	// Return the collected segments.
+	return result
+}
+
+// Pairwise returns every pair of adjacent elements in the slice, in order.
+// For the input [a, b, c] the result is [(a, b), (b, c)], which is convenient for computing deltas
+// between consecutive values. Slices with fewer than two elements yield nil.
+func Pairwise[T any](elements []T) []Pair[T, T] {
+	// At least two elements are required to form a pair.
+	if len(elements) < 2 {
+		return nil
+	}
+
+	// A slice of n elements has exactly n-1 adjacent pairs.
+	result := make([]Pair[T, T], 0, len(elements)-1)
+
+	// Pair every element with its successor.
+	for i := 1; i < len(elements); i++ {
+		result = append(result, Pair[T, T]{First: elements[i-1], Second: elements[i]})
+	}
+
+	// Return the collected pairs.
+	return result
+}
+
+// Zip combines two parallel slices into a slice of pairs, pairing the elements at the same index.
+// If the slices differ in length, the extra elements of the longer one are ignored.
+// If either slice is empty, nil is returned.
+func Zip[A, B any](a []A, b []B) []Pair[A, B] {
+	// Only indexes present in both slices can be paired.
+	n := min(len(a), len(b))
+	if n == 0 {
+		return nil
+	}
+
+	// Allocate the result once, as the number of pairs is known.
+	result := make([]Pair[A, B], n)
+
+	// Pair the elements at the same index.
+	for i := range result {
+		result[i] = Pair[A, B]{First: a[i], Second: b[i]}
+	}
+
+	// Return the collected pairs.
+	return result
+}
+
+// Unzip splits a slice of pairs into two parallel slices, the inverse of Zip.
+// The first slice holds the first values of the pairs and the second slice the second values, in order.
+// If the input is empty, both results are nil.
+func Unzip[A, B any](pairs []Pair[A, B]) ([]A, []B) {
+	// An empty input has nothing to split.
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+
+	// Both results have exactly one element per pair.
+	first := make([]A, len(pairs))
+	second := make([]B, len(pairs))
+
+	// Distribute the values of every pair to the two slices.
+	for i, pair := range pairs {
+		first[i] = pair.First
+		second[i] = pair.Second
+	}
+
+	// Return the two parallel slices.
+	return first, second
+}
+
+// TopK returns the k greatest elements of the slice according to the less function,
+// ordered from the greatest to the smallest. It keeps a bounded min-heap of at most k elements,
+// so it runs in O(n log k) time and O(k) memory without sorting the whole input, which is
+// significantly cheaper than a full sort when k is small compared to the number of elements.
+// The input slice is not modified. If k is not positive, nil is returned; if k exceeds the
+// number of elements, all elements are returned in descending order.
+func TopK[T any](elements []T, k int, less func(a, b T) bool) []T {
+	// Nothing can be selected for a non-positive k or an empty input.
+	if k <= 0 || len(elements) == 0 {
+		return nil
+	}
+
+	// The heap never holds more elements than the input provides.
+	if k > len(elements) {
+		k = len(elements)
+	}
+
+	// The heap is a min-heap, so its root is always the smallest of the k greatest elements seen so far.
+	heap := make([]T, 0, k)
+
+	for _, v := range elements {
+		// Fill the heap until it holds k elements.
+		if len(heap) < k {
+			heap = append(heap, v)
+			heapSiftUp(heap, len(heap)-1, less)
+			continue
+		}
+
+		// Replace the root only if the current element is greater than it,
+		// then restore the heap property.
+		if less(heap[0], v) {
+			heap[0] = v
+			heapSiftDown(heap, 0, less)
+		}
+	}
+
+	// Pop the heap from the back: repeatedly moving the smallest element to the end
+	// leaves the slice ordered from the greatest to the smallest element.
+	for end := len(heap) - 1; end > 0; end-- {
+		heap[0], heap[end] = heap[end], heap[0]
+		heapSiftDown(heap[:end], 0, less)
+	}
+
+	// Return the selected elements in descending order.
+	return heap
+}
+
+// NthElement partially reorders the slice in place so that the element at index n is the one that
+// would be there if the slice were sorted according to less. All elements before n are not greater
+// than it, and all elements after n are not less than it, but neither side is sorted.
+// The selection runs in linear time on average. It returns the selected element and true,
+// or the zero value and false if n is out of range.
+func NthElement[T any](elements []T, n int, less func(a, b T) bool) (T, bool) {
+	// Reject indexes outside the slice.
+	if n < 0 || n >= len(elements) {
+		var zero T
+		return zero, false
+	}
+
+	// Narrow the search range around n until it contains a single element.
+	lo, hi := 0, len(elements)-1
+	for lo < hi {
+		// Partition the current range and continue only in the part that contains n.
+		// If n falls into the band of elements equal to the pivot, the pivot is the answer.
+		lt, gt := partition(elements, lo, hi, less)
+		switch {
+		case n < lt:
+			hi = lt - 1
+		case n > gt:
+			lo = gt + 1
+		default:
+			return elements[n], true
+		}
+	}
+
+	// The range collapsed to n, so the element is in its sorted position.
+	return elements[n], true
+}
+
+// partition partitions elements[lo..hi] into three parts around a median-of-three pivot: the elements
+// less than the pivot, those equal to it, and those greater. It returns the first and last index of the
+// equal band. Grouping the equal elements keeps the selection linear on input with many duplicates.
+func partition[T any](elements []T, lo, hi int, less func(a, b T) bool) (int, int) {
+	// Pick the median of the first, middle and last elements as the pivot.
+	// This avoids the quadratic worst case on already sorted input.
+	mid := lo + (hi-lo)/2
+	if less(elements[mid], elements[lo]) {
+		elements[mid], elements[lo] = elements[lo], elements[mid]
+	}
+	if less(elements[hi], elements[lo]) {
+		elements[hi], elements[lo] = elements[lo], elements[hi]
+	}
+	if less(elements[mid], elements[hi]) {
+		elements[mid], elements[hi] = elements[hi], elements[mid]
+	}
+	pivot := elements[hi]
+
+	// Keep elements[lo..lt) less than the pivot, elements[lt..i) equal to it and elements(gt..hi]
+	// greater than it, while elements[i..gt] are not yet classified.
+	lt, i, gt := lo, lo, hi
+	for i <= gt {
+		switch {
+		case less(elements[i], pivot):
+			elements[i], elements[lt] = elements[lt], elements[i]
+			lt++
+			i++
+		case less(pivot, elements[i]):
+			elements[i], elements[gt] = elements[gt], elements[i]
+			gt--
+		default:
+			i++
+		}
+	}
+
+	return lt, gt
+}
+
+// heapSiftUp moves the element at index i up the min-heap until the heap property holds.
+func heapSiftUp[T any](heap []T, i int, less func(a, b T) bool) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		// Stop once the parent is not greater than the element.
+		if !less(heap[i], heap[parent]) {
+			return
+		}
+		heap[i], heap[parent] = heap[parent], heap[i]
+		i = parent
+	}
+}
+
+// heapSiftDown moves the element at index i down the min-heap until the heap property holds.
+func heapSiftDown[T any](heap []T, i int, less func(a, b T) bool) {
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+
+		// Find the smallest of the element and its children.
+		if left < len(heap) && less(heap[left], heap[smallest]) {
+			smallest = left
+		}
+		if right < len(heap) && less(heap[right], heap[smallest]) {
+			smallest = right
+		}
+
+		// Stop once the element is not greater than its children.
+		if smallest == i {
+			return
+		}
+		heap[i], heap[smallest] = heap[smallest], heap[i]
+		i = smallest
+	}
+}
+
+// ChooseWeighted picks a random element from items, where the probability of each element
+// is proportional to the weight at the same index in weights. Elements with a zero weight are never chosen.
+// It returns the chosen element and true, or the zero value and false if the slices are empty,
+// have different lengths, contain a negative weight, or all weights are zero.
+func ChooseWeighted[T any](items []T, weights []float64) (T, bool) {
+	var zero T
+
+	// Every item needs exactly one weight.
+	if len(items) == 0 || len(items) != len(weights) {
+		return zero, false
+	}
+
+	// Sum the weights, rejecting negative values that would make the distribution meaningless.
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 {
+			return zero, false
+		}
+		total += w
+	}
+
+	// Nothing can be chosen if no item has a positive weight.
+	if total == 0 {
+		return zero, false
+	}
+
+	// Pick a random point in [0, total) and find the item whose cumulative weight range contains it.
+	target := rand.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return items[i], true
+		}
+		target -= w
+	}
+
+	// Floating point rounding may leave the target just past the last range;
+	// fall back to the last item with a positive weight.
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return items[i], true
+		}
+	}
+
+	return zero, false
+}
+
+// Sample returns a uniformly random element of the slice and true, or the zero value and false if the
+// slice is empty, for example to pick a random backend host.
+func Sample[T any](elements []T) (T, bool) {
+	// An empty slice has nothing to pick.
+	if len(elements) == 0 {
+		var zero T
+		return zero, false
+	}
+
+	// Every index is equally likely.
+	return elements[rand.IntN(len(elements))], true
+}
+
+// SampleN returns n distinct elements of the slice chosen uniformly at random, leaving the input unchanged.
+// Elements are distinct by position, so duplicates in the input may appear more than once. If n is at least
+// the length of the slice, all elements are returned; if n is not positive, nil is returned.
+// The order of the returned elements is not meaningful.
+func SampleN[T any](elements []T, n int) []T {
+	// Select with reservoir sampling over the slice, which needs only the memory for the result.
+	return ReservoirSample(func(yield func(T) bool) {
+		for _, v := range elements {
+			if !yield(v) {
+				return
+			}
+		}
+	}, n)
+}
+
+// ReservoirSample selects k elements uniformly at random from a sequence of unknown length in a single pass,
+// using Algorithm R. The sequence is a push iterator with the same shape as iter.Seq: it calls yield for every
+// element and stops early when yield returns false. Only O(k) memory is used regardless of the sequence length.
+// If the sequence yields fewer than k elements, all of them are returned. If k is not positive, nil is returned.
+// The order of the returned elements is not meaningful.
+func ReservoirSample[T any](seq func(yield func(T) bool), k int) []T {
+	// Nothing can be sampled for a non-positive k.
+	if k <= 0 {
+		return nil
+	}
+
+	// The reservoir holds the current sample.
+	var reservoir []T
+	// seen counts the number of elements consumed from the sequence so far.
+	seen := 0
+
+	seq(func(v T) bool {
+		seen++
+
+		// Fill the reservoir with the first k elements.
+		if len(reservoir) < k {
+			reservoir = append(reservoir, v)
+			return true
+		}
+
+		// Replace a random element of the reservoir with probability k/seen,
+		// which keeps every element seen so far equally likely to be in the sample.
+		if j := rand.IntN(seen); j < k {
+			reservoir[j] = v
+		}
+
+		return true
+	})
+
+	// Return the sampled elements.
+	return reservoir
+}
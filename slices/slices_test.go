@@ -2,7 +2,9 @@ package slices
 
 import (
 	"fmt"
+	"math/rand/v2"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -281,6 +283,45 @@ func TestContains(t *testing.T) {
 	})
 }
 
+// TestContainsFunc verifies that ContainsFunc and IndexFunc find elements by predicate.
+func TestContainsFunc(t *testing.T) {
+	type host struct {
+		name    string
+		healthy bool
+	}
+	hosts := []host{{"a", false}, {"b", true}, {"c", true}}
+
+	cases := []struct {
+		name     string
+		elements []host
+		fn       func(host) bool
+		index    int
+	}{
+		{name: "First match", elements: hosts, fn: func(h host) bool { return h.healthy }, index: 1},
+		{name: "Match by field", elements: hosts, fn: func(h host) bool { return h.name == "c" }, index: 2},
+		{name: "No match", elements: hosts, fn: func(h host) bool { return h.name == "z" }, index: -1},
+		{name: "Nil slice", elements: nil, fn: func(host) bool { return true }, index: -1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.index, IndexFunc(tt.elements, tt.fn), "Unexpected index for case: %s", tt.name)
+			assert.Equal(t, tt.index >= 0, ContainsFunc(tt.elements, tt.fn), "Unexpected result for case: %s", tt.name)
+		})
+	}
+
+	// StopsAtFirstMatch ensures the predicate is not called after a match.
+	t.Run("StopsAtFirstMatch", func(t *testing.T) {
+		calls := 0
+		ContainsFunc([]int{1, 2, 3, 4}, func(v int) bool {
+			calls++
+			return v == 2
+		})
+
+		assert.Equal(t, 2, calls, "Expected the search to stop at the first match")
+	})
+}
+
 func TestExclude(t *testing.T) {
 	t.Parallel()
 
@@ -454,6 +495,43 @@ func TestExclude(t *testing.T) {
 	})
 }
 
+// TestExcludeCopy verifies that ExcludeCopy leaves the input untouched and ExcludeInPlace reuses it.
+func TestExcludeCopy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		elements []int
+		element  int
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, element: 1, expected: nil},
+		{name: "Empty slice", elements: []int{}, element: 1, expected: []int{}},
+		{name: "Multiple occurrences", elements: []int{3, 1, 3, 2, 3}, element: 3, expected: []int{1, 2}},
+		{name: "All excluded", elements: []int{3, 3}, element: 3, expected: []int{}},
+		{name: "Nothing excluded", elements: []int{1, 2}, element: 3, expected: []int{1, 2}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			original := Merge(tt.elements, nil)
+
+			assert.Equal(t, tt.expected, ExcludeCopy(tt.elements, tt.element), "Unexpected result for case: %s", tt.name)
+			assert.True(t, Equal(original, tt.elements), "Expected the input to stay unchanged for case: %s", tt.name)
+		})
+	}
+
+	// InPlace ensures ExcludeInPlace reuses the underlying array of the input.
+	t.Run("InPlace", func(t *testing.T) {
+		elements := []int{3, 1, 3, 2}
+
+		result := ExcludeInPlace(elements, 3)
+
+		assert.Equal(t, []int{1, 2}, result, "Unexpected result")
+		assert.Equal(t, []int{1, 2, 3, 2}, elements, "Expected the input to be overwritten")
+	})
+}
+
 func TestMap(t *testing.T) {
 	t.Parallel()
 
@@ -850,6 +928,31 @@ func TestFilter(t *testing.T) {
 			})
 		}
 	})
+
+	// SparseMatches ensures a few matches in a large input do not keep a large array alive.
+	t.Run("SparseMatches", func(t *testing.T) {
+		elements := Repeat(1, 1_000_000)
+		elements[500_000] = 2
+
+		result := Filter(elements, func(n int) bool { return n == 2 })
+		assert.Equal(t, []int{2}, result, "Unexpected result")
+		assert.LessOrEqual(t, cap(result), filterSampleSize, "Expected a small capacity")
+
+		mapped := FilterMap(elements, func(n int) (int, bool) { return n, n == 2 })
+		assert.LessOrEqual(t, cap(mapped), filterSampleSize, "Expected a small capacity")
+	})
+
+	// DenseMatches ensures the capacity for the matches is reserved from the ratio of the sample.
+	t.Run("DenseMatches", func(t *testing.T) {
+		elements := make([]int, 1_000_000)
+		for i := range elements {
+			elements[i] = i
+		}
+
+		result := Filter(elements, func(n int) bool { return n%2 == 0 })
+		assert.Len(t, result, 500_000, "Unexpected number of matches")
+		assert.Equal(t, 500_000, cap(result), "Expected the reserved capacity to fit the matches")
+	})
 }
 
 func TestUnique(t *testing.T) {
@@ -957,7 +1060,1102 @@ func TestUnique(t *testing.T) {
 	}
 }
 
+// TestUniqueBy verifies that UniqueBy keeps the first element of every key in order.
+func TestUniqueBy(t *testing.T) {
+	type task struct {
+		id      int
+		payload string
+	}
+
+	cases := []struct {
+		name     string
+		elements []task
+		expected []task
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "No duplicates", elements: []task{{1, "a"}, {2, "b"}}, expected: []task{{1, "a"}, {2, "b"}}},
+		{
+			name:     "Duplicate IDs",
+			elements: []task{{1, "a"}, {2, "b"}, {1, "c"}, {3, "d"}, {2, "e"}},
+			expected: []task{{1, "a"}, {2, "b"}, {3, "d"}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result := UniqueBy(tt.elements, func(v task) int { return v.id })
+			assert.Equal(t, tt.expected, result, "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestFilterMap verifies that FilterMap transforms the kept elements in order.
+func TestFilterMap(t *testing.T) {
+	// parse keeps the strings that are numbers, converted to integers.
+	parse := func(s string) (int, bool) {
+		n, err := strconv.Atoi(s)
+		return n, err == nil
+	}
+
+	cases := []struct {
+		name     string
+		elements []string
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "None kept", elements: []string{"a", "b"}, expected: nil},
+		{name: "Some kept", elements: []string{"1", "x", "22", "", "3"}, expected: []int{1, 22, 3}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FilterMap(tt.elements, parse), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestFilterInto verifies that FilterInto appends the matching elements to the destination
+// slice and that it can reuse the source array for in-place filtering without allocations.
+func TestFilterInto(t *testing.T) {
+	// isEven is the predicate shared by the subtests.
+	isEven := func(n int) bool { return n%2 == 0 }
+
+	// AppendToDestination ensures matching elements are appended after the existing contents of dst.
+	t.Run("AppendToDestination", func(t *testing.T) {
+		dst := []int{100}
+		result := FilterInto(dst, []int{1, 2, 3, 4, 5, 6}, isEven)
+
+		assert.Equal(t, []int{100, 2, 4, 6}, result, "Expected matching elements to follow existing ones")
+	})
+
+	// NilDestination ensures a nil destination stays nil when nothing matches.
+	t.Run("NilDestination", func(t *testing.T) {
+		result := FilterInto(nil, []int{1, 3, 5}, isEven)
+
+		assert.Nil(t, result, "Expected nil when no element matches")
+	})
+
+	// InPlace ensures that passing src[:0] filters the source slice in place.
+	t.Run("InPlace", func(t *testing.T) {
+		src := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		result := FilterInto(src[:0], src, isEven)
+
+		assert.Equal(t, []int{2, 4, 6, 8}, result, "Expected only even numbers")
+		assert.Equal(t, &src[0], &result[0], "Expected the source array to be reused")
+	})
+
+	// NoAllocations ensures that no allocations happen when dst has enough capacity.
+	t.Run("NoAllocations", func(t *testing.T) {
+		src := test.CreateSequenceWithoutRepeats(1000)
+		dst := make([]int, 0, len(src))
+
+		allocs := testing.AllocsPerRun(100, func() {
+			dst = FilterInto(dst[:0], src, isEven)
+		})
+
+		assert.Zero(t, allocs, "Expected no allocations with a preallocated destination")
+	})
+}
+
+// TestPartition verifies that Partition splits the elements by the predicate in a single pass.
+func TestPartition(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	cases := []struct {
+		name     string
+		elements []int
+		matched  []int
+		rest     []int
+	}{
+		{name: "Nil slice", elements: nil, matched: nil, rest: nil},
+		{name: "All match", elements: []int{2, 4}, matched: []int{2, 4}, rest: nil},
+		{name: "None match", elements: []int{1, 3}, matched: nil, rest: []int{1, 3}},
+		{name: "Mixed", elements: []int{1, 2, 3, 4, 5, 6}, matched: []int{2, 4, 6}, rest: []int{1, 3, 5}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			matched, rest := Partition(tt.elements, func(v int) bool {
+				calls++
+				return isEven(v)
+			})
+
+			assert.Equal(t, tt.matched, matched, "Unexpected matched elements for case: %s", tt.name)
+			assert.Equal(t, tt.rest, rest, "Unexpected remaining elements for case: %s", tt.name)
+			assert.Equal(t, len(tt.elements), calls, "Expected one predicate call per element for case: %s", tt.name)
+		})
+	}
+}
+
+// TestCount verifies that Count and CountBy count occurrences and matches.
+func TestCount(t *testing.T) {
+	elements := []string{"a", "b", "a", "c", "a"}
+
+	assert.Equal(t, 3, Count(elements, "a"), "Unexpected count of a")
+	assert.Equal(t, 0, Count(elements, "z"), "Unexpected count of a missing element")
+	assert.Equal(t, 0, Count(nil, "a"), "Unexpected count in a nil slice")
+
+	assert.Equal(t, 2, CountBy(elements, func(v string) bool { return v != "a" }), "Unexpected count of matches")
+	assert.Equal(t, 0, CountBy(nil, func(string) bool { return true }), "Unexpected count in a nil slice")
+}
+
+// TestSplice verifies Insert, RemoveAt and Splice including their bounds validation.
+func TestSplice(t *testing.T) {
+	elements := []string{"a", "b", "c", "d"}
+
+	cases := []struct {
+		name     string
+		fn       func() ([]string, error)
+		expected []string
+		err      error
+	}{
+		{name: "Insert at start", fn: func() ([]string, error) { return Insert(elements, 0, "x") }, expected: []string{"x", "a", "b", "c", "d"}},
+		{name: "Insert in middle", fn: func() ([]string, error) { return Insert(elements, 2, "x", "y") }, expected: []string{"a", "b", "x", "y", "c", "d"}},
+		{name: "Insert at end", fn: func() ([]string, error) { return Insert(elements, 4, "x") }, expected: []string{"a", "b", "c", "d", "x"}},
+		{name: "Insert into nil", fn: func() ([]string, error) { return Insert(nil, 0, "x") }, expected: []string{"x"}},
+		{name: "Insert past end", fn: func() ([]string, error) { return Insert(elements, 5, "x") }, err: ErrIndexOutOfRange},
+		{name: "Insert negative", fn: func() ([]string, error) { return Insert(elements, -1, "x") }, err: ErrIndexOutOfRange},
+		{name: "RemoveAt first", fn: func() ([]string, error) { return RemoveAt(elements, 0) }, expected: []string{"b", "c", "d"}},
+		{name: "RemoveAt last", fn: func() ([]string, error) { return RemoveAt(elements, 3) }, expected: []string{"a", "b", "c"}},
+		{name: "RemoveAt past end", fn: func() ([]string, error) { return RemoveAt(elements, 4) }, err: ErrIndexOutOfRange},
+		{name: "RemoveAt from nil", fn: func() ([]string, error) { return RemoveAt[string](nil, 0) }, err: ErrIndexOutOfRange},
+		{name: "Splice replace", fn: func() ([]string, error) { return Splice(elements, 1, 2, "x") }, expected: []string{"a", "x", "d"}},
+		{name: "Splice delete all", fn: func() ([]string, error) { return Splice(elements, 0, 4) }, expected: []string{}},
+		{name: "Splice count past end", fn: func() ([]string, error) { return Splice(elements, 3, 2) }, err: ErrIndexOutOfRange},
+		{name: "Splice negative count", fn: func() ([]string, error) { return Splice(elements, 1, -1) }, err: ErrIndexOutOfRange},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.fn()
+
+			assert.ErrorIs(t, err, tt.err, "Unexpected error for case: %s", tt.name)
+			assert.Equal(t, tt.expected, result, "Unexpected result for case: %s", tt.name)
+		})
+	}
+
+	// InputUnchanged ensures none of the operations modifies the input.
+	assert.Equal(t, []string{"a", "b", "c", "d"}, elements, "Expected the input slice to stay unchanged")
+}
+
+// TestRepeat verifies that Repeat returns n copies of the value.
+func TestRepeat(t *testing.T) {
+	assert.Equal(t, []string{"x", "x", "x"}, Repeat("x", 3), "Unexpected repeated values")
+	assert.Nil(t, Repeat("x", 0), "Expected nil for a zero count")
+	assert.Nil(t, Repeat("x", -1), "Expected nil for a negative count")
+}
+
+// TestGenerate verifies that Generate computes every element from its index.
+func TestGenerate(t *testing.T) {
+	assert.Equal(t, []int{0, 1, 4, 9}, Generate(4, func(i int) int { return i * i }), "Unexpected generated values")
+	assert.Nil(t, Generate(0, func(i int) int { return i }), "Expected nil for a zero count")
+}
+
+// TestFill verifies that Fill overwrites every element in place.
+func TestFill(t *testing.T) {
+	dst := make([]int, 3, 10)
+	Fill(dst, 7)
+
+	assert.Equal(t, []int{7, 7, 7}, dst, "Expected every element to be set")
+	assert.Equal(t, 0, dst[:4][3], "Expected the spare capacity to stay untouched")
+
+	// A nil slice has nothing to fill.
+	Fill[int](nil, 7)
+}
+
+// TestBinarySearch verifies searching sorted slices for present and missing elements.
+func TestBinarySearch(t *testing.T) {
+	sorted := []int{1, 3, 3, 5, 8}
+
+	cases := []struct {
+		name     string
+		elements []int
+		element  int
+		index    int
+		found    bool
+	}{
+		{name: "Nil slice", elements: nil, element: 1, index: 0, found: false},
+		{name: "First", elements: sorted, element: 1, index: 0, found: true},
+		{name: "First duplicate", elements: sorted, element: 3, index: 1, found: true},
+		{name: "Last", elements: sorted, element: 8, index: 4, found: true},
+		{name: "Missing in middle", elements: sorted, element: 4, index: 3, found: false},
+		{name: "Missing before start", elements: sorted, element: 0, index: 0, found: false},
+		{name: "Missing past end", elements: sorted, element: 9, index: 5, found: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			index, found := BinarySearch(tt.elements, tt.element)
+
+			assert.Equal(t, tt.index, index, "Unexpected index for case: %s", tt.name)
+			assert.Equal(t, tt.found, found, "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestReduce verifies that Reduce folds the elements in order and returns the initial value for empty slices.
+func TestReduce(t *testing.T) {
+	// Sum ensures numbers are aggregated into a single value.
+	t.Run("Sum", func(t *testing.T) {
+		sum := Reduce([]int{1, 2, 3, 4}, 0, func(acc, v int) int { return acc + v })
+		assert.Equal(t, 10, sum, "Unexpected sum")
+	})
+
+	// Order ensures elements are applied from first to last.
+	t.Run("Order", func(t *testing.T) {
+		joined := Reduce([]string{"a", "b", "c"}, ">", func(acc, v string) string { return acc + v })
+		assert.Equal(t, ">abc", joined, "Expected the elements in order")
+	})
+
+	// DifferentResultType ensures the result type can differ from the element type.
+	t.Run("DifferentResultType", func(t *testing.T) {
+		lengths := Reduce([]string{"go", "rust", "go"}, map[string]int{}, func(acc map[string]int, v string) map[string]int {
+			acc[v] = len(v)
+			return acc
+		})
+		assert.Equal(t, map[string]int{"go": 2, "rust": 4}, lengths, "Unexpected map")
+	})
+
+	// Empty ensures the initial value is returned for nil and empty slices.
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 42, Reduce(nil, 42, func(acc, v int) int { return acc + v }), "Expected the initial value for nil")
+		assert.Equal(t, 42, Reduce([]int{}, 42, func(acc, v int) int { return acc + v }), "Expected the initial value for an empty slice")
+	})
+}
+
+// TestMinMax verifies that Min and Max find the extreme elements and report empty slices.
+func TestMinMax(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements []int
+		min      int
+		max      int
+		ok       bool
+	}{
+		{name: "Nil slice", elements: nil, ok: false},
+		{name: "Single element", elements: []int{7}, min: 7, max: 7, ok: true},
+		{name: "Several elements", elements: []int{3, -2, 9, 0, 9}, min: -2, max: 9, ok: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			minimum, ok := Min(tt.elements)
+			assert.Equal(t, tt.ok, ok, "Unexpected Min flag for case: %s", tt.name)
+			assert.Equal(t, tt.min, minimum, "Unexpected Min for case: %s", tt.name)
+
+			maximum, ok := Max(tt.elements)
+			assert.Equal(t, tt.ok, ok, "Unexpected Max flag for case: %s", tt.name)
+			assert.Equal(t, tt.max, maximum, "Unexpected Max for case: %s", tt.name)
+		})
+	}
+
+	// Strings ensures ordered non-numeric types are supported.
+	t.Run("Strings", func(t *testing.T) {
+		minimum, _ := Min([]string{"pear", "apple", "plum"})
+		maximum, _ := Max([]string{"pear", "apple", "plum"})
+
+		assert.Equal(t, "apple", minimum, "Unexpected Min")
+		assert.Equal(t, "plum", maximum, "Unexpected Max")
+	})
+}
+
+// TestSum verifies that Sum adds up integers and floats and returns zero for empty slices.
+func TestSum(t *testing.T) {
+	assert.Equal(t, 0, Sum[int](nil), "Expected zero for a nil slice")
+	assert.Equal(t, 10, Sum([]int{1, 2, 3, 4}), "Unexpected integer sum")
+	assert.InDelta(t, 4.0, Sum([]float64{1.5, 2.25, 0.25}), 1e-9, "Unexpected float sum")
+}
+
+// TestGroupBy verifies that GroupBy groups elements by key and preserves their order within a group.
+func TestGroupBy(t *testing.T) {
+	type user struct {
+		name string
+		team string
+	}
+
+	// Groups ensures elements with the same key end up in the same group, in input order.
+	t.Run("Groups", func(t *testing.T) {
+		users := []user{{"ann", "ops"}, {"bob", "dev"}, {"cid", "ops"}, {"dan", "dev"}, {"eve", "qa"}}
+
+		groups := GroupBy(users, func(u user) string { return u.team })
+
+		assert.Equal(t, map[string][]user{
+			"ops": {{"ann", "ops"}, {"cid", "ops"}},
+			"dev": {{"bob", "dev"}, {"dan", "dev"}},
+			"qa":  {{"eve", "qa"}},
+		}, groups, "Unexpected groups")
+	})
+
+	// DerivedKey ensures keys can be computed from the elements.
+	t.Run("DerivedKey", func(t *testing.T) {
+		groups := GroupBy([]int{1, 2, 3, 4, 5}, func(v int) bool { return v%2 == 0 })
+
+		assert.Equal(t, map[bool][]int{true: {2, 4}, false: {1, 3, 5}}, groups, "Unexpected groups")
+	})
+
+	// Empty ensures nil and empty slices produce an empty, non-nil map.
+	t.Run("Empty", func(t *testing.T) {
+		groups := GroupBy(nil, func(v int) int { return v })
+
+		assert.NotNil(t, groups, "Expected a non-nil map")
+		assert.Empty(t, groups, "Expected no groups")
+	})
+}
+
+// TestSample verifies that Sample picks an element of the slice.
+func TestSample(t *testing.T) {
+	_, ok := Sample[int](nil)
+	assert.False(t, ok, "Expected no element from a nil slice")
+
+	hosts := []string{"a", "b", "c"}
+	seen := map[string]bool{}
+	for range 200 {
+		host, ok := Sample(hosts)
+		assert.True(t, ok, "Expected an element")
+		seen[host] = true
+	}
+
+	assert.Len(t, seen, 3, "Expected every element to be picked eventually")
+}
+
+// TestSampleN verifies that SampleN picks distinct elements without modifying the input.
+func TestSampleN(t *testing.T) {
+	elements := Generate(20, func(i int) int { return i })
+
+	assert.Nil(t, SampleN(elements, 0), "Expected nil for a zero count")
+	assert.ElementsMatch(t, elements, SampleN(elements, 50), "Expected all elements when n exceeds the length")
+
+	sample := SampleN(elements, 5)
+	assert.Len(t, sample, 5, "Unexpected sample size")
+	assert.Len(t, Unique(sample), 5, "Expected distinct elements")
+	assert.Subset(t, elements, sample, "Expected elements of the input")
+	assert.Equal(t, Generate(20, func(i int) int { return i }), elements, "Expected the input slice to stay unchanged")
+}
+
 // strPtr is a helper function to create a pointer to a string.
 func strPtr(s string) *string {
 	return &s
 }
+
+// TestUnion verifies that Union merges the slices and keeps only the first occurrence of every element.
+func TestUnion(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		slices   [][]int
+		expected []int
+	}{
+		{name: "No slices", slices: nil, expected: nil},
+		{name: "Only empty slices", slices: [][]int{nil, {}}, expected: nil},
+		{name: "Single slice", slices: [][]int{{3, 1, 3, 2}}, expected: []int{3, 1, 2}},
+		{name: "Two slices", slices: [][]int{{1, 2}, {2, 3}}, expected: []int{1, 2, 3}},
+		{name: "Many slices", slices: [][]int{{5}, nil, {4, 5}, {1, 4, 1}}, expected: []int{5, 4, 1}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Union(tt.slices...), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestIntersect verifies that the common elements are returned once, in the order of the first slice.
+func TestIntersect(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    []int
+		second   []int
+		expected []int
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: nil},
+		{name: "First nil", first: nil, second: []int{1, 2}, expected: nil},
+		{name: "Second nil", first: []int{1, 2}, second: nil, expected: nil},
+		{name: "No common elements", first: []int{1, 2}, second: []int{3, 4}, expected: nil},
+		{name: "Order of first slice", first: []int{4, 1, 3, 2}, second: []int{2, 3, 4}, expected: []int{4, 3, 2}},
+		{name: "Duplicates in first slice", first: []int{1, 2, 1, 2, 3}, second: []int{2, 1}, expected: []int{1, 2}},
+		{name: "Duplicates in second slice", first: []int{1, 2, 3}, second: []int{3, 3, 1, 1}, expected: []int{1, 3}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Intersect(tt.first, tt.second), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestDifference verifies that the elements missing from the second slice are returned once, in the order of the first slice.
+func TestDifference(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    []string
+		second   []string
+		expected []string
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: nil},
+		{name: "First nil", first: nil, second: []string{"a"}, expected: nil},
+		{name: "Second nil", first: []string{"b", "a", "b"}, second: nil, expected: []string{"b", "a"}},
+		{name: "Nothing remains", first: []string{"a", "b"}, second: []string{"b", "a"}, expected: nil},
+		{name: "Order of first slice", first: []string{"d", "a", "c", "b"}, second: []string{"a"}, expected: []string{"d", "c", "b"}},
+		{name: "Duplicates in first slice", first: []string{"a", "b", "a", "c", "b"}, second: []string{"c"}, expected: []string{"a", "b"}},
+		{name: "Duplicates in second slice", first: []string{"a", "b", "c"}, second: []string{"b", "b"}, expected: []string{"a", "c"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Difference(tt.first, tt.second), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestEqual verifies that Equal compares slices element by element in order.
+func TestEqual(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    []int
+		second   []int
+		expected bool
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: true},
+		{name: "Nil and empty", first: nil, second: []int{}, expected: true},
+		{name: "Same elements", first: []int{1, 2, 3}, second: []int{1, 2, 3}, expected: true},
+		{name: "Different order", first: []int{1, 2, 3}, second: []int{3, 2, 1}, expected: false},
+		{name: "Different length", first: []int{1, 2}, second: []int{1, 2, 3}, expected: false},
+		{name: "Different element", first: []int{1, 2, 3}, second: []int{1, 2, 4}, expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Compare the slices and check the result against the expectation.
+			assert.Equal(t, tt.expected, Equal(tt.first, tt.second), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestEqualFunc verifies that EqualFunc compares slices in order with the given function.
+func TestEqualFunc(t *testing.T) {
+	// sameFold compares strings ignoring case.
+	sameFold := func(a, b string) bool { return strings.EqualFold(a, b) }
+
+	cases := []struct {
+		name     string
+		first    []string
+		second   []string
+		expected bool
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: true},
+		{name: "Nil and empty", first: nil, second: []string{}, expected: true},
+		{name: "Equal under eq", first: []string{"A", "b"}, second: []string{"a", "B"}, expected: true},
+		{name: "Different order", first: []string{"a", "b"}, second: []string{"b", "a"}, expected: false},
+		{name: "Different lengths", first: []string{"a"}, second: []string{"a", "a"}, expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, EqualFunc(tt.first, tt.second, sameFold), "Unexpected result for case: %s", tt.name)
+		})
+	}
+
+	// NonComparable ensures element types without == are supported.
+	t.Run("NonComparable", func(t *testing.T) {
+		first := [][]int{{1, 2}, {3}}
+		second := [][]int{{1, 2}, {3}}
+
+		assert.True(t, EqualFunc(first, second, Equal[int]), "Expected nested slices to be equal")
+	})
+}
+
+// TestEqualUnordered verifies that EqualUnordered compares slices as multisets.
+func TestEqualUnordered(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    []string
+		second   []string
+		expected bool
+	}{
+		{name: "Both nil", first: nil, second: nil, expected: true},
+		{name: "Nil and empty", first: nil, second: []string{}, expected: true},
+		{name: "Same order", first: []string{"a", "b"}, second: []string{"a", "b"}, expected: true},
+		{name: "Different order", first: []string{"a", "b", "c"}, second: []string{"c", "a", "b"}, expected: true},
+		{name: "Same duplicates", first: []string{"a", "a", "b"}, second: []string{"b", "a", "a"}, expected: true},
+		{name: "Different multiplicity", first: []string{"a", "a", "b"}, second: []string{"a", "b", "b"}, expected: false},
+		{name: "Different length", first: []string{"a"}, second: []string{"a", "a"}, expected: false},
+		{name: "Missing element", first: []string{"a", "b"}, second: []string{"a", "c"}, expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Keep copies of the inputs to make sure they are not modified.
+			first := Merge(tt.first, nil)
+			second := Merge(tt.second, nil)
+
+			assert.Equal(t, tt.expected, EqualUnordered(tt.first, tt.second), "Unexpected result for case: %s", tt.name)
+			assert.True(t, Equal(first, tt.first), "Expected the first slice to stay unchanged")
+			assert.True(t, Equal(second, tt.second), "Expected the second slice to stay unchanged")
+		})
+	}
+}
+
+// TestCompare verifies that Compare orders slices lexicographically.
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    []int
+		second   []int
+		expected int
+	}{
+		{name: "Both empty", first: nil, second: []int{}, expected: 0},
+		{name: "Equal", first: []int{1, 2, 3}, second: []int{1, 2, 3}, expected: 0},
+		{name: "Less by element", first: []int{1, 2, 3}, second: []int{1, 3, 0}, expected: -1},
+		{name: "Greater by element", first: []int{2}, second: []int{1, 9, 9}, expected: 1},
+		{name: "Prefix is less", first: []int{1, 2}, second: []int{1, 2, 3}, expected: -1},
+		{name: "Longer is greater", first: []int{1, 2, 3}, second: []int{1, 2}, expected: 1},
+		{name: "Empty is less", first: nil, second: []int{0}, expected: -1},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Compare(tt.first, tt.second), "Unexpected result for case: %s", tt.name)
+		})
+	}
+}
+
+// TestWindows verifies that Windows returns overlapping windows that do not leak capacity into the input.
+func TestWindows(t *testing.T) {
+	t.Parallel()
+
+	// Windows checks the produced windows for a range of sizes.
+	t.Run("Windows", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			elements []int
+			size     int
+			expected [][]int
+		}{
+			{name: "Nil slice", elements: nil, size: 1, expected: nil},
+			{name: "Zero size", elements: []int{1, 2}, size: 0, expected: nil},
+			{name: "Negative size", elements: []int{1, 2}, size: -1, expected: nil},
+			{name: "Size exceeds length", elements: []int{1, 2}, size: 3, expected: nil},
+			{name: "Size one", elements: []int{1, 2, 3}, size: 1, expected: [][]int{{1}, {2}, {3}}},
+			{name: "Size two", elements: []int{1, 2, 3, 4}, size: 2, expected: [][]int{{1, 2}, {2, 3}, {3, 4}}},
+			{name: "Size equals length", elements: []int{1, 2, 3}, size: 3, expected: [][]int{{1, 2, 3}}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, Windows(tt.elements, tt.size), "Unexpected windows for case: %s", tt.name)
+			})
+		}
+	})
+
+	// AppendDoesNotOverwrite ensures that appending to a window does not modify the input slice.
+	t.Run("AppendDoesNotOverwrite", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4}
+		windows := Windows(elements, 2)
+
+		_ = append(windows[0], 100)
+
+		assert.Equal(t, []int{1, 2, 3, 4}, elements, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestChunk verifies that Chunk splits slices into batches of the given size.
+func TestChunk(t *testing.T) {
+	t.Parallel()
+
+	// Chunk checks the produced batches for a range of sizes.
+	t.Run("Chunk", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			elements []int
+			size     int
+			expected [][]int
+		}{
+			{name: "Nil slice", elements: nil, size: 2, expected: nil},
+			{name: "Empty slice", elements: []int{}, size: 2, expected: nil},
+			{name: "Zero size", elements: []int{1, 2}, size: 0, expected: nil},
+			{name: "Negative size", elements: []int{1, 2}, size: -1, expected: nil},
+			{name: "Even split", elements: []int{1, 2, 3, 4}, size: 2, expected: [][]int{{1, 2}, {3, 4}}},
+			{name: "Smaller last batch", elements: []int{1, 2, 3, 4, 5}, size: 2, expected: [][]int{{1, 2}, {3, 4}, {5}}},
+			{name: "Size exceeds length", elements: []int{1, 2}, size: 5, expected: [][]int{{1, 2}}},
+			{name: "Size one", elements: []int{1, 2, 3}, size: 1, expected: [][]int{{1}, {2}, {3}}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, Chunk(tt.elements, tt.size), "Unexpected batches for case: %s", tt.name)
+			})
+		}
+	})
+
+	// AppendDoesNotOverwrite ensures that appending to a batch does not modify the input slice.
+	t.Run("AppendDoesNotOverwrite", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4}
+		batches := Chunk(elements, 2)
+
+		_ = append(batches[0], 100)
+
+		assert.Equal(t, []int{1, 2, 3, 4}, elements, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestReverse verifies that Reverse returns a reversed copy without modifying the input.
+func TestReverse(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Empty slice", elements: []int{}, expected: []int{}},
+		{name: "Single element", elements: []int{1}, expected: []int{1}},
+		{name: "Several elements", elements: []int{1, 2, 3, 4}, expected: []int{4, 3, 2, 1}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Reverse(tt.elements), "Unexpected result for case: %s", tt.name)
+		})
+	}
+
+	// InputUnchanged ensures the input slice is left untouched.
+	t.Run("InputUnchanged", func(t *testing.T) {
+		elements := []int{1, 2, 3}
+		Reverse(elements)
+
+		assert.Equal(t, []int{1, 2, 3}, elements, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestShuffle verifies that Shuffle permutes a copy of the slice and is deterministic with a seeded generator.
+func TestShuffle(t *testing.T) {
+	// Nil ensures a nil slice stays nil.
+	t.Run("Nil", func(t *testing.T) {
+		assert.Nil(t, Shuffle[int](nil, nil), "Expected nil for a nil slice")
+	})
+
+	// Permutation ensures the result holds the same elements and the input is left untouched.
+	t.Run("Permutation", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		shuffled := Shuffle(elements, nil)
+
+		assert.ElementsMatch(t, elements, shuffled, "Expected the same elements")
+		assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, elements, "Expected the input slice to stay unchanged")
+	})
+
+	// Deterministic ensures generators with the same seed produce the same order.
+	t.Run("Deterministic", func(t *testing.T) {
+		elements := []int{1, 2, 3, 4, 5, 6, 7, 8}
+		first := Shuffle(elements, rand.New(rand.NewPCG(1, 2)))
+		second := Shuffle(elements, rand.New(rand.NewPCG(1, 2)))
+
+		assert.Equal(t, first, second, "Expected the same order for the same seed")
+	})
+}
+
+// TestSortBy verifies that SortBy and SortStableBy return copies ordered by key.
+func TestSortBy(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+
+	tasks := []task{{"c", 2}, {"a", 1}, {"d", 2}, {"b", 1}, {"e", 0}}
+	priority := func(v task) int { return v.priority }
+
+	// Nil ensures a nil slice stays nil.
+	t.Run("Nil", func(t *testing.T) {
+		assert.Nil(t, SortBy[task](nil, priority), "Expected nil from SortBy")
+		assert.Nil(t, SortStableBy[task](nil, priority), "Expected nil from SortStableBy")
+	})
+
+	// Unstable ensures SortBy orders the keys.
+	t.Run("Unstable", func(t *testing.T) {
+		sorted := SortBy(tasks, priority)
+
+		assert.Equal(t, []int{0, 1, 1, 2, 2}, Map(sorted, priority), "Expected the keys in ascending order")
+		assert.ElementsMatch(t, tasks, sorted, "Expected the same elements")
+	})
+
+	// Stable ensures SortStableBy keeps equal keys in their original order.
+	t.Run("Stable", func(t *testing.T) {
+		sorted := SortStableBy(tasks, priority)
+
+		assert.Equal(t, []task{{"e", 0}, {"a", 1}, {"b", 1}, {"c", 2}, {"d", 2}}, sorted, "Unexpected order")
+	})
+
+	// InputUnchanged ensures the input slice is left untouched.
+	t.Run("InputUnchanged", func(t *testing.T) {
+		SortBy(tasks, priority)
+		SortStableBy(tasks, priority)
+
+		assert.Equal(t, []task{{"c", 2}, {"a", 1}, {"d", 2}, {"b", 1}, {"e", 0}}, tasks, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestCompactConsecutive verifies that CompactConsecutive collapses runs of equal adjacent elements.
+func TestCompactConsecutive(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements []int
+		expected []int
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Single element", elements: []int{1}, expected: []int{1}},
+		{name: "Sorted with duplicates", elements: []int{1, 1, 2, 3, 3, 3}, expected: []int{1, 2, 3}},
+		{name: "Non-adjacent repeats are kept", elements: []int{1, 2, 1, 1, 2}, expected: []int{1, 2, 1, 2}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, CompactConsecutive(tt.elements), "Unexpected result for case: %s", tt.name)
+		})
+	}
+
+	// InputUnchanged ensures the input slice is left untouched.
+	t.Run("InputUnchanged", func(t *testing.T) {
+		elements := []int{1, 1, 2}
+		CompactConsecutive(elements)
+
+		assert.Equal(t, []int{1, 1, 2}, elements, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestSplitWhen verifies that SplitWhen cuts the slice at the boundaries reported by the predicate.
+func TestSplitWhen(t *testing.T) {
+	// entryStart starts a new segment at every line that is not indented.
+	entryStart := func(_, next string) bool { return !strings.HasPrefix(next, " ") }
+
+	cases := []struct {
+		name     string
+		elements []string
+		expected [][]string
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Single segment", elements: []string{"error", "  at main"}, expected: [][]string{{"error", "  at main"}}},
+		{
+			name:     "Several segments",
+			elements: []string{"start", "error", "  at foo", "  at main", "done"},
+			expected: [][]string{{"start"}, {"error", "  at foo", "  at main"}, {"done"}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SplitWhen(tt.elements, entryStart), "Unexpected segments for case: %s", tt.name)
+		})
+	}
+
+	// Increasing splits a sequence where it stops increasing, using both elements.
+	t.Run("Increasing", func(t *testing.T) {
+		segments := SplitWhen([]int{1, 2, 5, 3, 4, 1}, func(prev, next int) bool { return next < prev })
+
+		assert.Equal(t, [][]int{{1, 2, 5}, {3, 4}, {1}}, segments, "Unexpected segments")
+	})
+
+	// CappedSegments ensures appending to a segment does not overwrite the input.
+	t.Run("CappedSegments", func(t *testing.T) {
+		elements := []int{1, 2, 1, 2}
+		segments := SplitWhen(elements, func(prev, next int) bool { return next < prev })
+		_ = append(segments[0], 100)
+
+		assert.Equal(t, []int{1, 2, 1, 2}, elements, "Expected the input slice to stay unchanged")
+	})
+}
+
+// TestPairwise verifies that Pairwise returns all adjacent pairs in order.
+func TestPairwise(t *testing.T) {
+	cases := []struct {
+		name     string
+		elements []string
+		expected []Pair[string, string]
+	}{
+		{name: "Nil slice", elements: nil, expected: nil},
+		{name: "Single element", elements: []string{"a"}, expected: nil},
+		{name: "Two elements", elements: []string{"a", "b"}, expected: []Pair[string, string]{{First: "a", Second: "b"}}},
+		{
+			name:     "Several elements",
+			elements: []string{"a", "b", "c", "d"},
+			expected: []Pair[string, string]{{First: "a", Second: "b"}, {First: "b", Second: "c"}, {First: "c", Second: "d"}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Pairwise(tt.elements), "Unexpected pairs for case: %s", tt.name)
+		})
+	}
+}
+
+// TestZip verifies that Zip pairs the elements of two slices by index.
+func TestZip(t *testing.T) {
+	cases := []struct {
+		name     string
+		ids      []int
+		payloads []string
+		expected []Pair[int, string]
+	}{
+		{name: "Nil slices", ids: nil, payloads: nil, expected: nil},
+		{name: "One empty slice", ids: []int{1, 2}, payloads: []string{}, expected: nil},
+		{
+			name:     "Same length",
+			ids:      []int{1, 2, 3},
+			payloads: []string{"a", "b", "c"},
+			expected: []Pair[int, string]{{First: 1, Second: "a"}, {First: 2, Second: "b"}, {First: 3, Second: "c"}},
+		},
+		{
+			name:     "First longer",
+			ids:      []int{1, 2, 3},
+			payloads: []string{"a"},
+			expected: []Pair[int, string]{{First: 1, Second: "a"}},
+		},
+		{
+			name:     "Second longer",
+			ids:      []int{1},
+			payloads: []string{"a", "b"},
+			expected: []Pair[int, string]{{First: 1, Second: "a"}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Zip(tt.ids, tt.payloads), "Unexpected pairs for case: %s", tt.name)
+		})
+	}
+}
+
+// TestUnzip verifies that Unzip splits pairs into parallel slices and reverses Zip.
+func TestUnzip(t *testing.T) {
+	// Empty ensures an empty input yields nil slices.
+	t.Run("Empty", func(t *testing.T) {
+		ids, payloads := Unzip[int, string](nil)
+
+		assert.Nil(t, ids, "Expected nil first values")
+		assert.Nil(t, payloads, "Expected nil second values")
+	})
+
+	// RoundTrip ensures Unzip restores the slices combined by Zip.
+	t.Run("RoundTrip", func(t *testing.T) {
+		ids, payloads := Unzip(Zip([]int{1, 2, 3}, []string{"a", "b", "c"}))
+
+		assert.Equal(t, []int{1, 2, 3}, ids, "Unexpected first values")
+		assert.Equal(t, []string{"a", "b", "c"}, payloads, "Unexpected second values")
+	})
+}
+
+// TestTopK verifies that TopK selects the greatest elements in descending order without modifying the input.
+func TestTopK(t *testing.T) {
+	t.Parallel()
+
+	// less orders integers in ascending order.
+	less := func(a, b int) bool { return a < b }
+
+	// Selection checks the selected elements for a range of inputs.
+	t.Run("Selection", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			elements []int
+			k        int
+			expected []int
+		}{
+			{name: "Nil slice", elements: nil, k: 3, expected: nil},
+			{name: "Zero k", elements: []int{1, 2, 3}, k: 0, expected: nil},
+			{name: "Negative k", elements: []int{1, 2, 3}, k: -1, expected: nil},
+			{name: "Single greatest", elements: []int{4, 9, 1, 7}, k: 1, expected: []int{9}},
+			{name: "Several greatest", elements: []int{5, 1, 9, 3, 7, 2, 8}, k: 3, expected: []int{9, 8, 7}},
+			{name: "With duplicates", elements: []int{5, 9, 5, 9, 1}, k: 3, expected: []int{9, 9, 5}},
+			{name: "K exceeds length", elements: []int{3, 1, 2}, k: 10, expected: []int{3, 2, 1}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, TopK(tt.elements, tt.k, less), "Unexpected selection for case: %s", tt.name)
+			})
+		}
+	})
+
+	// InputUnchanged ensures the input slice is left untouched.
+	t.Run("InputUnchanged", func(t *testing.T) {
+		elements := []int{5, 1, 9, 3, 7}
+		TopK(elements, 2, less)
+
+		assert.Equal(t, []int{5, 1, 9, 3, 7}, elements, "Expected the input slice to stay unchanged")
+	})
+
+	// MatchesSort ensures the result matches the head of a fully sorted copy for a larger input.
+	t.Run("MatchesSort", func(t *testing.T) {
+		elements := test.CreateSequenceWithRepeats(1000, 500)
+		sorted := Merge(elements, nil)
+		sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+
+		assert.Equal(t, sorted[:25], TopK(elements, 25, less), "Expected the top elements of the sorted slice")
+	})
+}
+
+// TestNthElement verifies that NthElement places the requested element in its sorted position.
+func TestNthElement(t *testing.T) {
+	t.Parallel()
+
+	// less orders integers in ascending order.
+	less := func(a, b int) bool { return a < b }
+
+	// OutOfRange ensures indexes outside the slice are rejected.
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, ok := NthElement([]int{1, 2, 3}, 3, less)
+		assert.False(t, ok, "Expected an index past the end to be rejected")
+
+		_, ok = NthElement([]int{1, 2, 3}, -1, less)
+		assert.False(t, ok, "Expected a negative index to be rejected")
+
+		_, ok = NthElement([]int(nil), 0, less)
+		assert.False(t, ok, "Expected an empty slice to be rejected")
+	})
+
+	// EveryIndex ensures every index yields the element of a sorted copy and partitions the slice around it.
+	t.Run("EveryIndex", func(t *testing.T) {
+		source := []int{7, 3, 9, 1, 3, 8, 2, 6, 5, 4, 0, 9}
+		sorted := Merge(source, nil)
+		sort.Ints(sorted)
+
+		for n := range source {
+			elements := Merge(source, nil)
+			value, ok := NthElement(elements, n, less)
+
+			assert.True(t, ok, "Expected index %d to be in range", n)
+			assert.Equal(t, sorted[n], value, "Unexpected element at index %d", n)
+			assert.Equal(t, sorted[n], elements[n], "Expected the element to be moved to index %d", n)
+
+			// Every element before n must not be greater, and every element after n must not be less.
+			for i := 0; i < n; i++ {
+				assert.LessOrEqual(t, elements[i], value, "Element before index %d is greater", n)
+			}
+			for i := n + 1; i < len(elements); i++ {
+				assert.GreaterOrEqual(t, elements[i], value, "Element after index %d is less", n)
+			}
+		}
+	})
+
+	// Duplicates ensures input made of few distinct values is partitioned correctly and in linear time.
+	t.Run("Duplicates", func(t *testing.T) {
+		// All equal elements made the selection quadratic before the equal band was partitioned out.
+		equal := Repeat(7, 1_000_000)
+		value, ok := NthElement(equal, len(equal)/2, less)
+		assert.True(t, ok, "Expected the index to be in range")
+		assert.Equal(t, 7, value, "Unexpected element of equal input")
+
+		elements := make([]int, 10_000)
+		for i := range elements {
+			elements[i] = i % 3
+		}
+		value, ok = NthElement(elements, 5000, less)
+		assert.True(t, ok, "Expected the index to be in range")
+		assert.Equal(t, 1, value, "Unexpected element of input with duplicates")
+		for i := 0; i < 5000; i++ {
+			assert.LessOrEqual(t, elements[i], 1, "Element before the selected index is greater")
+		}
+		for i := 5001; i < len(elements); i++ {
+			assert.GreaterOrEqual(t, elements[i], 1, "Element after the selected index is less")
+		}
+	})
+}
+
+// TestChooseWeighted verifies that ChooseWeighted validates its input and respects the weights.
+func TestChooseWeighted(t *testing.T) {
+	t.Parallel()
+
+	// InvalidInput ensures invalid combinations of items and weights are rejected.
+	t.Run("InvalidInput", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			items   []string
+			weights []float64
+		}{
+			{name: "Empty items", items: nil, weights: nil},
+			{name: "Length mismatch", items: []string{"a", "b"}, weights: []float64{1}},
+			{name: "Negative weight", items: []string{"a", "b"}, weights: []float64{1, -1}},
+			{name: "All weights zero", items: []string{"a", "b"}, weights: []float64{0, 0}},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				_, ok := ChooseWeighted(tt.items, tt.weights)
+				assert.False(t, ok, "Expected the input to be rejected for case: %s", tt.name)
+			})
+		}
+	})
+
+	// ZeroWeightNeverChosen ensures items with a zero weight are never returned.
+	t.Run("ZeroWeightNeverChosen", func(t *testing.T) {
+		for i := 0; i < 1000; i++ {
+			item, ok := ChooseWeighted([]string{"never", "always", "never"}, []float64{0, 3, 0})
+			assert.True(t, ok, "Expected an item to be chosen")
+			assert.Equal(t, "always", item, "Expected only the weighted item to be chosen")
+		}
+	})
+
+	// Distribution ensures the frequencies roughly follow the weights.
+	t.Run("Distribution", func(t *testing.T) {
+		counts := make(map[string]int)
+		for i := 0; i < 10000; i++ {
+			item, _ := ChooseWeighted([]string{"a", "b"}, []float64{1, 3})
+			counts[item]++
+		}
+
+		// With weights 1:3 the share of "b" is expected to be around 75%.
+		assert.InDelta(t, 0.75, float64(counts["b"])/10000, 0.05, "Expected the frequencies to follow the weights")
+	})
+}
+
+// TestReservoirSample verifies that ReservoirSample selects k elements from a sequence.
+func TestReservoirSample(t *testing.T) {
+	t.Parallel()
+
+	// sequence returns a push iterator over the integers in [0, n).
+	sequence := func(n int) func(yield func(int) bool) {
+		return func(yield func(int) bool) {
+			for i := 0; i < n; i++ {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+	}
+
+	// NonPositiveK ensures nothing is sampled for a non-positive k.
+	t.Run("NonPositiveK", func(t *testing.T) {
+		assert.Nil(t, ReservoirSample(sequence(10), 0), "Expected nil for zero k")
+		assert.Nil(t, ReservoirSample(sequence(10), -1), "Expected nil for negative k")
+	})
+
+	// ShortSequence ensures all elements are returned when the sequence is shorter than k.
+	t.Run("ShortSequence", func(t *testing.T) {
+		assert.Equal(t, []int{0, 1, 2}, ReservoirSample(sequence(3), 5), "Expected every element of the sequence")
+	})
+
+	// SampleIsSubset ensures the sample has k distinct elements taken from the sequence.
+	t.Run("SampleIsSubset", func(t *testing.T) {
+		sample := ReservoirSample(sequence(1000), 10)
+
+		assert.Len(t, sample, 10, "Expected exactly k elements")
+		assert.Len(t, Unique(sample), 10, "Expected distinct elements")
+		for _, v := range sample {
+			assert.True(t, v >= 0 && v < 1000, "Expected the element %d to come from the sequence", v)
+		}
+	})
+
+	// Uniformity ensures every element has roughly the same chance of being sampled.
+	t.Run("Uniformity", func(t *testing.T) {
+		counts := make([]int, 10)
+		for i := 0; i < 10000; i++ {
+			for _, v := range ReservoirSample(sequence(10), 3) {
+				counts[v]++
+			}
+		}
+
+		// Each element is expected to be sampled in about 30% of the runs.
+		for v, count := range counts {
+			assert.InDelta(t, 0.3, float64(count)/10000, 0.05, "Unexpected frequency for element %d", v)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package slices
+
+// Stream is a lazy pipeline over a sequence of elements. Filter, Map, Take and Skip only describe the
+// processing; nothing runs until a terminal operation such as Collect or ForEach pulls the elements through
+// the whole chain one at a time. A chain over a huge slice therefore allocates no intermediate slices,
+// and Take stops reading the source as soon as enough elements have passed.
+//
+// Methods cannot introduce type parameters in Go, so Map keeps the element type; use MapStream to
+// convert a Stream into a Stream of another type. A Stream can be consumed more than once if its source
+// can, which is the case for streams created with StreamOf. The zero Stream is empty.
+type Stream[T any] struct {
+	// seq pushes the elements to yield until it returns false. It is nil for the zero Stream.
+	seq func(yield func(T) bool)
+}
+
+// StreamOf returns a Stream over the elements of the slice, in order. The slice is not copied,
+// so changes made to it before the stream is consumed are visible.
+func StreamOf[T any](elements []T) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		// Push the elements until the consumer stops.
+		for _, v := range elements {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// StreamFrom returns a Stream over a push iterator with the same shape as iter.Seq, such as the
+// sequences of the iter package.
+func StreamFrom[T any](seq func(yield func(T) bool)) Stream[T] {
+	return Stream[T]{seq: seq}
+}
+
+// MapStream returns a Stream applying fn to every element of s, converting them to another type.
+func MapStream[A, B any](s Stream[A], fn func(A) B) Stream[B] {
+	return Stream[B]{seq: func(yield func(B) bool) {
+		// Transform every element on its way to the consumer.
+		s.each(func(v A) bool {
+			return yield(fn(v))
+		})
+	}}
+}
+
+// Seq returns the stream as a push iterator, for functions such as ReservoirSample.
+func (s Stream[T]) Seq() func(yield func(T) bool) {
+	return s.each
+}
+
+// Filter returns a Stream of the elements that satisfy the predicate.
+func (s Stream[T]) Filter(fn func(T) bool) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		// Pass on only the matching elements; rejected elements do not stop the source.
+		s.each(func(v T) bool {
+			if !fn(v) {
+				return true
+			}
+			return yield(v)
+		})
+	}}
+}
+
+// Map returns a Stream applying fn to every element.
+func (s Stream[T]) Map(fn func(T) T) Stream[T] {
+	return MapStream(s, fn)
+}
+
+// Take returns a Stream of at most the first n elements. The source is not read past them.
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		// Taking nothing must not even start the source.
+		if n <= 0 {
+			return
+		}
+
+		// Count the elements passed on and stop the source after the last one.
+		taken := 0
+		s.each(func(v T) bool {
+			taken++
+			return yield(v) && taken < n
+		})
+	}}
+}
+
+// Skip returns a Stream without the first n elements.
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		// Drop elements until n have been skipped, then pass on the rest.
+		skipped := 0
+		s.each(func(v T) bool {
+			if skipped < n {
+				skipped++
+				return true
+			}
+			return yield(v)
+		})
+	}}
+}
+
+// ForEach runs the stream, calling fn for every element that reaches the end of the chain.
+func (s Stream[T]) ForEach(fn func(T)) {
+	s.each(func(v T) bool {
+		fn(v)
+		return true
+	})
+}
+
+// Collect runs the stream and returns its elements as a new slice, or nil if there are none.
+func (s Stream[T]) Collect() []T {
+	var result []T
+
+	// Append every element that reaches the end of the chain.
+	s.ForEach(func(v T) {
+		result = append(result, v)
+	})
+
+	// Return the collected elements.
+	return result
+}
+
+// each pushes the elements of the stream to yield, treating the zero Stream as empty.
+func (s Stream[T]) each(yield func(T) bool) {
+	if s.seq != nil {
+		s.seq(yield)
+	}
+}
@@ -0,0 +1,82 @@
+package slices
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStream verifies that streams process elements lazily through the chained operations.
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	// Chain checks the result of combined operations.
+	t.Run("Chain", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			stream   Stream[int]
+			expected []int
+		}{
+			{name: "Zero stream", stream: Stream[int]{}, expected: nil},
+			{name: "Collect", stream: StreamOf([]int{1, 2, 3}), expected: []int{1, 2, 3}},
+			{name: "Filter", stream: StreamOf([]int{1, 2, 3, 4}).Filter(func(v int) bool { return v%2 == 0 }), expected: []int{2, 4}},
+			{name: "Map", stream: StreamOf([]int{1, 2, 3}).Map(func(v int) int { return v * 10 }), expected: []int{10, 20, 30}},
+			{name: "Take", stream: StreamOf([]int{1, 2, 3}).Take(2), expected: []int{1, 2}},
+			{name: "Take zero", stream: StreamOf([]int{1, 2, 3}).Take(0), expected: nil},
+			{name: "Skip", stream: StreamOf([]int{1, 2, 3}).Skip(2), expected: []int{3}},
+			{name: "Skip past end", stream: StreamOf([]int{1, 2, 3}).Skip(5), expected: nil},
+			{
+				name: "Pagination",
+				stream: StreamOf([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}).
+					Filter(func(v int) bool { return v%2 == 1 }).
+					Skip(1).
+					Take(3),
+				expected: []int{3, 5, 7},
+			},
+		}
+
+		for _, tt := range cases {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.Equal(t, tt.expected, tt.stream.Collect(), "Unexpected elements for case: %s", tt.name)
+			})
+		}
+	})
+
+	// Lazy ensures only the elements needed by Take are read from the source and mapped.
+	t.Run("Lazy", func(t *testing.T) {
+		mapped := 0
+		result := StreamOf(Generate(1_000, func(i int) int { return i })).
+			Map(func(v int) int {
+				mapped++
+				return v * v
+			}).
+			Take(3).
+			Collect()
+
+		assert.Equal(t, []int{0, 1, 4}, result, "Unexpected elements")
+		assert.Equal(t, 3, mapped, "Expected only the taken elements to be mapped")
+	})
+
+	// MapStream ensures streams can change their element type.
+	t.Run("MapStream", func(t *testing.T) {
+		result := MapStream(StreamOf([]int{1, 2, 3}), strconv.Itoa).Collect()
+
+		assert.Equal(t, []string{"1", "2", "3"}, result, "Unexpected elements")
+	})
+
+	// Reusable ensures a stream over a slice can be consumed twice.
+	t.Run("Reusable", func(t *testing.T) {
+		stream := StreamOf([]int{1, 2, 3}).Filter(func(v int) bool { return v > 1 })
+
+		assert.Equal(t, stream.Collect(), stream.Collect(), "Expected the same elements on every run")
+	})
+
+	// Seq ensures streams interoperate with push iterators.
+	t.Run("Seq", func(t *testing.T) {
+		stream := StreamFrom(StreamOf([]int{1, 2, 3, 4}).Seq()).Skip(1)
+		sample := ReservoirSample(stream.Seq(), 10)
+
+		assert.ElementsMatch(t, []int{2, 3, 4}, sample, "Unexpected elements")
+	})
+}
@@ -0,0 +1,196 @@
+package strings
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DiffContext is the number of unchanged lines Diff shows around every change.
+const DiffContext = 3
+
+// DiffOp is the kind of a line in a diff.
+type DiffOp int
+
+const (
+	// DiffEqual marks a line present in both inputs.
+	DiffEqual DiffOp = iota
+	// DiffDelete marks a line present only in the first input.
+	DiffDelete
+	// DiffInsert marks a line present only in the second input.
+	DiffInsert
+)
+
+// DiffLine is a line of a diff with the kind of change.
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffSlices returns the shortest edit script turning the lines a into the lines b, based on their
+// longest common subsequence. Every line of both inputs appears exactly once in the result, in order,
+// with deletions placed before insertions at the same position.
+func DiffSlices(a, b []string) []DiffLine {
+	// Strip the common prefix and suffix, which keeps the quadratic table small for typical edits.
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]DiffLine, 0, len(a)+len(b)-prefix-suffix)
+	for _, line := range a[:prefix] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	x, y := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] holds the length of the longest common subsequence of x[i:] and y[j:].
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	// Walk the table to emit the edit script in order.
+	i, j := 0, 0
+	for i < len(x) || j < len(y) {
+		switch {
+		case i < len(x) && j < len(y) && x[i] == y[j]:
+			result = append(result, DiffLine{Op: DiffEqual, Text: x[i]})
+			i++
+			j++
+		case i < len(x) && (j == len(y) || lcs[i+1][j] >= lcs[i][j+1]):
+			result = append(result, DiffLine{Op: DiffDelete, Text: x[i]})
+			i++
+		default:
+			result = append(result, DiffLine{Op: DiffInsert, Text: y[j]})
+			j++
+		}
+	}
+
+	for _, line := range a[len(a)-suffix:] {
+		result = append(result, DiffLine{Op: DiffEqual, Text: line})
+	}
+
+	return result
+}
+
+// Diff returns the differences between the texts a and b in the unified diff format, with DiffContext
+// unchanged lines around every change, or an empty string if the texts are equal. Lines deleted from a
+// are prefixed with "-" and lines inserted from b with "+".
+func Diff(a, b string) string {
+	if a == b {
+		return ""
+	}
+
+	// A final newline ends the last line rather than starting an empty one,
+	// unless only one of the texts has it.
+	trim := strings.HasSuffix(a, "\n") && strings.HasSuffix(b, "\n")
+	split := func(s string) []string {
+		if s == "" {
+			return nil
+		}
+		if trim {
+			s = s[:len(s)-1]
+		}
+		return strings.Split(s, "\n")
+	}
+
+	return unifiedDiff(DiffSlices(split(a), split(b)), DiffContext)
+}
+
+// unifiedDiff formats the edit script as hunks with context unchanged lines around the changes.
+func unifiedDiff(lines []DiffLine, context int) string {
+	var out strings.Builder
+	out.WriteString("--- a\n+++ b\n")
+
+	// aLine and bLine count the lines of both inputs before position i of the script.
+	aLine, bLine := 0, 0
+	for i := 0; i < len(lines); {
+		// Find the next change; the unchanged lines before it only advance the counters.
+		if lines[i].Op == DiffEqual {
+			aLine++
+			bLine++
+			i++
+			continue
+		}
+
+		// Start the hunk with up to context lines before the change.
+		start := max(i-context, 0)
+		for k := start; k < i; k++ {
+			aLine--
+			bLine--
+		}
+
+		// Extend the hunk while the next change is close enough for the contexts to touch.
+		end := i
+		for end < len(lines) {
+			if lines[end].Op != DiffEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(lines) && lines[next].Op == DiffEqual {
+				next++
+			}
+			if next == len(lines) || next-end > 2*context {
+				end = min(end+context, len(lines))
+				break
+			}
+			end = next
+		}
+
+		// Count the lines of both inputs covered by the hunk.
+		aCount, bCount := 0, 0
+		for _, line := range lines[start:end] {
+			if line.Op != DiffInsert {
+				aCount++
+			}
+			if line.Op != DiffDelete {
+				bCount++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aLine, aCount), hunkRange(bLine, bCount))
+		for _, line := range lines[start:end] {
+			switch line.Op {
+			case DiffEqual:
+				out.WriteString(" " + line.Text + "\n")
+			case DiffDelete:
+				out.WriteString("-" + line.Text + "\n")
+			case DiffInsert:
+				out.WriteString("+" + line.Text + "\n")
+			}
+		}
+
+		aLine += aCount
+		bLine += bCount
+		i = end
+	}
+
+	return out.String()
+}
+
+// hunkRange formats the range of a hunk starting after before lines and spanning count lines.
+// Empty ranges refer to the line before them, as in the output of GNU diff.
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
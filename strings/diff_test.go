@@ -0,0 +1,97 @@
+package strings
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiffSlices verifies the edit script for insertions, deletions and replacements.
+func TestDiffSlices(t *testing.T) {
+	t.Parallel()
+
+	lines := DiffSlices([]string{"a", "b", "c", "d"}, []string{"a", "c", "x", "d", "e"})
+
+	assert.Equal(t, []DiffLine{
+		{Op: DiffEqual, Text: "a"},
+		{Op: DiffDelete, Text: "b"},
+		{Op: DiffEqual, Text: "c"},
+		{Op: DiffInsert, Text: "x"},
+		{Op: DiffEqual, Text: "d"},
+		{Op: DiffInsert, Text: "e"},
+	}, lines, "Unexpected edit script")
+
+	assert.Empty(t, DiffSlices(nil, nil), "Expected no lines for empty inputs")
+	assert.Equal(t, []DiffLine{{Op: DiffInsert, Text: "a"}}, DiffSlices(nil, []string{"a"}), "Expected a single insertion")
+}
+
+// TestDiff verifies the unified diff output.
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for equal texts, single changes and separate hunks.
+	cases := []struct {
+		name     string
+		a, b     string
+		expected string
+	}{
+		{name: "Equal", a: "same\n", b: "same\n", expected: ""},
+		{
+			name:     "Single change",
+			a:        "one\ntwo\nthree\n",
+			b:        "one\n2\nthree\n",
+			expected: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name:     "Insertion into empty text",
+			a:        "",
+			b:        "new",
+			expected: "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n",
+		},
+		{
+			name: "Separate hunks",
+			a:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			b:    "1\nTWO\n3\n4\n5\n6\n7\n8\n9\n10\n11\n",
+			expected: "--- a\n+++ b\n@@ -1,5 +1,5 @@\n 1\n-2\n+TWO\n 3\n 4\n 5\n" +
+				"@@ -9,4 +9,3 @@\n 9\n 10\n 11\n-12\n",
+		},
+		{
+			name:     "Adjacent changes share a hunk",
+			a:        "a\nb\nc\nd\ne\n",
+			b:        "A\nb\nc\nd\nE\n",
+			expected: "--- a\n+++ b\n@@ -1,5 +1,5 @@\n-a\n+A\n b\n c\n d\n-e\n+E\n",
+		},
+		{
+			name:     "Pure deletion at start",
+			a:        "x\na\n",
+			b:        "a\n",
+			expected: "--- a\n+++ b\n@@ -1,2 +1 @@\n-x\n a\n",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Diff(tt.a, tt.b), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestDiffLargeInput verifies that long inputs with small edits are diffed correctly.
+func TestDiffLargeInput(t *testing.T) {
+	t.Parallel()
+
+	a := make([]string, 5000)
+	for i := range a {
+		a[i] = strings.Repeat("x", i%7)
+	}
+	b := append(append([]string{}, a[:2500]...), append([]string{"inserted"}, a[2500:]...)...)
+
+	inserted := 0
+	for _, line := range DiffSlices(a, b) {
+		if line.Op != DiffEqual {
+			inserted++
+		}
+	}
+	assert.Equal(t, 1, inserted, "Expected a single insertion")
+}
@@ -0,0 +1,23 @@
+package strings_test
+
+import (
+	"strings"
+	"testing"
+
+	commonstrings "github.com/SyntaxErrorLineNULL/common/strings"
+	"github.com/SyntaxErrorLineNULL/common/test"
+)
+
+// TestStringSplitAroundGolden locks in the wrapping of a longer paragraph using a golden file.
+// Every line of the golden file is one segment produced by SplitStringWithWidthConstraints,
+// so any change in the wrapping behavior shows up as a readable line diff.
+// Run the tests with -update to regenerate the golden file after an intended change.
+func TestStringSplitAroundGolden(t *testing.T) {
+	input := "The quick brown fox jumps over the lazy dog while the unusually persistent " +
+		"narrator keeps describing every single detail of the scene in excessive length, " +
+		"including a supercalifragilisticexpialidocious word that cannot be split."
+
+	segments := commonstrings.SplitStringWithWidthConstraints(input, 30, 10)
+
+	test.Golden(t, "split_around.txt", []byte(strings.Join(segments, "\n")+"\n"))
+}
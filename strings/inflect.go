@@ -0,0 +1,121 @@
+package strings
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// irregularPlurals maps English nouns whose plural does not follow the suffix rules to their plural.
+var irregularPlurals = map[string]string{
+	"child":  "children",
+	"foot":   "feet",
+	"goose":  "geese",
+	"half":   "halves",
+	"knife":  "knives",
+	"leaf":   "leaves",
+	"life":   "lives",
+	"man":    "men",
+	"mouse":  "mice",
+	"person": "people",
+	"tooth":  "teeth",
+	"wife":   "wives",
+	"woman":  "women",
+}
+
+// uncountableNouns lists English nouns that have the same form in singular and plural.
+var uncountableNouns = map[string]bool{
+	"data":        true,
+	"deer":        true,
+	"equipment":   true,
+	"fish":        true,
+	"information": true,
+	"metadata":    true,
+	"series":      true,
+	"sheep":       true,
+	"species":     true,
+}
+
+// Pluralize returns the English plural of word unless count is exactly one, for messages such as
+// "3 files" or "1 file". Common irregular and uncountable nouns are recognized, other words follow the
+// suffix rules ("box" becomes "boxes", "city" becomes "cities"). The case of word is preserved for words
+// in lower, upper and title case.
+func Pluralize(word string, count int) string {
+	if count == 1 || word == "" {
+		return word
+	}
+
+	lower := strings.ToLower(word)
+
+	var plural string
+	switch {
+	case uncountableNouns[lower]:
+		return word
+	case irregularPlurals[lower] != "":
+		plural = irregularPlurals[lower]
+	case hasAnySuffix(lower, "s", "x", "z", "ch", "sh"):
+		plural = lower + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		plural = lower[:len(lower)-1] + "ies"
+	default:
+		plural = lower + "s"
+	}
+
+	return matchCase(plural, word)
+}
+
+// Ordinal returns n with its English ordinal suffix, such as "1st", "2nd", "3rd", "11th" or "22nd".
+func Ordinal(n int) string {
+	suffix := "th"
+
+	// The teens always take "th": 11th, 12th and 13th.
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	if abs%100 < 11 || abs%100 > 13 {
+		switch abs % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+
+	return strconv.Itoa(n) + suffix
+}
+
+// Quantify returns count followed by noun in the matching grammatical number, such as "1 file" or "3 files".
+func Quantify(count int, noun string) string {
+	return strconv.Itoa(count) + " " + Pluralize(noun, count)
+}
+
+// hasAnySuffix reports whether s ends with any of the suffixes.
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suffix := range suffixes {
+		if strings.HasSuffix(s, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchCase converts the lower-case word to the case of the original word: upper case if the original
+// is all upper case, title case if it starts with an upper-case letter, and lower case otherwise.
+func matchCase(word, original string) string {
+	first, _ := utf8.DecodeRuneInString(original)
+
+	switch {
+	case len(original) > 1 && strings.ToUpper(original) == original:
+		return strings.ToUpper(word)
+	case unicode.IsUpper(first):
+		r, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToUpper(r)) + word[size:]
+	}
+
+	return word
+}
@@ -0,0 +1,56 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPluralize verifies the suffix rules, irregular nouns and case preservation.
+func TestPluralize(t *testing.T) {
+	// Define test cases for regular, irregular and uncountable nouns.
+	cases := []struct {
+		name     string
+		word     string
+		count    int
+		expected string
+	}{
+		{name: "Singular", word: "file", count: 1, expected: "file"},
+		{name: "Zero is plural", word: "file", count: 0, expected: "files"},
+		{name: "Regular", word: "file", count: 2, expected: "files"},
+		{name: "Sibilant", word: "box", count: 2, expected: "boxes"},
+		{name: "Ch ending", word: "branch", count: 3, expected: "branches"},
+		{name: "Consonant y", word: "retry", count: 2, expected: "retries"},
+		{name: "Vowel y", word: "key", count: 2, expected: "keys"},
+		{name: "Irregular", word: "person", count: 2, expected: "people"},
+		{name: "Uncountable", word: "sheep", count: 5, expected: "sheep"},
+		{name: "Title case", word: "Child", count: 2, expected: "Children"},
+		{name: "Upper case", word: "HOST", count: 2, expected: "HOSTS"},
+		{name: "Empty", word: "", count: 2, expected: ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Pluralize(tt.word, tt.count), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestOrdinal verifies the ordinal suffixes, including the teens.
+func TestOrdinal(t *testing.T) {
+	cases := map[int]string{
+		0: "0th", 1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th",
+		21: "21st", 22: "22nd", 101: "101st", 111: "111th", 112: "112th", -1: "-1st",
+	}
+
+	for n, expected := range cases {
+		assert.Equal(t, expected, Ordinal(n), "Unexpected ordinal of %d", n)
+	}
+}
+
+// TestQuantify verifies that the count and the noun agree.
+func TestQuantify(t *testing.T) {
+	assert.Equal(t, "1 file", Quantify(1, "file"), "Expected the singular")
+	assert.Equal(t, "0 files", Quantify(0, "file"), "Expected the plural for zero")
+	assert.Equal(t, "3 matches", Quantify(3, "match"), "Expected the plural")
+}
@@ -0,0 +1,55 @@
+package strings
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// UpperCaseFirstLocale is the locale-aware variant of UpperCaseFirst. It applies the case rules of the
+// language, so for example the Turkish "istanbul" becomes "İstanbul" and the German "ßtraße" keeps its
+// sharp s in the remainder. UpperCaseFirst remains the faster choice for ASCII text.
+func UpperCaseFirstLocale(str string, tag language.Tag) string {
+	// Leave empty and whitespace-only strings unchanged, like UpperCaseFirst does.
+	if StringIsEmpty(str) {
+		return str
+	}
+
+	// Remove leading spaces to focus on the first character.
+	trimmed := strings.TrimLeft(str, " ")
+
+	// Title-case the first character, which differs from upper-casing for characters like "ß" ("Ss")
+	// and ligatures, and lower-case the remainder.
+	_, size := utf8.DecodeRuneInString(trimmed)
+
+	return cases.Title(tag).String(trimmed[:size]) + cases.Lower(tag).String(trimmed[size:])
+}
+
+// ToUpperLocale returns str with all letters mapped to upper case by the rules of the language.
+func ToUpperLocale(str string, tag language.Tag) string {
+	return cases.Upper(tag).String(str)
+}
+
+// ToLowerLocale returns str with all letters mapped to lower case by the rules of the language,
+// for example mapping the Turkish "I" to the dotless "ı".
+func ToLowerLocale(str string, tag language.Tag) string {
+	return cases.Lower(tag).String(str)
+}
+
+// CompareFold compares two strings ignoring case, returning 0 if they are equal, -1 if a sorts
+// before b and +1 otherwise. Unlike comparing the results of strings.ToLower, it uses full Unicode case
+// folding, under which for example "Straße" and "STRASSE" are equal.
+func CompareFold(a, b string) int {
+	fold := cases.Fold()
+
+	return strings.Compare(fold.String(a), fold.String(b))
+}
+
+// EqualFold reports whether two strings are equal under full Unicode case folding.
+// It differs from strings.EqualFold, which folds one character at a time and therefore considers
+// "Straße" and "STRASSE" different.
+func EqualFold(a, b string) bool {
+	return CompareFold(a, b) == 0
+}
@@ -0,0 +1,50 @@
+package strings
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+// TestUpperCaseFirstLocale verifies that the case rules of the language are applied.
+func TestUpperCaseFirstLocale(t *testing.T) {
+	// Define test cases for languages with special case mappings.
+	cases := []struct {
+		name     string
+		input    string
+		tag      language.Tag
+		expected string
+	}{
+		{name: "English", input: " hello WORLD", tag: language.English, expected: "Hello world"},
+		{name: "Turkish dotted i", input: "istanbul", tag: language.Turkish, expected: "İstanbul"},
+		{name: "Turkish dotless I", input: "IRMAK", tag: language.Turkish, expected: "Irmak"},
+		{name: "German sharp s", input: "STRASSE und ßtraße", tag: language.German, expected: "Strasse und ßtraße"},
+		{name: "German leading sharp s", input: "ß", tag: language.German, expected: "Ss"},
+		{name: "Whitespace only", input: "   ", tag: language.English, expected: "   "},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, UpperCaseFirstLocale(tt.input, tt.tag), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestCaseMappingLocale verifies upper and lower case mapping by language.
+func TestCaseMappingLocale(t *testing.T) {
+	assert.Equal(t, "İSTANBUL", ToUpperLocale("istanbul", language.Turkish), "Expected the dotted capital I")
+	assert.Equal(t, "ISTANBUL", ToUpperLocale("istanbul", language.English), "Expected the plain capital I")
+	assert.Equal(t, "ırmak", ToLowerLocale("IRMAK", language.Turkish), "Expected the dotless small i")
+	assert.Equal(t, "STRASSE", ToUpperLocale("straße", language.German), "Expected the sharp s to expand")
+}
+
+// TestCompareFold verifies comparison under full case folding.
+func TestCompareFold(t *testing.T) {
+	assert.True(t, EqualFold("Straße", "STRASSE"), "Expected the sharp s to fold to ss")
+	assert.True(t, EqualFold("Go", "gO"), "Expected ASCII letters to fold")
+	assert.False(t, EqualFold("Go", "Rust"), "Expected different words to differ")
+	assert.Equal(t, -1, CompareFold("apple", "BANANA"), "Expected apple before banana")
+	assert.Equal(t, 1, CompareFold("Cherry", "banana"), "Expected cherry after banana")
+	assert.Equal(t, 0, CompareFold("ΣΊΣΥΦΟΣ", "σίσυφος"), "Expected Greek sigmas to fold")
+}
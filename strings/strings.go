@@ -3,6 +3,8 @@ package strings
 import (
 	"strings"
 	"unicode/utf8"
+
+	"github.com/SyntaxErrorLineNULL/common/term"
 )
 
 // SplitStringBySeparator takes an input string and a separator, then splits the input string into two parts:
@@ -63,8 +65,9 @@ func SplitStringWithWidthConstraints(str string, maxWidth, overflowWidth int) []
 
 	// Check if the number of runes (Unicode code points) in the str string is less than the sum
 	// of maxWidth and overflowWidth. This condition ensures that the string is short enough to fit
-	// within the allowed width without needing to be split.
-	if utf8.RuneCountInString(str) < maxWidth+overflowWidth {
+	// within the allowed width without needing to be split. ANSI escape sequences such as color
+	// codes take no space on the terminal, so they are stripped before measuring.
+	if utf8.RuneCountInString(term.Strip(str)) < maxWidth+overflowWidth {
 		// If the condition is true, return the str string as a single-element slice.
 		// This avoids unnecessary processing when the string already fits within the allowed width.
 		return []string{str}
@@ -94,7 +97,8 @@ func SplitStringWithWidthConstraints(str string, maxWidth, overflowWidth int) []
 		// Calculate the number of runes (characters) in the current word
 		// using utf8.RuneCountInString. This ensures we account for
 		// multi-byte characters correctly when determining the word length.
-		wordLength := utf8.RuneCountInString(word)
+		// Escape sequences are stripped first, as they are not visible.
+		wordLength := utf8.RuneCountInString(term.Strip(word))
 
 		// Check if adding the current word would exceed the maximum allowed width,
 		// considering the overflow width. If it does exceed and the current chunk
@@ -175,6 +175,13 @@ func TestStringSplitAround(t *testing.T) {
 				"eiusmod", "tempor", "incididunt", "ut", "labore",
 				"et", "dolore", "magna", "aliqua."},
 		},
+		{
+			name:          "colored words keep their visible width",
+			input:         "\x1b[31mred\x1b[0m \x1b[32mgreen\x1b[0m blue",
+			maxWidth:      8,
+			overflowWidth: 0,
+			want:          []string{"\x1b[31mred\x1b[0m \x1b[32mgreen\x1b[0m", "blue"},
+		},
 	}
 
 	// Iterate through each test case defined in the cases slice.
@@ -0,0 +1,125 @@
+package templatex
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+
+	commonstrings "github.com/SyntaxErrorLineNULL/common/strings"
+)
+
+// Render parses tmpl as a text/template with the functions of Funcs and executes it with data.
+// It is meant for short templates such as notification messages and generated configuration,
+// where parsing on every call is cheaper than managing parsed templates.
+func Render(tmpl string, data any) (string, error) {
+	t, err := New("render").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("templatex: %w", err)
+	}
+
+	var out strings.Builder
+	if err = t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("templatex: %w", err)
+	}
+
+	return out.String(), nil
+}
+
+// New creates a template with the given name, the functions of Funcs and an error on missing map keys,
+// so typos in templates fail loudly instead of rendering "<no value>".
+func New(name string) *template.Template {
+	return template.New(name).Funcs(Funcs()).Option("missingkey=error")
+}
+
+// Funcs returns the function map available in templates created by New and Render:
+//
+//	upper, lower, trim        case and whitespace handling from the standard strings package
+//	upperFirst                capitalizes the first letter, see strings.UpperCaseFirst in this module
+//	wrap WIDTH TEXT           wraps text into lines of about WIDTH characters without breaking words
+//	contains, hasPrefix, hasSuffix, replace, split, join
+//	default DEFAULT VALUE     returns VALUE, or DEFAULT when VALUE is empty
+//	toJSON, toPrettyJSON      encode a value as JSON
+//	now                       returns the current time
+//	date LAYOUT TIME          formats a time.Time, or a Unix timestamp, with a Go layout
+//
+// A new map is returned on every call, so callers may extend it without affecting other templates.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"upperFirst": commonstrings.UpperCaseFirst,
+		"wrap":       wrap,
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"replace":    func(old, replacement, s string) string { return strings.ReplaceAll(s, old, replacement) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"default":    defaultValue,
+		"toJSON":     toJSON,
+		"toPrettyJSON": func(v any) (string, error) {
+			data, err := json.MarshalIndent(v, "", "  ")
+			return string(data), err
+		},
+		"now":  time.Now,
+		"date": date,
+	}
+}
+
+// wrap splits text into lines of at most width characters, allowing a word to overflow by a quarter of
+// the width before it is moved to the next line.
+func wrap(width int, text string) string {
+	return strings.Join(commonstrings.SplitStringWithWidthConstraints(text, width, width/4), "\n")
+}
+
+// defaultValue returns value, or def when value is nil or the zero value of its type.
+// The argument order allows piping: {{ .Name | default "anonymous" }}.
+func defaultValue(def, value any) any {
+	if value == nil {
+		return def
+	}
+
+	// Treat empty collections like empty strings, as templates usually do.
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Array:
+		if v.Len() == 0 {
+			return def
+		}
+	default:
+		if v.IsZero() {
+			return def
+		}
+	}
+
+	return value
+}
+
+// toJSON encodes v as compact JSON.
+func toJSON(v any) (string, error) {
+	data, err := json.Marshal(v)
+	return string(data), err
+}
+
+// date formats t with the Go layout. Besides time.Time it accepts Unix timestamps in seconds.
+func date(layout string, t any) (string, error) {
+	switch value := t.(type) {
+	case time.Time:
+		return value.Format(layout), nil
+	case *time.Time:
+		if value == nil {
+			return "", nil
+		}
+		return value.Format(layout), nil
+	case int64:
+		return time.Unix(value, 0).Format(layout), nil
+	case int:
+		return time.Unix(int64(value), 0).Format(layout), nil
+	default:
+		return "", fmt.Errorf("date: unsupported type %T", t)
+	}
+}
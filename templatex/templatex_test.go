@@ -0,0 +1,53 @@
+package templatex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRender verifies that templates are rendered with the curated function set.
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering every group of functions.
+	cases := []struct {
+		name     string
+		tmpl     string
+		data     any
+		expected string
+	}{
+		{name: "Case functions", tmpl: `{{ upper .a }} {{ lower .b }} {{ upperFirst .c }}`, data: map[string]string{"a": "up", "b": "DOWN", "c": "hELLO"}, expected: "UP down Hello"},
+		{name: "Default for empty value", tmpl: `{{ .name | default "anonymous" }}`, data: map[string]any{"name": ""}, expected: "anonymous"},
+		{name: "Default keeps value", tmpl: `{{ .name | default "anonymous" }}`, data: map[string]any{"name": "alice"}, expected: "alice"},
+		{name: "Default for empty slice", tmpl: `{{ .tags | default "none" }}`, data: map[string]any{"tags": []string{}}, expected: "none"},
+		{name: "JSON", tmpl: `{{ toJSON . }}`, data: map[string]any{"id": 1, "ok": true}, expected: `{"id":1,"ok":true}`},
+		{name: "Date from time", tmpl: `{{ date "2006-01-02" .at }}`, data: map[string]any{"at": time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}, expected: "2024-05-01"},
+		{name: "Date from timestamp", tmpl: `{{ date "2006" .at }}`, data: map[string]any{"at": int64(1714564800)}, expected: "2024"},
+		{name: "String helpers", tmpl: `{{ join "," (split " " "a b c") }} {{ replace "a" "o" "banana" }} {{ contains "an" "banana" }}`, data: nil, expected: "a,b,c bonono true"},
+		{name: "Wrap", tmpl: `{{ wrap 10 .text }}`, data: map[string]string{"text": "the quick brown fox jumps"}, expected: "the quick\nbrown fox\njumps"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := Render(tt.tmpl, tt.data)
+			assert.NoError(t, err, "Expected no error")
+			assert.Equal(t, tt.expected, actual, "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestRenderErrors verifies that parse errors, execution errors and missing keys are reported.
+func TestRenderErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Render(`{{ .name `, nil)
+	assert.Error(t, err, "Expected a parse error")
+
+	_, err = Render(`{{ .missing }}`, map[string]string{})
+	assert.Error(t, err, "Expected an error for a missing key")
+
+	_, err = Render(`{{ date "2006" "yesterday" }}`, nil)
+	assert.ErrorContains(t, err, "unsupported type string", "Expected an error for an unsupported date")
+}
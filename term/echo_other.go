@@ -0,0 +1,17 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// disableEcho is not supported on this platform, so Password refuses to read secrets from a terminal here.
+func disableEcho(file *os.File) (func(), error) {
+	if !isTerminalFile(file) {
+		return func() {}, nil
+	}
+
+	return nil, errors.ErrUnsupported
+}
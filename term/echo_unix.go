@@ -0,0 +1,31 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package term
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// disableEcho turns off the echo of typed characters on the terminal file and returns a function
+// restoring the previous settings. Files that are not terminals are left as they are.
+func disableEcho(file *os.File) (func(), error) {
+	if !isTerminalFile(file) {
+		return func() {}, nil
+	}
+
+	fd := int(file.Fd())
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *termios
+	termios.Lflag &^= unix.ECHO
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, termios); err != nil {
+		return nil, err
+	}
+
+	return func() { _ = unix.IoctlSetTermios(fd, ioctlSetTermios, &previous) }, nil
+}
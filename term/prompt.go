@@ -0,0 +1,278 @@
+package term
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// AssumeYesEnv is the environment variable that answers every Confirm with yes when set to a true value,
+// for running destructive commands unattended, for example in CI.
+const AssumeYesEnv = "ASSUME_YES"
+
+// ErrNotInteractive is returned by the prompts when the input is not a terminal and no answer
+// is provided through the environment.
+var ErrNotInteractive = errors.New("term: input is not interactive")
+
+// PromptOptions configures Confirm, Select and Password.
+type PromptOptions struct {
+	// Env names an environment variable holding the answer. When it is set, the prompt is not shown,
+	// which keeps scripted and CI runs from blocking on input.
+	Env string
+	// In is read for the answer. When nil, os.Stdin is used. Files that are not terminals, such as
+	// redirected standard input, are not read and the prompt fails with ErrNotInteractive instead.
+	// Other readers are read as given, which lets tests script the answers.
+	In io.Reader
+	// Out receives the prompt. When nil, os.Stderr is used, so the prompt does not mix with the output.
+	Out io.Writer
+}
+
+// Confirm asks a yes or no question and reports whether it was answered with yes.
+// An empty answer means no. The answer can be given through opts.Env or AssumeYesEnv instead.
+func Confirm(ctx context.Context, question string, opts PromptOptions) (bool, error) {
+	if answer, ok := lookupEnv(opts.Env); ok {
+		return parseYesNo(answer)
+	}
+	if assumeYes, ok := lookupEnv(AssumeYesEnv); ok {
+		if yes, err := parseYesNo(assumeYes); err == nil && yes {
+			return true, nil
+		}
+	}
+
+	in, out, err := opts.streams()
+	if err != nil {
+		return false, err
+	}
+
+	for {
+		if _, err := fmt.Fprintf(out, "%s [y/N]: ", question); err != nil {
+			return false, err
+		}
+
+		answer, err := readLine(ctx, in)
+		if err != nil {
+			return false, err
+		}
+		if answer == "" {
+			return false, nil
+		}
+
+		if yes, err := parseYesNo(answer); err == nil {
+			return yes, nil
+		}
+		if _, err := fmt.Fprintln(out, "Please answer y or n."); err != nil {
+			return false, err
+		}
+	}
+}
+
+// Select asks to choose one of options and returns the index of the chosen option.
+// The options are listed with numbers, and either the number or the text of an option is accepted.
+// Through opts.Env, the answer is given the same way.
+func Select(ctx context.Context, question string, options []string, opts PromptOptions) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("term: no options to select from")
+	}
+
+	if answer, ok := lookupEnv(opts.Env); ok {
+		if index, ok := matchOption(answer, options); ok {
+			return index, nil
+		}
+		return -1, fmt.Errorf("term: invalid option %q in %s", answer, opts.Env)
+	}
+
+	in, out, err := opts.streams()
+	if err != nil {
+		return -1, err
+	}
+
+	var list strings.Builder
+	list.WriteString(question + "\n")
+	for i, option := range options {
+		fmt.Fprintf(&list, "  %d) %s\n", i+1, option)
+	}
+	if _, err := io.WriteString(out, list.String()); err != nil {
+		return -1, err
+	}
+
+	for {
+		if _, err := fmt.Fprintf(out, "Choose [1-%d]: ", len(options)); err != nil {
+			return -1, err
+		}
+
+		answer, err := readLine(ctx, in)
+		if err != nil {
+			return -1, err
+		}
+
+		if index, ok := matchOption(answer, options); ok {
+			return index, nil
+		}
+		if _, err := fmt.Fprintln(out, "Please choose one of the listed options."); err != nil {
+			return -1, err
+		}
+	}
+}
+
+// Password asks for a secret without echoing the typed characters. Input from a terminal is only read
+// on platforms where the echo can be turned off; elsewhere Password fails rather than show the secret.
+// The secret can be given through opts.Env instead. The echo is restored before Password returns,
+// also when ctx is done first.
+func Password(ctx context.Context, prompt string, opts PromptOptions) (string, error) {
+	if answer, ok := lookupEnv(opts.Env); ok {
+		return answer, nil
+	}
+
+	in, out, err := opts.streams()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.WriteString(out, prompt+": "); err != nil {
+		return "", err
+	}
+
+	if file, ok := in.(*os.File); ok {
+		restore, err := disableEcho(file)
+		if err != nil {
+			return "", fmt.Errorf("term: disable echo: %w", err)
+		}
+		defer restore()
+	}
+
+	secret, err := readLine(ctx, in)
+
+	// The newline typed by the user was not echoed either.
+	_, _ = io.WriteString(out, "\n")
+
+	return secret, err
+}
+
+// streams returns the input and output of the prompt, failing if the input is not interactive.
+func (o PromptOptions) streams() (io.Reader, io.Writer, error) {
+	in, out := o.In, o.Out
+	if in == nil {
+		in = os.Stdin
+	}
+	if out == nil {
+		out = os.Stderr
+	}
+
+	if file, ok := in.(*os.File); ok && !isTerminalFile(file) {
+		return nil, nil, ErrNotInteractive
+	}
+
+	return in, out, nil
+}
+
+// isTerminalFile reports whether file is a terminal.
+func isTerminalFile(file *os.File) bool {
+	info, err := file.Stat()
+
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// lookupEnv returns the value of the environment variable env, if the name is given and the variable is set.
+func lookupEnv(env string) (string, bool) {
+	if env == "" {
+		return "", false
+	}
+
+	return os.LookupEnv(env)
+}
+
+// parseYesNo interprets answers such as "y", "yes", "no" and the values accepted by strconv.ParseBool.
+func parseYesNo(answer string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+
+	yes, err := strconv.ParseBool(strings.TrimSpace(answer))
+	if err != nil {
+		return false, fmt.Errorf("term: invalid answer %q", answer)
+	}
+
+	return yes, nil
+}
+
+// matchOption finds the option selected by answer, given as a number starting at 1 or as the option text.
+func matchOption(answer string, options []string) (int, bool) {
+	answer = strings.TrimSpace(answer)
+
+	if number, err := strconv.Atoi(answer); err == nil {
+		return number - 1, number >= 1 && number <= len(options)
+	}
+
+	for i, option := range options {
+		if strings.EqualFold(option, answer) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// readLine reads a line from r without the line ending, returning early with the context error when ctx
+// is done. Terminals are polled where the platform allows it, so the read stops with ctx. Other reads
+// cannot be interrupted and finish in the background when the user answers later.
+func readLine(ctx context.Context, r io.Reader) (string, error) {
+	if file, ok := r.(*os.File); ok && isTerminalFile(file) {
+		return readTerminalLine(ctx, file)
+	}
+
+	return readLineBackground(ctx, r)
+}
+
+// readLineBackground reads a line from r in a goroutine, returning early with the context error when
+// ctx is done.
+func readLineBackground(ctx context.Context, r io.Reader) (string, error) {
+	type result struct {
+		line string
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		line, err := readLineFrom(r)
+		done <- result{line: line, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.line, res.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
+
+// readLineFrom reads a line from r without the line ending. Bytes are read one at a time, so nothing
+// after the line is consumed from r.
+func readLineFrom(r io.Reader) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if buf[0] == '\n' {
+				break
+			}
+			line = append(line, buf[0])
+		}
+		if err != nil {
+			// A final line without a line ending is still an answer.
+			if errors.Is(err, io.EOF) && len(line) > 0 {
+				break
+			}
+			return "", err
+		}
+	}
+
+	return strings.TrimSuffix(string(line), "\r"), nil
+}
@@ -0,0 +1,94 @@
+package term
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+// openPTY opens a pseudo-terminal and returns its controlling and terminal sides, skipping the test
+// where pseudo-terminals are unavailable.
+func openPTY(t *testing.T) (*os.File, *os.File) {
+	t.Helper()
+
+	ptmx, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("No pseudo-terminals: %v", err)
+	}
+	t.Cleanup(func() { _ = ptmx.Close() })
+
+	if err := unix.IoctlSetPointerInt(int(ptmx.Fd()), unix.TIOCSPTLCK, 0); err != nil {
+		t.Skipf("Cannot unlock the pseudo-terminal: %v", err)
+	}
+	number, err := unix.IoctlGetInt(int(ptmx.Fd()), unix.TIOCGPTN)
+	if err != nil {
+		t.Skipf("Cannot query the pseudo-terminal: %v", err)
+	}
+	tty, err := os.OpenFile(fmt.Sprintf("/dev/pts/%d", number), os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("Cannot open the pseudo-terminal: %v", err)
+	}
+	t.Cleanup(func() { _ = tty.Close() })
+
+	return ptmx, tty
+}
+
+// echoEnabled reports whether the terminal echoes typed characters.
+func echoEnabled(t *testing.T, tty *os.File) bool {
+	t.Helper()
+
+	termios, err := unix.IoctlGetTermios(int(tty.Fd()), unix.TCGETS)
+	assert.NoError(t, err, "Expected the terminal settings")
+
+	return termios.Lflag&unix.ECHO != 0
+}
+
+// TestPasswordTerminal verifies reading a secret from a terminal and the terminal state after Password.
+func TestPasswordTerminal(t *testing.T) {
+	// Read ensures the secret is read without echo and the echo is restored afterwards.
+	t.Run("Read", func(t *testing.T) {
+		ptmx, tty := openPTY(t)
+
+		typed := make(chan struct{})
+		go func() {
+			defer close(typed)
+			// Type the secret once the echo is off.
+			for echoEnabled(t, tty) {
+				time.Sleep(time.Millisecond)
+			}
+			_, _ = io.WriteString(ptmx, "s3cret\n")
+		}()
+
+		secret, err := Password(context.Background(), "Token", PromptOptions{In: tty, Out: io.Discard})
+		assert.NoError(t, err, "Expected no error")
+		assert.Equal(t, "s3cret", secret, "Unexpected secret")
+		assert.True(t, echoEnabled(t, tty), "Expected the echo to be restored")
+		<-typed
+	})
+
+	// Cancel ensures a canceled Password restores the echo and leaves the next line to the next prompt.
+	t.Run("Cancel", func(t *testing.T) {
+		t.Setenv(AssumeYesEnv, "")
+		ptmx, tty := openPTY(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := Password(ctx, "Token", PromptOptions{In: tty, Out: io.Discard})
+		assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected the context error")
+		assert.True(t, echoEnabled(t, tty), "Expected the echo to be restored before Password returned")
+
+		_, err = io.WriteString(ptmx, "y\n")
+		assert.NoError(t, err, "Expected the answer to be typed")
+
+		yes, err := Confirm(context.Background(), "Delete?", PromptOptions{In: tty, Out: io.Discard})
+		assert.NoError(t, err, "Expected no error")
+		assert.True(t, yes, "Expected the next prompt to read the answer")
+	})
+}
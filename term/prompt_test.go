@@ -0,0 +1,133 @@
+package term
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConfirm verifies the answers accepted by Confirm and the repeated question after an invalid answer.
+func TestConfirm(t *testing.T) {
+	t.Setenv(AssumeYesEnv, "")
+
+	// Define test cases for the accepted answers.
+	cases := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{name: "Yes", input: "y\n", expected: true},
+		{name: "Long yes", input: "YES\n", expected: true},
+		{name: "No", input: "n\n", expected: false},
+		{name: "Empty answer", input: "\n", expected: false},
+		{name: "Invalid answer first", input: "maybe\ny\n", expected: true},
+		{name: "No line ending", input: "yes", expected: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			yes, err := Confirm(context.Background(), "Delete?", PromptOptions{In: strings.NewReader(tt.input), Out: &out})
+
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, yes, "Test case %s failed", tt.name)
+			assert.True(t, strings.HasPrefix(out.String(), "Delete? [y/N]: "), "Expected the question to be shown")
+		})
+	}
+}
+
+// TestConfirmEnv verifies that answers given through the environment skip the prompt.
+func TestConfirmEnv(t *testing.T) {
+	t.Setenv("TEST_CONFIRM", "no")
+	t.Setenv(AssumeYesEnv, "1")
+
+	var out bytes.Buffer
+	yes, err := Confirm(context.Background(), "Delete?", PromptOptions{Env: "TEST_CONFIRM", In: strings.NewReader(""), Out: &out})
+	assert.NoError(t, err, "Expected no error for an answer in the environment")
+	assert.False(t, yes, "Expected the prompt variable to take precedence")
+	assert.Empty(t, out.String(), "Expected no prompt to be shown")
+
+	yes, err = Confirm(context.Background(), "Delete?", PromptOptions{In: strings.NewReader(""), Out: &out})
+	assert.NoError(t, err, "Expected no error with ASSUME_YES")
+	assert.True(t, yes, "Expected ASSUME_YES to confirm")
+	assert.Empty(t, out.String(), "Expected no prompt to be shown")
+}
+
+// TestSelect verifies selecting options by number and by text.
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	options := []string{"staging", "production"}
+
+	var out bytes.Buffer
+	index, err := Select(context.Background(), "Environment?", options, PromptOptions{In: strings.NewReader("3\nproduction\n"), Out: &out})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 1, index, "Expected the option chosen by text")
+	assert.Equal(t, "Environment?\n  1) staging\n  2) production\nChoose [1-2]: Please choose one of the listed options.\nChoose [1-2]: ", out.String(), "Unexpected prompt")
+
+	index, err = Select(context.Background(), "Environment?", options, PromptOptions{In: strings.NewReader("1\n"), Out: io.Discard})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 0, index, "Expected the option chosen by number")
+
+	_, err = Select(context.Background(), "Environment?", nil, PromptOptions{})
+	assert.Error(t, err, "Expected an error without options")
+}
+
+// TestSelectEnv verifies that the selection can be given through the environment.
+func TestSelectEnv(t *testing.T) {
+	t.Setenv("TEST_SELECT", "production")
+
+	index, err := Select(context.Background(), "Environment?", []string{"staging", "production"}, PromptOptions{Env: "TEST_SELECT"})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, 1, index, "Expected the option from the environment")
+
+	t.Setenv("TEST_SELECT", "qa")
+	_, err = Select(context.Background(), "Environment?", []string{"staging", "production"}, PromptOptions{Env: "TEST_SELECT"})
+	assert.Error(t, err, "Expected an error for an unknown option")
+}
+
+// TestPassword verifies reading a secret from a reader and from the environment.
+func TestPassword(t *testing.T) {
+	var out bytes.Buffer
+	secret, err := Password(context.Background(), "Token", PromptOptions{In: strings.NewReader("s3cret\r\n"), Out: &out})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "s3cret", secret, "Unexpected secret")
+	assert.Equal(t, "Token: \n", out.String(), "Expected the secret not to be written")
+
+	t.Setenv("TEST_TOKEN", "from-env")
+	secret, err = Password(context.Background(), "Token", PromptOptions{Env: "TEST_TOKEN"})
+	assert.NoError(t, err, "Expected no error")
+	assert.Equal(t, "from-env", secret, "Expected the secret from the environment")
+}
+
+// TestPromptNotInteractive verifies that redirected input is not read.
+func TestPromptNotInteractive(t *testing.T) {
+	t.Setenv(AssumeYesEnv, "")
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "in"))
+	assert.NoError(t, err, "Expected the file to be created")
+	defer file.Close()
+
+	_, err = Confirm(context.Background(), "Delete?", PromptOptions{In: file, Out: io.Discard})
+	assert.ErrorIs(t, err, ErrNotInteractive, "Expected a regular file not to be interactive")
+}
+
+// TestPromptCancel verifies that a prompt waiting for input returns when the context is cancelled.
+func TestPromptCancel(t *testing.T) {
+	t.Setenv(AssumeYesEnv, "")
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Confirm(ctx, "Delete?", PromptOptions{In: reader, Out: io.Discard})
+	assert.ErrorIs(t, err, context.Canceled, "Expected the context error")
+}
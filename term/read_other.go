@@ -0,0 +1,14 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package term
+
+import (
+	"context"
+	"os"
+)
+
+// readTerminalLine reads a line from the terminal file. Terminal reads cannot be interrupted on this
+// platform, so the read finishes in the background when ctx is done first.
+func readTerminalLine(ctx context.Context, file *os.File) (string, error) {
+	return readLineBackground(ctx, file)
+}
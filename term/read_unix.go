@@ -0,0 +1,78 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package term
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// readTerminalLine reads a line from the terminal file. The terminal is polled together with a pipe
+// written when ctx is done, so the read stops with the context error and nothing keeps reading the
+// terminal afterwards.
+func readTerminalLine(ctx context.Context, file *os.File) (string, error) {
+	var wake [2]int
+	if err := unix.Pipe(wake[:]); err != nil {
+		return "", err
+	}
+	defer unix.Close(wake[0])
+	defer unix.Close(wake[1])
+
+	woken := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(woken)
+		_, _ = unix.Write(wake[1], []byte{0})
+	})
+	defer func() {
+		// Wait for a running wake-up, which must not write to the pipe after it is closed.
+		if !stop() {
+			<-woken
+		}
+	}()
+
+	return readLineFrom(&pollReader{ctx: ctx, fd: int(file.Fd()), wake: wake[0]})
+}
+
+// pollReader reads from fd once it is readable, failing with the context error when wake becomes
+// readable first.
+type pollReader struct {
+	// ctx provides the error returned when the read is woken.
+	ctx context.Context
+	// fd is the terminal read from.
+	fd int
+	// wake is the read end of the pipe written when ctx is done.
+	wake int
+}
+
+// Read implements io.Reader.
+func (r *pollReader) Read(p []byte) (int, error) {
+	fds := []unix.PollFd{{Fd: int32(r.fd), Events: unix.POLLIN}, {Fd: int32(r.wake), Events: unix.POLLIN}}
+	for {
+		if _, err := unix.Poll(fds, -1); err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			return 0, err
+		}
+		if fds[1].Revents != 0 {
+			return 0, r.ctx.Err()
+		}
+		if fds[0].Revents != 0 {
+			break
+		}
+	}
+
+	n, err := unix.Read(r.fd, p)
+	switch {
+	case err != nil:
+		return 0, err
+	case n == 0:
+		return 0, io.EOF
+	}
+
+	return n, nil
+}
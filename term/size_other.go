@@ -0,0 +1,13 @@
+//go:build !(aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris)
+
+package term
+
+import (
+	"errors"
+	"os"
+)
+
+// size is not supported on this platform, so Width falls back to COLUMNS.
+func size(*os.File) (width, height int, err error) {
+	return 0, 0, errors.ErrUnsupported
+}
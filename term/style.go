@@ -0,0 +1,94 @@
+package term
+
+import (
+	"io"
+	"strings"
+)
+
+// Style is an ANSI SGR (Select Graphic Rendition) parameter list, such as "1" for bold or "31" for red.
+type Style string
+
+// Text attributes.
+const (
+	Bold      Style = "1"
+	Dim       Style = "2"
+	Italic    Style = "3"
+	Underline Style = "4"
+)
+
+// Foreground colors.
+const (
+	Black   Style = "30"
+	Red     Style = "31"
+	Green   Style = "32"
+	Yellow  Style = "33"
+	Blue    Style = "34"
+	Magenta Style = "35"
+	Cyan    Style = "36"
+	White   Style = "37"
+	Gray    Style = "90"
+)
+
+// reset restores the default rendition.
+const reset = "\x1b[0m"
+
+// Combine returns a style applying all the given styles at once, for example Combine(Bold, Red).
+func Combine(styles ...Style) Style {
+	parts := make([]string, 0, len(styles))
+	for _, style := range styles {
+		if style != "" {
+			parts = append(parts, string(style))
+		}
+	}
+
+	return Style(strings.Join(parts, ";"))
+}
+
+// Apply wraps text in the escape sequences of the style, regardless of the output.
+// Use a Palette to apply styles only where colors are enabled.
+func (s Style) Apply(text string) string {
+	if s == "" || text == "" {
+		return text
+	}
+
+	return "\x1b[" + string(s) + "m" + text + reset
+}
+
+// Palette applies styles for a particular output, and leaves text unchanged when colors are disabled there.
+type Palette struct {
+	// enabled reports whether styles are applied.
+	enabled bool
+}
+
+// NewPalette creates a Palette for w, enabled as reported by ColorEnabled.
+func NewPalette(w io.Writer) *Palette {
+	return &Palette{enabled: ColorEnabled(w)}
+}
+
+// NewPaletteEnabled creates a Palette that applies styles if enabled is set,
+// for example to honor a --color=always flag.
+func NewPaletteEnabled(enabled bool) *Palette {
+	return &Palette{enabled: enabled}
+}
+
+// Enabled reports whether the palette applies styles.
+func (p *Palette) Enabled() bool {
+	return p.enabled
+}
+
+// Apply returns text with the style applied if the palette is enabled, and text unchanged otherwise.
+func (p *Palette) Apply(style Style, text string) string {
+	if !p.enabled {
+		return text
+	}
+
+	return style.Apply(text)
+}
+
+// Styler returns a function applying style through the palette, suitable for options such as
+// render.TableOptions.HeaderStyle.
+func (p *Palette) Styler(style Style) func(string) string {
+	return func(text string) string {
+		return p.Apply(style, text)
+	}
+}
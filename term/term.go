@@ -0,0 +1,84 @@
+package term
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DefaultWidth is the terminal width assumed when the size cannot be queried.
+const DefaultWidth = 80
+
+// ErrNotTerminal is returned by Size when the writer is not a terminal.
+var ErrNotTerminal = errors.New("term: not a terminal")
+
+// IsTerminal reports whether w is a terminal, such as os.Stdout attached to an interactive shell.
+// Writers that are not files, like buffers and pipes, are never terminals.
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+
+	return ok && isTerminalFile(file)
+}
+
+// ColorEnabled reports whether colors should be written to w. Colors are disabled when w is not
+// a terminal, when the NO_COLOR environment variable is set to a non-empty value (see no-color.org),
+// and when TERM is "dumb".
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	return IsTerminal(w)
+}
+
+// Size returns the width and height of the terminal w in characters.
+// It fails if w is not a terminal or its size cannot be queried on this platform.
+func Size(w io.Writer) (width, height int, err error) {
+	file, ok := w.(*os.File)
+	if !ok || !IsTerminal(w) {
+		return 0, 0, ErrNotTerminal
+	}
+
+	return size(file)
+}
+
+// Width returns the width of the terminal w, falling back to the COLUMNS environment variable
+// and then DefaultWidth, which keeps layouts sensible in pipes and CI logs.
+func Width(w io.Writer) int {
+	if width, _, err := Size(w); err == nil && width > 0 {
+		return width
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	return DefaultWidth
+}
+
+// Strip removes ANSI CSI escape sequences, such as color codes, from s.
+// Strip text before measuring its width, as escape sequences take no space on the terminal.
+func Strip(s string) string {
+	// Avoid allocating for the common case of plain text.
+	if !strings.Contains(s, "\x1b[") {
+		return s
+	}
+
+	var out strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '[' {
+			// Skip the parameters up to and including the final byte in the range 0x40-0x7e.
+			j := i + 2
+			for j < len(s) && (s[j] < 0x40 || s[j] > 0x7e) {
+				j++
+			}
+			i = j
+			continue
+		}
+		out.WriteByte(s[i])
+	}
+
+	return out.String()
+}
@@ -0,0 +1,79 @@
+package term
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStrip verifies that escape sequences are removed and other text is kept.
+func TestStrip(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for plain text, single and combined sequences and unterminated sequences.
+	cases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Plain text", input: "hello", expected: "hello"},
+		{name: "Color", input: "\x1b[31mred\x1b[0m", expected: "red"},
+		{name: "Combined style", input: "a\x1b[1;32mb\x1b[0mc", expected: "abc"},
+		{name: "Cursor movement", input: "\x1b[2Kline", expected: "line"},
+		{name: "Unterminated", input: "text\x1b[31", expected: "text"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Strip(tt.input), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestStyle verifies that styles produce the expected escape sequences.
+func TestStyle(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "\x1b[31mx\x1b[0m", Red.Apply("x"), "Unexpected red text")
+	assert.Equal(t, "\x1b[1;32mx\x1b[0m", Combine(Bold, Green).Apply("x"), "Unexpected combined style")
+	assert.Equal(t, "", Red.Apply(""), "Expected empty text to stay empty")
+	assert.Equal(t, "x", Style("").Apply("x"), "Expected an empty style to leave the text unchanged")
+}
+
+// TestPalette verifies that styles are only applied by enabled palettes.
+func TestPalette(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "x", NewPalette(&bytes.Buffer{}).Apply(Red, "x"), "Expected no colors for a buffer")
+	assert.Equal(t, "\x1b[31mx\x1b[0m", NewPaletteEnabled(true).Apply(Red, "x"), "Expected colors when enabled")
+	assert.Equal(t, "\x1b[1mx\x1b[0m", NewPaletteEnabled(true).Styler(Bold)("x"), "Expected the styler to apply the style")
+}
+
+// TestTerminalDetection verifies the detection of terminals and the size fallbacks for other outputs.
+func TestTerminalDetection(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	assert.NoError(t, err, "Expected the file to be created")
+	defer file.Close()
+
+	assert.False(t, IsTerminal(&bytes.Buffer{}), "Expected a buffer not to be a terminal")
+	assert.False(t, IsTerminal(file), "Expected a regular file not to be a terminal")
+
+	_, _, err = Size(file)
+	assert.ErrorIs(t, err, ErrNotTerminal, "Expected a size error for a regular file")
+
+	t.Setenv("COLUMNS", "120")
+	assert.Equal(t, 120, Width(file), "Expected the COLUMNS fallback")
+
+	t.Setenv("COLUMNS", "")
+	assert.Equal(t, DefaultWidth, Width(file), "Expected the default width")
+}
+
+// TestColorEnabled verifies that NO_COLOR disables colors.
+func TestColorEnabled(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	assert.False(t, ColorEnabled(os.Stdout), "Expected NO_COLOR to disable colors")
+}
@@ -0,0 +1,11 @@
+//go:build aix || linux || solaris
+
+package term
+
+import "golang.org/x/sys/unix"
+
+// The ioctl requests reading and writing the terminal settings on Linux and System V derived systems.
+const (
+	ioctlGetTermios = unix.TCGETS
+	ioctlSetTermios = unix.TCSETS
+)
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// LeakGracePeriod is how long VerifyNoLeaks waits for goroutines started by the test to exit.
+const LeakGracePeriod = time.Second
+
+// deadlineMargin is how long before the test deadline the context returned by Context expires.
+const deadlineMargin = time.Second
+
+// Context returns a context that is cancelled when the test and all its subtests complete.
+// If the test has a deadline, the context expires slightly before it, so that blocked code
+// returns with a context error instead of the whole test binary being killed.
+func Context(t testing.TB) context.Context {
+	t.Helper()
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+
+	// Only *testing.T knows its deadline, benchmarks and fuzz targets run without one.
+	if deadliner, ok := t.(interface{ Deadline() (time.Time, bool) }); ok {
+		if deadline, ok := deadliner.Deadline(); ok {
+			ctx, cancel = context.WithDeadline(ctx, deadline.Add(-deadlineMargin))
+		}
+	}
+
+	ctx, cancelTest := context.WithCancel(ctx)
+	t.Cleanup(func() {
+		cancelTest()
+		cancel()
+	})
+
+	return ctx
+}
+
+// Eventually calls cond every tick until it returns true, and fails the test if it does not
+// within timeout. Unlike a fixed sleep, it returns as soon as the condition holds.
+func Eventually(t testing.TB, cond func() bool, timeout, tick time.Duration) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		if cond() {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("condition was not met within %s", timeout)
+			return
+		}
+
+		<-ticker.C
+	}
+}
+
+// VerifyNoLeaks records the goroutines running at the time of the call and, when the test completes,
+// fails it if goroutines started since then are still running after LeakGracePeriod.
+// Call it at the start of a test that is not run in parallel, since goroutines of parallel tests
+// would be reported as leaks as well.
+func VerifyNoLeaks(t testing.TB) {
+	t.Helper()
+
+	before := goroutines()
+
+	t.Cleanup(func() {
+		t.Helper()
+
+		var leaked []string
+		deadline := time.Now().Add(LeakGracePeriod)
+		for {
+			leaked = leaked[:0]
+			for id, stack := range goroutines() {
+				if _, ok := before[id]; !ok {
+					leaked = append(leaked, stack)
+				}
+			}
+
+			// Goroutines shutting down in the background usually exit shortly after the test.
+			if len(leaked) == 0 || time.Now().After(deadline) {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		if len(leaked) > 0 {
+			t.Errorf("found %d leaked goroutine(s):\n\n%s", len(leaked), strings.Join(leaked, "\n\n"))
+		}
+	})
+}
+
+// goroutines returns the stacks of the running goroutines keyed by goroutine ID.
+// The calling goroutine and goroutines run by the testing framework itself are excluded.
+func goroutines() map[string]string {
+	// Grow the buffer until the stacks of all goroutines fit into it.
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	result := make(map[string]string)
+	for i, stack := range strings.Split(string(buf), "\n\n") {
+		// The first stack always belongs to the calling goroutine.
+		if i == 0 || strings.Contains(stack, "testing.tRunner(") || strings.Contains(stack, "testing.(*M).") {
+			continue
+		}
+
+		// Every stack starts with a header like "goroutine 12 [running]:".
+		header, _, _ := strings.Cut(stack, "\n")
+		fields := strings.Fields(header)
+		if len(fields) < 2 || fields[0] != "goroutine" {
+			continue
+		}
+		result[fields[1]] = stack
+	}
+
+	return result
+}
@@ -0,0 +1,172 @@
+package test
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+)
+
+// letters is the alphabet used by RandomString.
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// NewRand creates a random source seeded with the given value.
+// Using a fixed seed makes the generated fixtures identical on every run, so failing tests are reproducible.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
+
+// RandomSlice generates a slice of n elements, producing each element with gen.
+// The index of the element is passed to gen, which allows generating sequences as well as random values.
+func RandomSlice[T any](n int, gen func(i int) T) []T {
+	// A non-positive size produces an empty slice.
+	if n <= 0 {
+		return []T{}
+	}
+
+	slice := make([]T, n)
+	for i := range slice {
+		slice[i] = gen(i)
+	}
+
+	return slice
+}
+
+// RandomString generates a string of n alphanumeric characters using the random source r.
+func RandomString(r *rand.Rand, n int) string {
+	// A non-positive length produces an empty string.
+	if n <= 0 {
+		return ""
+	}
+
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = letters[r.IntN(len(letters))]
+	}
+
+	return string(buf)
+}
+
+// RandomBytes generates n random bytes using the random source r.
+func RandomBytes(r *rand.Rand, n int) []byte {
+	// A non-positive length produces an empty slice.
+	if n <= 0 {
+		return []byte{}
+	}
+
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = byte(r.UintN(256))
+	}
+
+	return buf
+}
+
+// Fill populates the exported fields of the struct pointed to by v with random values drawn from r.
+// Strings, booleans, integers, floats, byte slices, slices, maps, nested structs and pointers are supported;
+// fields of other kinds and unexported fields are left untouched. Values nested deeper than a few levels,
+// such as the tail of a self-referencing type, are left at their zero value. Calling Fill with sources created by NewRand with the same seed
+// produces the same values, so filled structs can be used as reproducible fixtures.
+func Fill(r *rand.Rand, v any) error {
+	value := reflect.ValueOf(v)
+
+	// Only a non-nil pointer to a struct can be filled in place.
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return errors.New("test: Fill expects a non-nil pointer to a struct")
+	}
+
+	fillValue(r, value.Elem(), 0)
+
+	return nil
+}
+
+// maxFillDepth limits the nesting depth of Fill, which protects against self-referencing types.
+const maxFillDepth = 5
+
+// fillValue assigns a random value to value depending on its kind.
+func fillValue(r *rand.Rand, value reflect.Value, depth int) {
+	// Stop descending into deeply nested or recursive types.
+	if depth > maxFillDepth {
+		return
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(RandomString(r, 1+r.IntN(16)))
+	case reflect.Bool:
+		value.SetBool(r.IntN(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Keep the value within the range of the narrowest integer type.
+		value.SetInt(int64(r.IntN(128)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(uint64(r.IntN(256)))
+	case reflect.Float32, reflect.Float64:
+		value.SetFloat(r.Float64() * 1000)
+	case reflect.Slice:
+		// Generate a short slice and fill each of its elements.
+		length := 1 + r.IntN(3)
+		slice := reflect.MakeSlice(value.Type(), length, length)
+		for i := 0; i < slice.Len(); i++ {
+			fillValue(r, slice.Index(i), depth+1)
+		}
+		value.Set(slice)
+	case reflect.Map:
+		// Generate a few entries; keys drawn twice simply overwrite each other.
+		count := 1 + r.IntN(3)
+		m := reflect.MakeMapWithSize(value.Type(), count)
+		for range count {
+			key := reflect.New(value.Type().Key()).Elem()
+			fillValue(r, key, depth+1)
+			elem := reflect.New(value.Type().Elem()).Elem()
+			fillValue(r, elem, depth+1)
+			m.SetMapIndex(key, elem)
+		}
+		value.Set(m)
+	case reflect.Pointer:
+		// Allocate the pointed-to value and fill it.
+		pointer := reflect.New(value.Type().Elem())
+		fillValue(r, pointer.Elem(), depth+1)
+		value.Set(pointer)
+	case reflect.Struct:
+		// Fill every exported field, as unexported fields cannot be set through reflection.
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).IsExported() {
+				fillValue(r, value.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// Builder creates fixtures from a base value by applying a chain of modifications.
+// It is useful in table tests where each case differs from a common fixture by one or two fields.
+type Builder[T any] struct {
+	// base is the value every build starts from.
+	base T
+	// modifiers are applied to a copy of the base value in order.
+	modifiers []func(*T)
+}
+
+// NewBuilder creates a Builder starting from the given base value.
+func NewBuilder[T any](base T) *Builder[T] {
+	return &Builder[T]{base: base}
+}
+
+// With returns a new Builder that additionally applies modify, leaving the receiver unchanged.
+// This allows deriving several fixtures from a shared partially configured builder.
+func (b *Builder[T]) With(modify func(*T)) *Builder[T] {
+	// Copy the modifiers so that builders derived from the same parent do not share them.
+	modifiers := make([]func(*T), len(b.modifiers), len(b.modifiers)+1)
+	copy(modifiers, b.modifiers)
+
+	return &Builder[T]{base: b.base, modifiers: append(modifiers, modify)}
+}
+
+// Build returns a copy of the base value with all modifications applied.
+// The copy is shallow, so modifiers should replace rather than mutate shared slices, maps and pointers.
+func (b *Builder[T]) Build() T {
+	value := b.base
+	for _, modify := range b.modifiers {
+		modify(&value)
+	}
+
+	return value
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// address and customer are nested fixtures filled by the tests.
+type (
+	address struct {
+		City string
+		Zip  uint16
+	}
+	customer struct {
+		Name     string
+		Age      int8
+		Active   bool
+		Score    float64
+		Avatar   []byte
+		Tags     []string
+		Labels   map[string]int
+		Address  address
+		Billing  *address
+		internal string
+	}
+)
+
+// chain is a self-referencing type, which Fill must not follow forever.
+type chain struct {
+	Value int
+	Next  *chain
+}
+
+// TestGenerators verifies that the random generators are reproducible for a fixed seed.
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	// Deterministic ensures sources with the same seed produce the same values.
+	t.Run("Deterministic", func(t *testing.T) {
+		first, second := NewRand(42), NewRand(42)
+
+		assert.Equal(t, RandomString(first, 32), RandomString(second, 32), "Expected the same string")
+		assert.Equal(t, RandomBytes(first, 32), RandomBytes(second, 32), "Expected the same bytes")
+		assert.NotEqual(t, RandomString(NewRand(1), 32), RandomString(NewRand(2), 32), "Expected different seeds to differ")
+	})
+
+	// Sizes ensures the generated values have the requested size, and non-positive sizes yield empty values.
+	t.Run("Sizes", func(t *testing.T) {
+		r := NewRand(1)
+
+		assert.Len(t, RandomString(r, 10), 10, "Unexpected string length")
+		assert.Len(t, RandomBytes(r, 10), 10, "Unexpected byte count")
+		assert.Empty(t, RandomString(r, -1), "Expected an empty string")
+		assert.Equal(t, []byte{}, RandomBytes(r, 0), "Expected empty bytes")
+		assert.Equal(t, []int{0, 2, 4}, RandomSlice(3, func(i int) int { return i * 2 }), "Unexpected slice")
+		assert.Equal(t, []int{}, RandomSlice(0, func(i int) int { return i }), "Expected an empty slice")
+	})
+}
+
+// TestFill verifies that Fill populates structs reproducibly.
+func TestFill(t *testing.T) {
+	t.Parallel()
+
+	// Nested ensures exported fields of every supported kind are filled, including nested ones.
+	t.Run("Nested", func(t *testing.T) {
+		var value customer
+		assert.NoError(t, Fill(NewRand(7), &value), "Expected no error")
+
+		assert.NotEmpty(t, value.Name, "Expected a name")
+		assert.NotEmpty(t, value.Avatar, "Expected bytes")
+		assert.NotEmpty(t, value.Tags, "Expected a slice")
+		assert.NotEmpty(t, value.Labels, "Expected a map")
+		assert.NotEmpty(t, value.Address.City, "Expected the nested struct to be filled")
+		if assert.NotNil(t, value.Billing, "Expected the pointer to be allocated") {
+			assert.NotEmpty(t, value.Billing.City, "Expected the pointed-to struct to be filled")
+		}
+		assert.Empty(t, value.internal, "Expected unexported fields to be left untouched")
+	})
+
+	// Deterministic ensures the same seed fills the same values.
+	t.Run("Deterministic", func(t *testing.T) {
+		var first, second customer
+		assert.NoError(t, Fill(NewRand(7), &first), "Expected no error")
+		assert.NoError(t, Fill(NewRand(7), &second), "Expected no error")
+
+		assert.Equal(t, first, second, "Expected identical fixtures")
+	})
+
+	// Depth ensures self-referencing types are filled up to the maximum depth only.
+	t.Run("Depth", func(t *testing.T) {
+		var value chain
+		assert.NoError(t, Fill(NewRand(3), &value), "Expected no error")
+
+		links := 0
+		for node := value.Next; node != nil; node = node.Next {
+			links++
+		}
+		assert.Positive(t, links, "Expected the pointers to be followed")
+		assert.LessOrEqual(t, links, maxFillDepth, "Expected the recursion to stop")
+	})
+
+	// InvalidTarget ensures values other than non-nil struct pointers are rejected.
+	t.Run("InvalidTarget", func(t *testing.T) {
+		var value customer
+		assert.Error(t, Fill(NewRand(1), value), "Expected an error for a struct value")
+		assert.Error(t, Fill(NewRand(1), (*customer)(nil)), "Expected an error for a nil pointer")
+		assert.Error(t, Fill(NewRand(1), new(int)), "Expected an error for a non-struct pointer")
+	})
+}
+
+// TestBuilder verifies that builders derive fixtures without affecting each other.
+func TestBuilder(t *testing.T) {
+	t.Parallel()
+
+	base := NewBuilder(address{City: "Riga", Zip: 1000})
+	withCity := base.With(func(a *address) { a.City = "Tallinn" })
+	withZip := withCity.With(func(a *address) { a.Zip = 2000 })
+	otherZip := withCity.With(func(a *address) { a.Zip = 3000 })
+
+	assert.Equal(t, address{City: "Riga", Zip: 1000}, base.Build(), "Expected the base to stay unchanged")
+	assert.Equal(t, address{City: "Tallinn", Zip: 1000}, withCity.Build(), "Expected the parent to stay unchanged")
+	assert.Equal(t, address{City: "Tallinn", Zip: 2000}, withZip.Build(), "Unexpected derived fixture")
+	assert.Equal(t, address{City: "Tallinn", Zip: 3000}, otherZip.Build(), "Expected siblings not to share modifiers")
+}
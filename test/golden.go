@@ -0,0 +1,87 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	commonstrings "github.com/SyntaxErrorLineNULL/common/strings"
+)
+
+// update rewrites golden files with the actual output instead of comparing against them.
+// Run `go test ./... -update` after an intended change of output and review the resulting diff.
+var update = flag.Bool("update", false, "update golden files")
+
+// GoldenDir is the directory, relative to the package under test, where golden files are stored.
+const GoldenDir = "testdata/golden"
+
+// Golden compares got with the contents of the golden file GoldenDir/name and fails the test with a line diff
+// when they differ. When the -update flag is set, the golden file is written instead.
+// Names ending in ".json" are compared after normalizing both sides, so indentation and the order
+// of object keys do not cause spurious failures.
+func Golden(t testing.TB, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join(GoldenDir, name)
+
+	// Normalize JSON output so the golden file stays stable and readable.
+	if strings.HasSuffix(name, ".json") {
+		normalized, err := normalizeJSON(got)
+		if err != nil {
+			t.Fatalf("golden %s: invalid JSON output: %v", name, err)
+		}
+		got = normalized
+	}
+
+	// Rewrite the golden file when requested.
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("golden %s: %v", name, err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("golden %s: %v (run the tests with -update to create it)", name, err)
+	}
+
+	// Normalize the stored JSON as well, in case it was edited by hand.
+	if strings.HasSuffix(name, ".json") {
+		if want, err = normalizeJSON(want); err != nil {
+			t.Fatalf("golden %s: invalid JSON in golden file: %v", name, err)
+		}
+	}
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("golden %s: output differs (-want +got):\n%s", name, commonstrings.Diff(string(want), string(got)))
+	}
+}
+
+// normalizeJSON re-encodes data with sorted object keys and a fixed indentation.
+func normalizeJSON(data []byte) ([]byte, error) {
+	// Decoding into an empty interface sorts object keys on re-encoding,
+	// while UseNumber keeps numbers exactly as written.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	normalized, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	// End the file with a newline, as most editors do.
+	return append(normalized, '\n'), nil
+}
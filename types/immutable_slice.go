@@ -0,0 +1,94 @@
+package types
+
+// ImmutableSlice is a read-only view over a sequence of elements. The constructor copies its input and
+// no method exposes the backing array, so values can be handed out freely without the risk of a caller
+// mutating shared data. Operations that "modify" the slice follow copy-on-write semantics and return
+// a new ImmutableSlice, leaving the original untouched. The zero value is an empty slice ready to use.
+// ImmutableSlice is safe for concurrent reads, as it is never modified after construction.
+type ImmutableSlice[T any] struct {
+	// items holds the elements; it is never written to after construction.
+	items []T
+}
+
+// NewImmutableSlice creates an ImmutableSlice holding a copy of the given elements.
+func NewImmutableSlice[T any](elements ...T) ImmutableSlice[T] {
+	return ImmutableSlice[T]{items: cloneSlice(elements)}
+}
+
+// Len returns the number of elements.
+func (s ImmutableSlice[T]) Len() int {
+	return len(s.items)
+}
+
+// At returns the element at index i and true, or the zero value and false if i is out of range.
+func (s ImmutableSlice[T]) At(i int) (T, bool) {
+	if i < 0 || i >= len(s.items) {
+		var zero T
+		return zero, false
+	}
+
+	return s.items[i], true
+}
+
+// ToSlice returns a mutable copy of the elements. Modifying the result does not affect the ImmutableSlice.
+func (s ImmutableSlice[T]) ToSlice() []T {
+	return cloneSlice(s.items)
+}
+
+// Iterate calls fn for every element in order, stopping early when fn returns false.
+func (s ImmutableSlice[T]) Iterate(fn func(i int, element T) bool) {
+	for i, element := range s.items {
+		if !fn(i, element) {
+			return
+		}
+	}
+}
+
+// Sub returns the elements in the range [from, to) as a new ImmutableSlice. The bounds are clamped
+// to the valid range, and an empty slice is returned when from is not less than to. The result shares
+// memory with the original, which is safe because neither can be modified.
+func (s ImmutableSlice[T]) Sub(from, to int) ImmutableSlice[T] {
+	// Clamp the bounds to the valid range.
+	from = max(from, 0)
+	to = min(to, len(s.items))
+	if from >= to {
+		return ImmutableSlice[T]{}
+	}
+
+	// Limit the capacity so that an append on the copy-on-write path can never reach past the range.
+	return ImmutableSlice[T]{items: s.items[from:to:to]}
+}
+
+// Append returns a new ImmutableSlice with the elements added at the end. The original is not modified.
+func (s ImmutableSlice[T]) Append(elements ...T) ImmutableSlice[T] {
+	items := make([]T, 0, len(s.items)+len(elements))
+	items = append(items, s.items...)
+	items = append(items, elements...)
+
+	return ImmutableSlice[T]{items: items}
+}
+
+// With returns a new ImmutableSlice where the element at index i is replaced by value, and true.
+// If i is out of range, the original slice and false are returned.
+func (s ImmutableSlice[T]) With(i int, value T) (ImmutableSlice[T], bool) {
+	if i < 0 || i >= len(s.items) {
+		return s, false
+	}
+
+	items := cloneSlice(s.items)
+	items[i] = value
+
+	return ImmutableSlice[T]{items: items}, true
+}
+
+// cloneSlice returns a copy of elements, preserving nil for nil input.
+func cloneSlice[T any](elements []T) []T {
+	if elements == nil {
+		return nil
+	}
+
+	result := make([]T, len(elements))
+	copy(result, elements)
+
+	return result
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestImmutableSlice verifies that ImmutableSlice never exposes or modifies its backing data.
+func TestImmutableSlice(t *testing.T) {
+	t.Parallel()
+
+	// ConstructorCopies ensures modifying the input after construction does not affect the slice.
+	t.Run("ConstructorCopies", func(t *testing.T) {
+		input := []int{1, 2, 3}
+		slice := NewImmutableSlice(input...)
+		input[0] = 100
+
+		value, ok := slice.At(0)
+		assert.True(t, ok, "Expected index 0 to be in range")
+		assert.Equal(t, 1, value, "Expected the original value")
+	})
+
+	// ToSliceCopies ensures modifying the exported copy does not affect the slice.
+	t.Run("ToSliceCopies", func(t *testing.T) {
+		slice := NewImmutableSlice("a", "b")
+		exported := slice.ToSlice()
+		exported[0] = "changed"
+
+		assert.Equal(t, []string{"a", "b"}, slice.ToSlice(), "Expected the slice to stay unchanged")
+	})
+
+	// At ensures out-of-range indexes are reported.
+	t.Run("At", func(t *testing.T) {
+		slice := NewImmutableSlice(1, 2)
+
+		_, ok := slice.At(2)
+		assert.False(t, ok, "Expected index 2 to be out of range")
+		_, ok = slice.At(-1)
+		assert.False(t, ok, "Expected a negative index to be out of range")
+	})
+
+	// CopyOnWrite ensures Append and With return new slices and leave the original untouched.
+	t.Run("CopyOnWrite", func(t *testing.T) {
+		original := NewImmutableSlice(1, 2, 3)
+
+		appended := original.Append(4)
+		replaced, ok := original.With(1, 20)
+
+		assert.True(t, ok, "Expected index 1 to be replaced")
+		assert.Equal(t, []int{1, 2, 3}, original.ToSlice(), "Expected the original to stay unchanged")
+		assert.Equal(t, []int{1, 2, 3, 4}, appended.ToSlice(), "Unexpected appended slice")
+		assert.Equal(t, []int{1, 20, 3}, replaced.ToSlice(), "Unexpected replaced slice")
+
+		_, ok = original.With(3, 0)
+		assert.False(t, ok, "Expected an out-of-range replacement to be rejected")
+	})
+
+	// Sub ensures sub-slices clamp their bounds and do not leak writes into the original.
+	t.Run("Sub", func(t *testing.T) {
+		original := NewImmutableSlice(1, 2, 3, 4, 5)
+
+		assert.Equal(t, []int{2, 3}, original.Sub(1, 3).ToSlice(), "Unexpected sub-slice")
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, original.Sub(-5, 50).ToSlice(), "Expected the bounds to be clamped")
+		assert.Zero(t, original.Sub(3, 1).Len(), "Expected an empty sub-slice for inverted bounds")
+
+		original.Sub(0, 2).Append(100)
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, original.ToSlice(), "Expected the original to stay unchanged")
+	})
+
+	// IterateAndZeroValue ensures iteration stops early and the zero value is usable.
+	t.Run("IterateAndZeroValue", func(t *testing.T) {
+		var visited []int
+		NewImmutableSlice(1, 2, 3).Iterate(func(_ int, element int) bool {
+			visited = append(visited, element)
+			return element < 2
+		})
+		assert.Equal(t, []int{1, 2}, visited, "Expected iteration to stop after 2")
+
+		var empty ImmutableSlice[string]
+		assert.Zero(t, empty.Len(), "Expected the zero value to be empty")
+		assert.Equal(t, []string{"x"}, empty.Append("x").ToSlice(), "Expected the zero value to support Append")
+	})
+}
@@ -0,0 +1,260 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// orderedEntry is a node of the doubly linked list that keeps the insertion order of an OrderedMap.
+type orderedEntry[K comparable, V any] struct {
+	// key is the key of the entry.
+	key K
+	// value is the value stored under the key.
+	value V
+	// prev points to the previously inserted entry.
+	prev *orderedEntry[K, V]
+	// next points to the next inserted entry.
+	next *orderedEntry[K, V]
+}
+
+// OrderedMap is a generic map that remembers the order in which keys were first inserted.
+// Lookups, insertions and deletions run in O(1), and iteration as well as JSON encoding follow
+// the insertion order, which makes the output deterministic. Updating the value of an existing key
+// keeps its original position. The zero value is an empty map ready to use.
+// OrderedMap is not safe for concurrent use.
+type OrderedMap[K comparable, V any] struct {
+	// entries indexes the list nodes by key.
+	entries map[K]*orderedEntry[K, V]
+	// head is the oldest entry.
+	head *orderedEntry[K, V]
+	// tail is the newest entry.
+	tail *orderedEntry[K, V]
+}
+
+// NewOrderedMap creates a new, empty OrderedMap.
+func NewOrderedMap[K comparable, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{entries: make(map[K]*orderedEntry[K, V])}
+}
+
+// Get returns the value stored under the key and whether the key is present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	if entry, ok := m.entries[key]; ok {
+		return entry.value, true
+	}
+
+	var zero V
+	return zero, false
+}
+
+// Set stores the value under the key. A new key is appended to the end of the order,
+// while an existing key keeps its position and only its value is replaced.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	// Update the value in place if the key already exists.
+	if entry, ok := m.entries[key]; ok {
+		entry.value = value
+		return
+	}
+
+	// Lazily initialize the index so that a zero-value map is ready to use.
+	if m.entries == nil {
+		m.entries = make(map[K]*orderedEntry[K, V])
+	}
+
+	// Link a new entry at the tail of the list.
+	entry := &orderedEntry[K, V]{key: key, value: value, prev: m.tail}
+	if m.tail != nil {
+		m.tail.next = entry
+	} else {
+		m.head = entry
+	}
+	m.tail = entry
+	m.entries[key] = entry
+}
+
+// Delete removes the key from the map and reports whether it was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	entry, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+
+	// Unlink the entry from its neighbours, updating the head and tail when needed.
+	if entry.prev != nil {
+		entry.prev.next = entry.next
+	} else {
+		m.head = entry.next
+	}
+	if entry.next != nil {
+		entry.next.prev = entry.prev
+	} else {
+		m.tail = entry.prev
+	}
+
+	delete(m.entries, key)
+
+	return true
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Iterate calls fn for every key and value in insertion order, stopping early when fn returns false.
+// The map must not be modified by fn.
+func (m *OrderedMap[K, V]) Iterate(fn func(key K, value V) bool) {
+	for entry := m.head; entry != nil; entry = entry.next {
+		if !fn(entry.key, entry.value) {
+			return
+		}
+	}
+}
+
+// Keys returns the keys of the map in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.entries))
+	for entry := m.head; entry != nil; entry = entry.next {
+		keys = append(keys, entry.key)
+	}
+
+	return keys
+}
+
+// Values returns the values of the map in insertion order.
+func (m *OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, len(m.entries))
+	for entry := m.head; entry != nil; entry = entry.next {
+		values = append(values, entry.value)
+	}
+
+	return values
+}
+
+// MarshalJSON encodes the map as a JSON object whose members follow the insertion order.
+// Keys are encoded the same way encoding/json encodes them: strings and encoding.TextMarshaler
+// implementations are used as-is, while numeric and boolean keys are converted to strings.
+// Keys of other kinds produce an error.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+
+	for entry := m.head; entry != nil; entry = entry.next {
+		// Separate members with a comma.
+		if entry != m.head {
+			buf.WriteByte(',')
+		}
+
+		// Encode the key, which must end up as a JSON string.
+		key, err := marshalOrderedKey(entry.key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		// Encode the value using the standard encoder.
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(value)
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes a JSON object into the map, preserving the order of its members.
+// Existing entries are kept and keys present in the input are updated or appended.
+// The value null leaves the map unchanged.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	// Read the opening token, which must start an object.
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("ordered map: expected a JSON object")
+	}
+
+	// Decode the members one by one to preserve their order.
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		// Object keys are always strings in JSON.
+		rawKey, ok := token.(string)
+		if !ok {
+			return errors.New("ordered map: expected a string key")
+		}
+
+		key, err := unmarshalOrderedKey[K](rawKey)
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err = decoder.Decode(&value); err != nil {
+			return err
+		}
+
+		m.Set(key, value)
+	}
+
+	// Consume the closing brace.
+	_, err = decoder.Token()
+
+	return err
+}
+
+// marshalOrderedKey encodes a map key as a JSON string.
+func marshalOrderedKey[K comparable](key K) ([]byte, error) {
+	encoded, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Strings and text marshalers are already encoded as JSON strings.
+	if len(encoded) > 0 && encoded[0] == '"' {
+		return encoded, nil
+	}
+
+	// Numbers and booleans are quoted so that they form a valid JSON object key.
+	if len(encoded) > 0 && (encoded[0] == '-' || encoded[0] == 't' || encoded[0] == 'f' || (encoded[0] >= '0' && encoded[0] <= '9')) {
+		return json.Marshal(string(encoded))
+	}
+
+	return nil, errors.New("ordered map: unsupported key type " + string(encoded))
+}
+
+// unmarshalOrderedKey decodes a JSON object key into a value of type K.
+func unmarshalOrderedKey[K comparable](raw string) (K, error) {
+	var key K
+
+	// Re-encode the key as a JSON string so it can be decoded by the standard decoder.
+	quoted, err := json.Marshal(raw)
+	if err != nil {
+		return key, err
+	}
+
+	// Try the key as a JSON string first, which covers strings and text unmarshalers.
+	if err = json.Unmarshal(quoted, &key); err == nil {
+		return key, nil
+	}
+
+	// Otherwise try the raw text, which covers numbers and booleans.
+	if err = json.Unmarshal([]byte(raw), &key); err != nil {
+		return key, err
+	}
+
+	return key, nil
+}
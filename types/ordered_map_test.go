@@ -0,0 +1,144 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOrderedMap verifies that OrderedMap keeps the insertion order across all operations.
+func TestOrderedMap(t *testing.T) {
+	t.Parallel()
+
+	// SetAndGet ensures values can be stored and retrieved.
+	t.Run("SetAndGet", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		m.Set("a", 1)
+
+		value, ok := m.Get("a")
+		assert.True(t, ok, "Expected the key to be present")
+		assert.Equal(t, 1, value, "Unexpected value")
+
+		_, ok = m.Get("missing")
+		assert.False(t, ok, "Expected the key to be absent")
+	})
+
+	// InsertionOrder ensures keys are returned in insertion order and updates keep their position.
+	t.Run("InsertionOrder", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		m.Set("z", 1)
+		m.Set("a", 2)
+		m.Set("m", 3)
+		m.Set("z", 10)
+
+		assert.Equal(t, []string{"z", "a", "m"}, m.Keys(), "Expected keys in insertion order")
+		assert.Equal(t, []int{10, 2, 3}, m.Values(), "Expected the updated value to keep its position")
+	})
+
+	// Delete ensures removed keys disappear from the order, including the head and the tail.
+	t.Run("Delete", func(t *testing.T) {
+		m := NewOrderedMap[int, string]()
+		for i := 1; i <= 5; i++ {
+			m.Set(i, "v")
+		}
+
+		assert.True(t, m.Delete(1), "Expected the head to be deleted")
+		assert.True(t, m.Delete(3), "Expected a middle key to be deleted")
+		assert.True(t, m.Delete(5), "Expected the tail to be deleted")
+		assert.False(t, m.Delete(42), "Expected a missing key to be reported")
+
+		assert.Equal(t, []int{2, 4}, m.Keys(), "Unexpected keys after deletion")
+		assert.Equal(t, 2, m.Len(), "Unexpected length after deletion")
+
+		m.Set(1, "again")
+		assert.Equal(t, []int{2, 4, 1}, m.Keys(), "Expected a re-added key to move to the end")
+	})
+
+	// IterateStopsEarly ensures iteration stops when the callback returns false.
+	t.Run("IterateStopsEarly", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+
+		var visited []string
+		m.Iterate(func(key string, _ int) bool {
+			visited = append(visited, key)
+			return key != "b"
+		})
+
+		assert.Equal(t, []string{"a", "b"}, visited, "Expected iteration to stop after b")
+	})
+}
+
+// TestOrderedMapJSON verifies that JSON encoding and decoding preserve the insertion order.
+func TestOrderedMapJSON(t *testing.T) {
+	t.Parallel()
+
+	// Marshal ensures members are written in insertion order.
+	t.Run("Marshal", func(t *testing.T) {
+		m := NewOrderedMap[string, any]()
+		m.Set("zeta", 1)
+		m.Set("alpha", "two")
+		m.Set("mid", []int{3})
+
+		data, err := json.Marshal(m)
+		assert.NoError(t, err, "Expected no error while marshaling")
+		assert.Equal(t, `{"zeta":1,"alpha":"two","mid":[3]}`, string(data), "Expected members in insertion order")
+	})
+
+	// MarshalEmpty ensures an empty map is encoded as an empty object.
+	t.Run("MarshalEmpty", func(t *testing.T) {
+		data, err := json.Marshal(NewOrderedMap[string, int]())
+		assert.NoError(t, err, "Expected no error while marshaling")
+		assert.Equal(t, `{}`, string(data), "Expected an empty object")
+	})
+
+	// MarshalNumericKeys ensures numeric keys are quoted like encoding/json does.
+	t.Run("MarshalNumericKeys", func(t *testing.T) {
+		m := NewOrderedMap[int, bool]()
+		m.Set(10, true)
+		m.Set(-2, false)
+
+		data, err := json.Marshal(m)
+		assert.NoError(t, err, "Expected no error while marshaling")
+		assert.Equal(t, `{"10":true,"-2":false}`, string(data), "Expected quoted numeric keys")
+	})
+
+	// MarshalUnsupportedKey ensures keys that cannot be represented as strings are rejected.
+	t.Run("MarshalUnsupportedKey", func(t *testing.T) {
+		m := NewOrderedMap[struct{ A int }, int]()
+		m.Set(struct{ A int }{A: 1}, 1)
+
+		_, err := json.Marshal(m)
+		assert.Error(t, err, "Expected an error for a struct key")
+	})
+
+	// UnmarshalRoundTrip ensures decoding keeps the order of the input members.
+	t.Run("UnmarshalRoundTrip", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		err := json.Unmarshal([]byte(`{"c":3,"a":1,"b":2}`), m)
+		assert.NoError(t, err, "Expected no error while unmarshaling")
+		assert.Equal(t, []string{"c", "a", "b"}, m.Keys(), "Expected keys in input order")
+
+		data, err := json.Marshal(m)
+		assert.NoError(t, err, "Expected no error while marshaling")
+		assert.Equal(t, `{"c":3,"a":1,"b":2}`, string(data), "Expected the same document after a round trip")
+	})
+
+	// UnmarshalNumericKeys ensures numeric keys are parsed back into their type.
+	t.Run("UnmarshalNumericKeys", func(t *testing.T) {
+		var m OrderedMap[int, string]
+		err := json.Unmarshal([]byte(`{"2":"b","1":"a"}`), &m)
+		assert.NoError(t, err, "Expected no error while unmarshaling")
+		assert.Equal(t, []int{2, 1}, m.Keys(), "Expected numeric keys in input order")
+	})
+
+	// UnmarshalInvalid ensures non-object input is rejected.
+	t.Run("UnmarshalInvalid", func(t *testing.T) {
+		m := NewOrderedMap[string, int]()
+		assert.Error(t, json.Unmarshal([]byte(`[1,2]`), m), "Expected an error for an array")
+		assert.Error(t, json.Unmarshal([]byte(`{"a":"text"}`), m), "Expected an error for a mistyped value")
+	})
+}
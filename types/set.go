@@ -0,0 +1,177 @@
+package types
+
+import "sync"
+
+// Set is a generic collection of unique comparable elements backed by a map.
+// It replaces the map[T]struct{} boilerplate with a small, expressive API.
+// The zero value is an empty set ready to use.
+// Set is not safe for concurrent use; use SyncSet when the set is shared between goroutines.
+type Set[T comparable] struct {
+	// items holds the elements of the set as map keys.
+	items map[T]struct{}
+}
+
+// NewSet creates a new Set containing the given elements.
+// Duplicate elements are stored only once.
+func NewSet[T comparable](elements ...T) *Set[T] {
+	// Preallocate the map for the provided elements.
+	set := &Set[T]{items: make(map[T]struct{}, len(elements))}
+	set.Add(elements...)
+
+	return set
+}
+
+// Add inserts the given elements into the set. Elements already present are ignored.
+func (s *Set[T]) Add(elements ...T) {
+	// Allocate the map on the first insertion, so the zero value is usable.
+	if s.items == nil {
+		s.items = make(map[T]struct{}, len(elements))
+	}
+
+	for _, element := range elements {
+		s.items[element] = struct{}{}
+	}
+}
+
+// Remove deletes the given elements from the set. Elements that are not present are ignored.
+func (s *Set[T]) Remove(elements ...T) {
+	for _, element := range elements {
+		delete(s.items, element)
+	}
+}
+
+// Has reports whether the element is present in the set.
+func (s *Set[T]) Has(element T) bool {
+	_, ok := s.items[element]
+	return ok
+}
+
+// Len returns the number of elements in the set.
+func (s *Set[T]) Len() int {
+	return len(s.items)
+}
+
+// Union returns a new set containing the elements present in either this set or the other one.
+// Neither set is modified.
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	// Start from a copy of this set sized for both sets.
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items)+len(other.items))}
+	for element := range s.items {
+		result.items[element] = struct{}{}
+	}
+
+	// Add the elements of the other set.
+	for element := range other.items {
+		result.items[element] = struct{}{}
+	}
+
+	return result
+}
+
+// Intersect returns a new set containing only the elements present in both this set and the other one.
+// Neither set is modified.
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	// Iterate over the smaller set and probe the larger one to minimize lookups.
+	small, large := s, other
+	if len(small.items) > len(large.items) {
+		small, large = large, small
+	}
+
+	result := &Set[T]{items: make(map[T]struct{}, len(small.items))}
+	for element := range small.items {
+		if large.Has(element) {
+			result.items[element] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+// ToSlice returns the elements of the set as a slice. The order of the elements is not specified.
+func (s *Set[T]) ToSlice() []T {
+	result := make([]T, 0, len(s.items))
+	for element := range s.items {
+		result = append(result, element)
+	}
+
+	return result
+}
+
+// SyncSet is a Set guarded by a read-write mutex, making it safe for concurrent use by multiple goroutines.
+// The zero value is an empty set ready to use.
+type SyncSet[T comparable] struct {
+	// mu guards the underlying set.
+	mu sync.RWMutex
+	// set holds the elements.
+	set Set[T]
+}
+
+// NewSyncSet creates a new SyncSet containing the given elements.
+func NewSyncSet[T comparable](elements ...T) *SyncSet[T] {
+	return &SyncSet[T]{set: *NewSet(elements...)}
+}
+
+// Add inserts the given elements into the set. Elements already present are ignored.
+func (s *SyncSet[T]) Add(elements ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set.Add(elements...)
+}
+
+// Remove deletes the given elements from the set. Elements that are not present are ignored.
+func (s *SyncSet[T]) Remove(elements ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.set.Remove(elements...)
+}
+
+// Has reports whether the element is present in the set.
+func (s *SyncSet[T]) Has(element T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Has(element)
+}
+
+// Len returns the number of elements in the set.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.Len()
+}
+
+// Union returns a new SyncSet containing the elements present in either this set or the other one.
+// The other set is snapshotted before this set is locked, so both sets are never locked at the same time
+// and calling Union with the same set on both sides does not deadlock.
+func (s *SyncSet[T]) Union(other *SyncSet[T]) *SyncSet[T] {
+	// Take a snapshot of the other set while holding only its lock.
+	snapshot := NewSet(other.ToSlice()...)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &SyncSet[T]{set: *s.set.Union(snapshot)}
+}
+
+// Intersect returns a new SyncSet containing only the elements present in both this set and the other one.
+// Like Union, it snapshots the other set first so that the two locks are never held together.
+func (s *SyncSet[T]) Intersect(other *SyncSet[T]) *SyncSet[T] {
+	// Take a snapshot of the other set while holding only its lock.
+	snapshot := NewSet(other.ToSlice()...)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &SyncSet[T]{set: *s.set.Intersect(snapshot)}
+}
+
+// ToSlice returns the elements of the set as a slice. The order of the elements is not specified.
+func (s *SyncSet[T]) ToSlice() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.set.ToSlice()
+}
@@ -0,0 +1,122 @@
+package types
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSet verifies the basic operations of the Set type.
+func TestSet(t *testing.T) {
+	t.Parallel()
+
+	// AddAndHas ensures added elements are reported as present and duplicates are stored once.
+	t.Run("AddAndHas", func(t *testing.T) {
+		set := NewSet(1, 2, 2)
+		set.Add(3, 3)
+
+		assert.Equal(t, 3, set.Len(), "Expected duplicates to be stored once")
+		assert.True(t, set.Has(1), "Expected 1 to be present")
+		assert.True(t, set.Has(3), "Expected 3 to be present")
+		assert.False(t, set.Has(4), "Expected 4 to be absent")
+	})
+
+	// Remove ensures removed elements are no longer present and missing elements are ignored.
+	t.Run("Remove", func(t *testing.T) {
+		set := NewSet("a", "b", "c")
+		set.Remove("b", "missing")
+
+		assert.Equal(t, 2, set.Len(), "Expected one element to be removed")
+		assert.False(t, set.Has("b"), "Expected b to be removed")
+	})
+
+	// Union ensures the union contains the elements of both sets and the inputs stay unchanged.
+	t.Run("Union", func(t *testing.T) {
+		first, second := NewSet(1, 2), NewSet(2, 3)
+		union := first.Union(second)
+
+		assert.ElementsMatch(t, []int{1, 2, 3}, union.ToSlice(), "Unexpected union")
+		assert.ElementsMatch(t, []int{1, 2}, first.ToSlice(), "Expected the first set to stay unchanged")
+		assert.ElementsMatch(t, []int{2, 3}, second.ToSlice(), "Expected the second set to stay unchanged")
+	})
+
+	// Intersect ensures the intersection contains only the common elements.
+	t.Run("Intersect", func(t *testing.T) {
+		first, second := NewSet(1, 2, 3, 4), NewSet(3, 4, 5)
+
+		assert.ElementsMatch(t, []int{3, 4}, first.Intersect(second).ToSlice(), "Unexpected intersection")
+		assert.ElementsMatch(t, []int{3, 4}, second.Intersect(first).ToSlice(), "Expected intersection to be symmetric")
+		assert.Zero(t, first.Intersect(NewSet[int]()).Len(), "Expected empty intersection with an empty set")
+	})
+
+	// ToSliceEmpty ensures an empty set produces an empty slice.
+	t.Run("ToSliceEmpty", func(t *testing.T) {
+		assert.Empty(t, NewSet[string]().ToSlice(), "Expected an empty slice")
+	})
+
+	// ZeroValue ensures the zero value is an empty set ready to use.
+	t.Run("ZeroValue", func(t *testing.T) {
+		var set Set[int]
+		assert.False(t, set.Has(1), "Expected an empty set")
+
+		set.Add(1)
+		assert.True(t, set.Has(1), "Expected 1 to be added")
+		assert.Equal(t, 1, set.Union(&Set[int]{}).Len(), "Expected the union with an empty set")
+	})
+}
+
+// TestSyncSet verifies that SyncSet behaves like Set and is safe for concurrent use.
+func TestSyncSet(t *testing.T) {
+	t.Parallel()
+
+	// Operations ensures the wrapped operations behave like the plain Set.
+	t.Run("Operations", func(t *testing.T) {
+		first := NewSyncSet(1, 2, 3)
+		second := NewSyncSet(3, 4)
+		first.Remove(1)
+
+		assert.True(t, first.Has(2), "Expected 2 to be present")
+		assert.False(t, first.Has(1), "Expected 1 to be removed")
+		assert.ElementsMatch(t, []int{2, 3, 4}, first.Union(second).ToSlice(), "Unexpected union")
+		assert.ElementsMatch(t, []int{3}, first.Intersect(second).ToSlice(), "Unexpected intersection")
+	})
+
+	// ZeroValue ensures the zero value is an empty set ready to use.
+	t.Run("ZeroValue", func(t *testing.T) {
+		var set SyncSet[string]
+		set.Add("a")
+
+		assert.True(t, set.Has("a"), "Expected a to be added")
+		assert.Equal(t, 1, set.Len(), "Unexpected length")
+	})
+
+	// SelfUnion ensures combining a set with itself does not deadlock.
+	t.Run("SelfUnion", func(t *testing.T) {
+		set := NewSyncSet("a", "b")
+
+		assert.Equal(t, 2, set.Union(set).Len(), "Expected the union with itself to be the same set")
+		assert.Equal(t, 2, set.Intersect(set).Len(), "Expected the intersection with itself to be the same set")
+	})
+
+	// Concurrent ensures concurrent writers and readers do not race.
+	t.Run("Concurrent", func(t *testing.T) {
+		set := NewSyncSet[int]()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(2)
+			go func(v int) {
+				defer wg.Done()
+				set.Add(v)
+			}(i)
+			go func(v int) {
+				defer wg.Done()
+				_ = set.Has(v)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 100, set.Len(), "Expected every element to be added")
+	})
+}
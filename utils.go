@@ -1,6 +1,9 @@
 package common
 
-import "reflect"
+import (
+	"reflect"
+	"sync"
+)
 
 // GetRecoverError extracts an error from a recoverable panic.
 // It checks if the recovered value is an error type, and if so, returns it.
@@ -34,9 +37,86 @@ func GetType(v interface{}) reflect.Type {
 		return nil
 	}
 
-	// Use reflect.ValueOf to obtain the reflection value of the provided interface.
-	// Then use reflect.Indirect to get the value that the interface points to,
-	// effectively dereferencing it if it is a pointer. Finally, retrieve the type
-	// of the dereferenced value using the Type method.
-	return reflect.Indirect(reflect.ValueOf(v)).Type()
+	// Use reflect.TypeOf to obtain the dynamic type of the provided interface.
+	// If it is a pointer, return the type it points to. Working on the type rather than
+	// the value keeps typed nil pointers safe, as there is no value to dereference.
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+
+	return t
+}
+
+// IsNil reports whether v is nil, including typed nil values stored in an interface.
+// A plain comparison with nil is false for a nil pointer, map, slice, channel or function
+// wrapped in an interface, which IsNil reports as nil.
+func IsNil(v any) bool {
+	// An untyped nil interface holds no value at all.
+	if v == nil {
+		return true
+	}
+
+	// Only these kinds can hold a nil value, calling IsNil on other kinds panics.
+	value := reflect.ValueOf(v)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// KindOf returns the kind of the value v, dereferencing a pointer the same way as GetType.
+// It allows asking whether a value is a slice, map, function and so on without handling
+// the pointer case separately. For a nil value it returns reflect.Invalid.
+func KindOf(v any) reflect.Kind {
+	// Reuse GetType, which already handles nil values and typed nil pointers.
+	t := GetType(v)
+	if t == nil {
+		return reflect.Invalid
+	}
+
+	return t.Kind()
+}
+
+// typeNames caches the names computed by TypeName, keyed by reflect.Type.
+var typeNames sync.Map
+
+// TypeName returns the name of the dynamic type of v qualified with the full package path,
+// for example "github.com/SyntaxErrorLineNULL/common/types.Set[int]" or "*net/url.URL".
+// Unnamed types such as []int are returned as they are printed by reflect. For a nil value
+// it returns an empty string. Names are cached, so TypeName is cheap to call on hot paths.
+func TypeName(v any) string {
+	// A nil value has no type to name.
+	if v == nil {
+		return ""
+	}
+
+	t := reflect.TypeOf(v)
+
+	// Return the cached name if the type was seen before.
+	if name, ok := typeNames.Load(t); ok {
+		return name.(string)
+	}
+
+	name := qualifiedTypeName(t)
+	typeNames.Store(t, name)
+
+	return name
+}
+
+// qualifiedTypeName builds the name of t, qualifying named types with their full package path.
+func qualifiedTypeName(t reflect.Type) string {
+	// Name pointer types after the type they point to.
+	if t.Kind() == reflect.Pointer {
+		return "*" + qualifiedTypeName(t.Elem())
+	}
+
+	// Predeclared and unnamed types have no package path.
+	if t.PkgPath() == "" || t.Name() == "" {
+		return t.String()
+	}
+
+	return t.PkgPath() + "." + t.Name()
 }
@@ -2,6 +2,7 @@ package common
 
 import (
 	"errors"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -84,6 +85,7 @@ func TestGetType(t *testing.T) {
 		{name: "Struct type", input: struct{}{}, expected: reflect.TypeOf(struct{}{})},
 		{name: "Pointer to struct", input: &struct{}{}, expected: reflect.TypeOf(struct{}{})},
 		{name: "Nil value", input: nil, expected: nil},
+		{name: "Typed nil pointer", input: (*int)(nil), expected: reflect.TypeOf(0)},
 	}
 
 	// Iterate over each test case defined in the cases slice. Each test case will be executed
@@ -101,3 +103,91 @@ func TestGetType(t *testing.T) {
 		})
 	}
 }
+
+// TestIsNil verifies that IsNil detects untyped nil values as well as typed nil values
+// wrapped in an interface, while reporting non-nillable and non-nil values as not nil.
+func TestIsNil(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases covering nil interfaces, typed nils of every nillable kind and non-nil values.
+	cases := []struct {
+		name     string
+		input    any
+		expected bool
+	}{
+		{name: "Untyped nil", input: nil, expected: true},
+		{name: "Nil pointer", input: (*int)(nil), expected: true},
+		{name: "Nil map", input: map[string]int(nil), expected: true},
+		{name: "Nil slice", input: []int(nil), expected: true},
+		{name: "Nil channel", input: (chan int)(nil), expected: true},
+		{name: "Nil function", input: (func())(nil), expected: true},
+		{name: "Non-nil pointer", input: new(int), expected: false},
+		{name: "Empty slice", input: []int{}, expected: false},
+		{name: "Zero integer", input: 0, expected: false},
+		{name: "Zero struct", input: struct{}{}, expected: false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assert that IsNil reports the expected result for the input value.
+			assert.Equal(t, tt.expected, IsNil(tt.input), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestKindOf verifies that KindOf returns the kind of the value, dereferencing pointers
+// the same way as GetType and returning reflect.Invalid for nil.
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	// Define test cases for the kinds callers typically ask about.
+	cases := []struct {
+		name     string
+		input    any
+		expected reflect.Kind
+	}{
+		{name: "Slice", input: []int{1}, expected: reflect.Slice},
+		{name: "Map", input: map[string]int{}, expected: reflect.Map},
+		{name: "Function", input: func() {}, expected: reflect.Func},
+		{name: "Pointer to struct", input: &struct{}{}, expected: reflect.Struct},
+		{name: "Typed nil pointer", input: (*string)(nil), expected: reflect.String},
+		{name: "Nil value", input: nil, expected: reflect.Invalid},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Assert that KindOf reports the expected kind for the input value.
+			assert.Equal(t, tt.expected, KindOf(tt.input), "Test case %s failed", tt.name)
+		})
+	}
+}
+
+// TestTypeName verifies that TypeName qualifies named types with their full package path
+// and keeps predeclared, unnamed and pointer types readable.
+func TestTypeName(t *testing.T) {
+	t.Parallel()
+
+	type local struct{}
+
+	// Define test cases for predeclared, unnamed, named and pointer types.
+	cases := []struct {
+		name     string
+		input    any
+		expected string
+	}{
+		{name: "Predeclared type", input: 1, expected: "int"},
+		{name: "Unnamed type", input: []string{}, expected: "[]string"},
+		{name: "Named type", input: url.URL{}, expected: "net/url.URL"},
+		{name: "Pointer to named type", input: &url.URL{}, expected: "*net/url.URL"},
+		{name: "Local type", input: local{}, expected: "github.com/SyntaxErrorLineNULL/common.local"},
+		{name: "Nil value", input: nil, expected: ""},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Call TypeName twice to cover both the computed and the cached name.
+			assert.Equal(t, tt.expected, TypeName(tt.input), "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, TypeName(tt.input), "Test case %s failed on cached name", tt.name)
+		})
+	}
+}
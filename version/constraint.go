@@ -0,0 +1,152 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidConstraint is returned by ParseConstraint for malformed constraint expressions.
+var ErrInvalidConstraint = errors.New("version: invalid constraint")
+
+// comparator is a single condition on a version, such as ">=1.2.0".
+type comparator struct {
+	// op is the comparison operator.
+	op string
+	// version is the version compared against.
+	version Version
+}
+
+// check reports whether v satisfies the comparator.
+func (c comparator) check(v Version) bool {
+	result := v.Compare(c.version)
+
+	switch c.op {
+	case "=":
+		return result == 0
+	case "!=":
+		return result != 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	case "<":
+		return result < 0
+	default:
+		return result <= 0
+	}
+}
+
+// Constraint is a set of requirements on versions, such as ">=1.2.0 <2.0.0". Check reports whether a
+// version satisfies it, for example before invoking an external tool that must be recent enough.
+type Constraint struct {
+	// alternatives are the groups separated by "||"; a version must satisfy all comparators of one group.
+	alternatives [][]comparator
+	// text is the expression the constraint was parsed from.
+	text string
+}
+
+// ParseConstraint parses a constraint expression. Comparators separated by spaces or commas must all
+// be satisfied, and groups of them separated by "||" are alternatives. The operators are =, !=, >, >=,
+// <, <=, ~ and ^; a version without an operator must match exactly:
+//
+//   - "~1.2.3" allows patch updates: >=1.2.3 <1.3.0.
+//   - "^1.2.3" allows changes that do not modify the leftmost non-zero number: >=1.2.3 <2.0.0,
+//     while "^0.2.3" means >=0.2.3 <0.3.0.
+//
+// Versions are compared by precedence, so ">=1.2.0" is also satisfied by "2.0.0-rc.1".
+func ParseConstraint(expr string) (Constraint, error) {
+	constraint := Constraint{text: strings.TrimSpace(expr)}
+
+	for _, group := range strings.Split(expr, "||") {
+		fields := strings.FieldsFunc(group, func(r rune) bool { return r == ' ' || r == ',' || r == '\t' })
+		if len(fields) == 0 {
+			return Constraint{}, fmt.Errorf("%w: %q", ErrInvalidConstraint, expr)
+		}
+
+		var comparators []comparator
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Allow a space between the operator and the version, as in ">= 1.2.0".
+			if strings.Trim(field, "=!<>~^") == "" && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+
+			parsed, err := parseComparator(field)
+			if err != nil {
+				return Constraint{}, fmt.Errorf("%w: %q: %w", ErrInvalidConstraint, expr, err)
+			}
+			comparators = append(comparators, parsed...)
+		}
+		constraint.alternatives = append(constraint.alternatives, comparators)
+	}
+
+	return constraint, nil
+}
+
+// MustParseConstraint is like ParseConstraint but panics on error. It is intended for constants.
+func MustParseConstraint(expr string) Constraint {
+	c, err := ParseConstraint(expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// parseComparator parses a single operator and version into the comparators it stands for.
+func parseComparator(field string) ([]comparator, error) {
+	op := field[:len(field)-len(strings.TrimLeft(field, "=!<>~^"))]
+
+	v, err := Parse(field[len(op):])
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "", "=", "==":
+		return []comparator{{op: "=", version: v}}, nil
+	case "!=", ">", ">=", "<", "<=":
+		return []comparator{{op: op, version: v}}, nil
+	case "~":
+		upper := Version{Major: v.Major, Minor: v.Minor + 1}
+		return []comparator{{op: ">=", version: v}, {op: "<", version: upper}}, nil
+	case "^":
+		var upper Version
+		switch {
+		case v.Major > 0:
+			upper = Version{Major: v.Major + 1}
+		case v.Minor > 0:
+			upper = Version{Minor: v.Minor + 1}
+		default:
+			upper = Version{Patch: v.Patch + 1}
+		}
+		return []comparator{{op: ">=", version: v}, {op: "<", version: upper}}, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %q", op)
+	}
+}
+
+// Check reports whether v satisfies the constraint.
+func (c Constraint) Check(v Version) bool {
+	for _, group := range c.alternatives {
+		satisfied := true
+		for _, comparator := range group {
+			if !comparator.check(v) {
+				satisfied = false
+				break
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the expression the constraint was parsed from.
+func (c Constraint) String() string {
+	return c.text
+}
@@ -0,0 +1,56 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConstraint verifies parsing and checking constraint expressions.
+func TestConstraint(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		expr       string
+		satisfied  []string
+		violations []string
+	}{
+		{name: "Range", expr: ">=1.2.0 <2.0.0", satisfied: []string{"1.2.0", "1.9.9"}, violations: []string{"1.1.9", "2.0.0"}},
+		{name: "Commas and spaces", expr: ">= 1.2.0, < 2.0.0", satisfied: []string{"1.5.0"}, violations: []string{"2.1.0"}},
+		{name: "Exact", expr: "1.2.3", satisfied: []string{"1.2.3", "1.2.3+build"}, violations: []string{"1.2.4"}},
+		{name: "Not equal", expr: "!=1.2.3", satisfied: []string{"1.2.4"}, violations: []string{"1.2.3"}},
+		{name: "Tilde", expr: "~1.2.3", satisfied: []string{"1.2.3", "1.2.9"}, violations: []string{"1.3.0", "1.2.2"}},
+		{name: "Caret", expr: "^1.2.3", satisfied: []string{"1.2.3", "1.9.0"}, violations: []string{"2.0.0", "1.2.2"}},
+		{name: "Caret zero major", expr: "^0.2.3", satisfied: []string{"0.2.9"}, violations: []string{"0.3.0"}},
+		{name: "Caret zero minor", expr: "^0.0.3", satisfied: []string{"0.0.3"}, violations: []string{"0.0.4"}},
+		{name: "Alternatives", expr: "<1.0.0 || >=2.0.0", satisfied: []string{"0.9.0", "2.1.0"}, violations: []string{"1.5.0"}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			constraint, err := ParseConstraint(tt.expr)
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+
+			for _, v := range tt.satisfied {
+				assert.True(t, constraint.Check(MustParse(v)), "Expected %s to satisfy %s", v, tt.expr)
+			}
+			for _, v := range tt.violations {
+				assert.False(t, constraint.Check(MustParse(v)), "Expected %s to violate %s", v, tt.expr)
+			}
+		})
+	}
+
+	// Invalid ensures malformed expressions are rejected.
+	t.Run("Invalid", func(t *testing.T) {
+		for _, expr := range []string{"", ">=1.2.0 ||", "=>1.2.0", ">=abc", "~>1.0"} {
+			_, err := ParseConstraint(expr)
+			assert.ErrorIs(t, err, ErrInvalidConstraint, "Expected %q to be rejected", expr)
+		}
+	})
+
+	// String ensures the expression is kept.
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, ">=1.2.0 <2.0.0", MustParseConstraint(" >=1.2.0 <2.0.0 ").String(), "Unexpected expression")
+	})
+}
@@ -0,0 +1,197 @@
+package version
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidVersion is returned by Parse for strings that are not semantic versions.
+var ErrInvalidVersion = errors.New("version: invalid version")
+
+// Version is a semantic version as described by https://semver.org, such as "1.2.3-rc.1+build.5".
+type Version struct {
+	// Major is incremented for incompatible changes.
+	Major uint64
+	// Minor is incremented for backwards compatible features.
+	Minor uint64
+	// Patch is incremented for backwards compatible fixes.
+	Patch uint64
+	// Prerelease holds the dot-separated pre-release identifiers, such as "rc.1", or is empty for a release.
+	Prerelease string
+	// Build holds the build metadata, which is ignored when comparing versions.
+	Build string
+}
+
+// Parse parses a semantic version. A leading "v", as used by Go modules and git tags, is accepted, and
+// missing minor and patch numbers default to zero, so the output of tools such as "2.39" can be parsed.
+func Parse(s string) (Version, error) {
+	var v Version
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "v")
+
+	// Split off the build metadata first, as it may contain hyphens.
+	rest, v.Build, _ = strings.Cut(rest, "+")
+	rest, v.Prerelease, _ = strings.Cut(rest, "-")
+
+	parts := strings.Split(rest, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		if !isNumeric(part) {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+		}
+		*numbers[i] = n
+	}
+
+	if !validIdentifiers(v.Prerelease) || !validIdentifiers(v.Build) {
+		return Version{}, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	return v, nil
+}
+
+// MustParse is like Parse but panics on error. It is intended for constants in code and tests.
+func MustParse(s string) Version {
+	v, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+// String formats the version without a "v" prefix, such as "1.2.3-rc.1+build.5".
+func (v Version) String() string {
+	s := strconv.FormatUint(v.Major, 10) + "." + strconv.FormatUint(v.Minor, 10) + "." + strconv.FormatUint(v.Patch, 10)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v precedes, equals or follows other in semantic
+// version precedence. A pre-release precedes its release, and build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// Compare returns -1, 0 or +1 depending on whether a precedes, equals or follows b.
+// It can be passed to sorting functions that take a comparison.
+func Compare(a, b Version) int {
+	return a.Compare(b)
+}
+
+// Sort sorts the versions in ascending order of precedence, keeping equal versions in their order.
+func Sort(versions []Version) {
+	sort.SliceStable(versions, func(i, j int) bool { return versions[i].Compare(versions[j]) < 0 })
+}
+
+// compareUint compares two numbers.
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePrerelease compares pre-release strings: numeric identifiers compare numerically and below
+// alphanumeric ones, and a shorter list of equal identifiers comes first. A release has no pre-release
+// and follows all of its pre-releases.
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		l, r := left[i], right[i]
+		lNumeric, rNumeric := isNumeric(l), isNumeric(r)
+
+		switch {
+		case lNumeric && rNumeric:
+			// Compare by length first, which orders numbers of any size without parsing them.
+			if c := compareUint(uint64(len(l)), uint64(len(r))); c != 0 {
+				return c
+			}
+			if c := strings.Compare(l, r); c != 0 {
+				return c
+			}
+		case lNumeric:
+			return -1
+		case rNumeric:
+			return 1
+		default:
+			if c := strings.Compare(l, r); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareUint(uint64(len(left)), uint64(len(right)))
+}
+
+// isNumeric reports whether s is a non-empty string of digits without a leading zero.
+func isNumeric(s string) bool {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validIdentifiers reports whether s is empty or a dot-separated list of non-empty identifiers
+// made of ASCII letters, digits and hyphens.
+func validIdentifiers(s string) bool {
+	if s == "" {
+		return true
+	}
+
+	for _, identifier := range strings.Split(s, ".") {
+		if identifier == "" {
+			return false
+		}
+		for _, r := range identifier {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
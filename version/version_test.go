@@ -0,0 +1,81 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParse verifies parsing semantic versions.
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		input    string
+		expected Version
+		err      bool
+	}{
+		{name: "Full", input: "1.2.3", expected: Version{Major: 1, Minor: 2, Patch: 3}},
+		{name: "Prefix", input: "v10.0.1", expected: Version{Major: 10, Patch: 1}},
+		{name: "Partial", input: "2.39", expected: Version{Major: 2, Minor: 39}},
+		{name: "Major only", input: "3", expected: Version{Major: 3}},
+		{
+			name:     "Pre-release and build",
+			input:    "1.0.0-rc.1-fix+build.5",
+			expected: Version{Major: 1, Prerelease: "rc.1-fix", Build: "build.5"},
+		},
+		{name: "Empty", input: "", err: true},
+		{name: "Too many numbers", input: "1.2.3.4", err: true},
+		{name: "Leading zero", input: "01.2.3", err: true},
+		{name: "Letters", input: "1.x", err: true},
+		{name: "Empty identifier", input: "1.0.0-rc..1", err: true},
+		{name: "Invalid character", input: "1.0.0+build_5", err: true},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := Parse(tt.input)
+
+			if tt.err {
+				assert.ErrorIs(t, err, ErrInvalidVersion, "Test case %s failed", tt.name)
+				return
+			}
+			assert.NoError(t, err, "Test case %s failed", tt.name)
+			assert.Equal(t, tt.expected, v, "Test case %s failed", tt.name)
+		})
+	}
+
+	// String ensures versions format without the prefix.
+	t.Run("String", func(t *testing.T) {
+		assert.Equal(t, "1.0.0-rc.1+build.5", MustParse("v1.0.0-rc.1+build.5").String(), "Unexpected format")
+		assert.Equal(t, "2.39.0", MustParse("2.39").String(), "Unexpected format")
+	})
+}
+
+// TestCompare verifies semantic version precedence.
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	// The precedence example of the specification, in ascending order.
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, b := MustParse(ordered[i-1]), MustParse(ordered[i])
+		assert.Equal(t, -1, Compare(a, b), "Expected %s to precede %s", a, b)
+		assert.Equal(t, 1, Compare(b, a), "Expected %s to follow %s", b, a)
+	}
+
+	assert.Equal(t, 0, Compare(MustParse("1.0.0+a"), MustParse("1.0.0+b")), "Expected build metadata to be ignored")
+
+	// Sort ensures versions are sorted by precedence.
+	t.Run("Sort", func(t *testing.T) {
+		versions := []Version{MustParse("1.10.0"), MustParse("1.2.0"), MustParse("1.2.0-rc.1"), MustParse("0.9.0")}
+		Sort(versions)
+
+		assert.Equal(t, []Version{MustParse("0.9.0"), MustParse("1.2.0-rc.1"), MustParse("1.2.0"), MustParse("1.10.0")}, versions, "Unexpected order")
+	})
+}